@@ -0,0 +1,17 @@
+package main
+
+// ActiveApp describes the application that was frontmost when the
+// overlay was summoned
+type ActiveApp struct {
+	Name string `json:"name"`
+	// ID is the bundle identifier on macOS, the executable path on
+	// Windows and the WM_CLASS on Linux
+	ID          string `json:"id"`
+	WindowTitle string `json:"windowTitle"`
+	PID         int    `json:"pid"`
+}
+
+// GetActiveApp returns the frontmost application
+func (a *App) GetActiveApp() (ActiveApp, error) {
+	return activeApp()
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+const activeAppScript = `tell application "System Events"
+	set p to first application process whose frontmost is true
+	set t to ""
+	try
+		set t to name of front window of p
+	end try
+	return (name of p) & linefeed & (bundle identifier of p) & linefeed & (unix id of p) & linefeed & t
+end tell`
+
+func activeApp() (ActiveApp, error) {
+	out, err := exec.Command("osascript", "-e", activeAppScript).Output()
+	if err != nil {
+		return ActiveApp{}, err
+	}
+	parts := strings.SplitN(strings.TrimRight(string(out), "\n"), "\n", 4)
+	for len(parts) < 4 {
+		parts = append(parts, "")
+	}
+	pid, _ := strconv.Atoi(parts[2])
+	return ActiveApp{Name: parts[0], ID: parts[1], PID: pid, WindowTitle: parts[3]}, nil
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// activeApp relies on xdotool, which is only available under X11
+func activeApp() (ActiveApp, error) {
+	xdotool := func(args ...string) (string, error) {
+		out, err := exec.Command("xdotool", append([]string{"getactivewindow"}, args...)...).Output()
+		return strings.TrimSpace(string(out)), err
+	}
+
+	pidStr, err := xdotool("getwindowpid")
+	if err != nil {
+		return ActiveApp{}, err
+	}
+	pid, _ := strconv.Atoi(pidStr)
+	app := ActiveApp{PID: pid}
+	app.WindowTitle, _ = xdotool("getwindowname")
+	app.ID, _ = xdotool("getwindowclassname")
+	if comm, err := os.ReadFile("/proc/" + pidStr + "/comm"); err == nil {
+		app.Name = strings.TrimSpace(string(comm))
+	}
+	return app, nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	procGetForegroundWindow    = user32.NewProc("GetForegroundWindow")
+	procGetWindowTextW         = user32.NewProc("GetWindowTextW")
+	procQueryFullProcessImageW = kernel32.NewProc("QueryFullProcessImageNameW")
+)
+
+const processQueryLimitedInformation = 0x1000
+
+func activeApp() (ActiveApp, error) {
+	hwnd, _, _ := procGetForegroundWindow.Call()
+	if hwnd == 0 {
+		return ActiveApp{}, fmt.Errorf("no foreground window")
+	}
+
+	title := make([]uint16, 512)
+	procGetWindowTextW.Call(hwnd, uintptr(unsafe.Pointer(&title[0])), uintptr(len(title)))
+
+	var pid uint32
+	procGetWindowThreadProcessId.Call(hwnd, uintptr(unsafe.Pointer(&pid)))
+
+	app := ActiveApp{WindowTitle: syscall.UTF16ToString(title), PID: int(pid)}
+
+	h, err := syscall.OpenProcess(processQueryLimitedInformation, false, pid)
+	if err != nil {
+		return app, nil
+	}
+	defer syscall.CloseHandle(h)
+
+	path := make([]uint16, syscall.MAX_PATH)
+	size := uint32(len(path))
+	if r, _, _ := procQueryFullProcessImageW.Call(uintptr(h), 0, uintptr(unsafe.Pointer(&path[0])), uintptr(unsafe.Pointer(&size))); r != 0 {
+		app.ID = syscall.UTF16ToString(path[:size])
+		app.Name = strings.TrimSuffix(filepath.Base(app.ID), filepath.Ext(app.ID))
+	}
+	return app, nil
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2"
+	"github.com/wailsapp/wails/v2/pkg/options"
+	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
+	"github.com/wailsapp/wails/v2/pkg/options/mac"
+	"github.com/wailsapp/wails/v2/pkg/options/windows"
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ToggleAnnotation opens the full-screen annotation overlay, or toggles
+// click-through when it is already open so the user can interact with the
+// apps underneath without losing the drawing
+func (a *App) ToggleAnnotation() error {
+	a.annotationMu.Lock()
+	defer a.annotationMu.Unlock()
+
+	if a.annotation.running() {
+		return a.annotation.send("toggle-click-through", nil)
+	}
+
+	if err := a.requirePermission(capScreenCapture); err != nil {
+		return err
+	}
+	// Capture first so the screenshot shows the screen without the overlay
+	screenshot, err := captureScreenToTemp()
+	if err != nil {
+		return err
+	}
+	args := map[string]string{"screenshot": screenshot}
+	if display, ok := a.overlayDisplay(); ok {
+		if frame, err := json.Marshal(display.Bounds); err == nil {
+			args["frame"] = string(frame)
+		}
+	}
+	child, err := spawnChildWindow("annotation", args, nil)
+	if err != nil {
+		os.Remove(screenshot)
+		return err
+	}
+	a.annotation = child
+	go func() {
+		<-child.done
+		os.Remove(screenshot)
+		eventAnnotationClosed.emit(a.ctx, struct{}{})
+	}()
+	eventAnnotationOpened.emit(a.ctx, struct{}{})
+	return nil
+}
+
+// overlayDisplay is the display the overlay is on, or while it is hidden
+// the one under the cursor, where it would open
+func (a *App) overlayDisplay() (Display, bool) {
+	displays, err := listDisplays()
+	if err != nil {
+		return Display{}, false
+	}
+	if a.GetWindowState().Visible {
+		if frame, err := ownWindowFrame(); err == nil {
+			return displayFor(displays, frame)
+		}
+	}
+	return cursorDisplay(displays)
+}
+
+// CloseAnnotation closes the annotation overlay if it is open
+func (a *App) CloseAnnotation() {
+	a.annotationMu.Lock()
+	child := a.annotation
+	a.annotationMu.Unlock()
+	child.close()
+}
+
+// AnnotationWindow is bound in the annotation child process
+type AnnotationWindow struct {
+	ctx        context.Context
+	screenshot string
+	// frame is the bounds of the display to cover, when known
+	frame Rect
+
+	mu           sync.Mutex
+	clickThrough bool
+}
+
+// runAnnotationWindow runs the transparent, frameless annotation window
+func runAnnotationWindow(flags map[string]string) error {
+	w := &AnnotationWindow{screenshot: flags["screenshot"]}
+	if frame, ok := flags["frame"]; ok {
+		if err := json.Unmarshal([]byte(frame), &w.frame); err != nil {
+			println("Error reading annotation frame:", err.Error())
+		}
+	}
+	return wails.Run(&options.App{
+		Title:            "Overlae Annotation",
+		Width:            1280,
+		Height:           800,
+		Frameless:        true,
+		AlwaysOnTop:      true,
+		BackgroundColour: &options.RGBA{R: 0, G: 0, B: 0, A: 0},
+		AssetServer: &assetserver.Options{
+			Assets: assets,
+		},
+		Mac: &mac.Options{
+			WebviewIsTransparent: true,
+			WindowIsTranslucent:  true,
+		},
+		Windows: &windows.Options{
+			WebviewIsTransparent: true,
+		},
+		OnStartup: w.startup,
+		Bind: []interface{}{
+			w,
+			&WindowInfo{kind: "annotation"},
+		},
+	})
+}
+
+func (w *AnnotationWindow) startup(ctx context.Context) {
+	w.ctx = ctx
+
+	// Cover the overlay's screen; where frames cannot be set, cover the
+	// screen the window opened on
+	if w.frame == (Rect{}) || setOwnWindowFrame(w.frame) != nil {
+		if screens, err := wailsruntime.ScreenGetAll(ctx); err == nil {
+			for _, s := range screens {
+				if s.IsCurrent {
+					wailsruntime.WindowSetSize(ctx, s.Size.Width, s.Size.Height)
+					wailsruntime.WindowSetPosition(ctx, 0, 0)
+				}
+			}
+		}
+	}
+
+	go readParentCommands(func(cmd childCommand) {
+		switch cmd.Cmd {
+		case "toggle-click-through":
+			w.mu.Lock()
+			enabled := !w.clickThrough
+			w.mu.Unlock()
+			_ = w.SetClickThrough(enabled)
+		case "close":
+			wailsruntime.Quit(ctx)
+		}
+	}, func() { wailsruntime.Quit(ctx) })
+}
+
+// SetClickThrough switches between drawing and letting clicks reach the
+// apps underneath
+func (w *AnnotationWindow) SetClickThrough(enabled bool) error {
+	if err := setClickThrough(enabled); err != nil {
+		return err
+	}
+	w.mu.Lock()
+	w.clickThrough = enabled
+	w.mu.Unlock()
+	eventClickThrough.emit(w.ctx, enabled)
+	return nil
+}
+
+// ExportAnnotated composites the drawing layer (a PNG data URL from the
+// canvas) over the screenshot taken when the overlay opened and saves it.
+// It returns the saved path, or an empty string if the dialog was cancelled.
+func (w *AnnotationWindow) ExportAnnotated(layerDataURL string) (string, error) {
+	base, err := decodePNGFile(w.screenshot)
+	if err != nil {
+		return "", fmt.Errorf("reading screenshot: %w", err)
+	}
+	layer, err := decodePNGDataURL(layerDataURL)
+	if err != nil {
+		return "", fmt.Errorf("reading annotations: %w", err)
+	}
+
+	out := image.NewRGBA(base.Bounds())
+	draw.Draw(out, out.Bounds(), base, base.Bounds().Min, draw.Src)
+	// The canvas is in logical pixels while the screenshot is in physical
+	// pixels, so scale the layer up on HiDPI screens
+	draw.Draw(out, out.Bounds(), scaleNearest(layer, out.Bounds().Dx(), out.Bounds().Dy()), image.Point{}, draw.Over)
+
+	path, err := wailsruntime.SaveFileDialog(w.ctx, wailsruntime.SaveDialogOptions{
+		DefaultFilename: "annotated-" + time.Now().Format("20060102-150405") + ".png",
+		Filters:         []wailsruntime.FileFilter{{DisplayName: "PNG image", Pattern: "*.png"}},
+	})
+	if err != nil || path == "" {
+		return "", err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if err := png.Encode(f, out); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// Close quits the annotation window
+func (w *AnnotationWindow) Close() {
+	wailsruntime.Quit(w.ctx)
+}
+
+func decodePNGFile(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return png.Decode(f)
+}
+
+func decodePNGDataURL(dataURL string) (image.Image, error) {
+	_, encoded, ok := strings.Cut(dataURL, ",")
+	if !ok {
+		return nil, fmt.Errorf("not a data URL")
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	return png.Decode(bytes.NewReader(data))
+}
+
+// scaleNearest resizes img to w x h with nearest-neighbour sampling
+func scaleNearest(img image.Image, w, h int) image.Image {
+	b := img.Bounds()
+	if b.Dx() == w && b.Dy() == h {
+		return img
+	}
+	out := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		sy := b.Min.Y + y*b.Dy()/h
+		for x := 0; x < w; x++ {
+			out.Set(x, y, img.At(b.Min.X+x*b.Dx()/w, sy))
+		}
+	}
+	return out
+}
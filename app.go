@@ -2,11 +2,14 @@ package main
 
 import (
 	"context"
+	"net/http"
 	"runtime"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/wailsapp/wails/v2/pkg/menu"
 	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
-	"golang.design/x/hotkey"
 )
 
 var (
@@ -22,38 +25,252 @@ func init() {
 // App struct
 type App struct {
 	ctx context.Context
-	hk  *hotkey.Hotkey
+
+	hotkeysMu       sync.Mutex
+	hotkeys         []*hotkeyBinding
+	hotkeysPaused   bool
+	overlayHotkeys  bool
+	hotkeysMenuItem *menu.MenuItem
+
+	settingsMu sync.RWMutex
+	settings   Settings
+
+	grantsMu sync.Mutex
+	grants   map[string]bool
+
+	clipboardMu      sync.Mutex
+	clipboardClear   *time.Timer
+	clipboardOwn     []string
+	clipboardHistory []clipboardCopy
+
+	syncMu sync.Mutex
+
+	showMu   sync.Mutex
+	lastShow ShowContext
+
+	pageMu sync.Mutex
+	page   PageContext
+
+	annotationMu sync.Mutex
+	annotation   *childWindow
+
+	companionMu     sync.Mutex
+	companion       *childWindow
+	companionStatus CompanionStatus
+
+	detachedMu sync.Mutex
+	detached   map[string]*detachedEntry
+
+	mouseMu   sync.Mutex
+	mouseStop func()
+
+	wakeMu       sync.Mutex
+	wakeStop     func()
+	wakeStatus   WakeWordStatus
+	wakeMenuItem *menu.MenuItem
+
+	dictionaryMu sync.Mutex
+	dictionary   map[string]DictionaryEntry
+
+	vectorsMu sync.Mutex
+	vectors   *vectorIndex
+
+	workspaceMu  sync.Mutex
+	workspaceMap *WorkspaceMap
+
+	quicklinksMu sync.Mutex
+
+	netToolsMu sync.Mutex
+	netTools   map[string]context.CancelFunc
+
+	queryMu sync.Mutex
+	query   *pendingQuery
+
+	searchMu sync.Mutex
+	searchID string
+
+	selectionMu      sync.Mutex
+	selectionHint    *childWindow
+	selectionShow    ShowContext
+	selectionFrame   Rect
+	selectionReading bool
+
+	selfCheckMu sync.Mutex
+	selfCheck   *SelfCheck
+
+	automationMu     sync.Mutex
+	automationHooks  []AutomationHook
+	automationLoaded bool
+	automationRuns   []HookRun
+
+	processMu        sync.Mutex
+	processSamples   map[int]processSample
+	processSampledAt time.Time
+
+	recentMu    sync.Mutex
+	recentApps  []RecentItem
+	recentFiles *recentFiles
+
+	downloadsMu sync.Mutex
+	downloads   []*Download
+
+	recordingMu sync.Mutex
+	recording   bool
+
+	captureMu   sync.Mutex
+	captureStop func()
+
+	blurMu    sync.Mutex
+	blurTimer *time.Timer
+
+	windowMu            sync.Mutex
+	windowState         WindowState
+	alwaysOnTopMenuItem *menu.MenuItem
+	fullscreenMenuItem  *menu.MenuItem
+
+	requestsMu      sync.Mutex
+	requests        map[string]*inflightRequest
+	requestQueue    []string
+	requestsRunning int
+
+	history     *historyStore
+	tagsMu      sync.Mutex
+	templatesMu sync.Mutex
+	personasMu  sync.Mutex
+	inputMu     sync.Mutex
+	inputCursor int
+	inputDraft  string
+
+	sessionMu    sync.Mutex
+	sessionID    string
+	sessionKeyID string
+	// sessionPersonaID is the persona of the current session
+	sessionPersonaID string
+
+	keysMu   sync.Mutex
+	modelsMu sync.Mutex
+
+	responseCacheMu sync.Mutex
+	responseCache   map[string]cachedResponse
+
+	apiMu     sync.Mutex
+	apiServer *http.Server
+
+	watchCancel context.CancelFunc
+
+	shutdownMu    sync.Mutex
+	shutdownHooks []shutdownHook
+	shuttingDown  atomic.Bool
 }
 
 // NewApp creates a new App application struct
 func NewApp() *App {
-	return &App{}
+	settings, err := loadSettings()
+	if err != nil {
+		println("Error loading settings:", err.Error())
+	}
+	a := &App{settings: settings, windowState: WindowState{AlwaysOnTop: true}, inputCursor: -1, sessionID: newID()}
+	if a.grants, err = loadGrants(); err != nil {
+		println("Error loading permissions:", err.Error())
+	}
+	if path, err := configPath(historyFile); err == nil {
+		if a.history, err = openHistoryStore(path); err != nil {
+			println("Error opening history:", err.Error())
+		}
+	}
+	return a
 }
 
 // startup is called when the app starts. The context is saved
 // so we can call the runtime methods
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
+	a.registerShutdownHooks()
+	a.restoreDetachedWindows()
+	a.applySpellCheck()
+
+	// Watchers get their own context so shutdown can stop them first
+	watchCtx, cancel := context.WithCancel(ctx)
+	a.watchCancel = cancel
+	go a.watchDisplays(watchCtx)
+	go a.watchTheme(watchCtx)
+	go a.watchAudioDevices(watchCtx)
+	go a.watchClipboard(watchCtx)
+	go a.watchSelection(watchCtx)
+	go a.startupSelfCheck()
+	go a.startupOnboarding()
+	go a.runAutomation(watchCtx)
+	go a.watchStorage(watchCtx)
+
+	if err := a.startLocalAPI(); err != nil {
+		println("Error starting local API:", err.Error())
+	}
 
 	hotkeyInitOnce.Do(func() {
 		// Initialize hotkey on the main OS thread
 		runtime.LockOSThread()
 		defer runtime.UnlockOSThread()
 
-		// Register Cmd+G on macOS (ModCmd) or Ctrl+G on Windows/Linux
-		hk := hotkey.New([]hotkey.Modifier{hotkey.ModCmd}, hotkey.KeyG)
-		if err := hk.Register(); err != nil {
-			return
+		// Register Cmd+G on macOS or Ctrl+G on Windows/Linux
+		_ = a.bindHotkey("show-overlay", defaultHotkeys["show-overlay"], func() {
+			a.emitShowVariant(overlayVariant{name: "show-overlay"})
+		})
+		// Shift, Alt and Alt+Shift variants open clipboard, favorites and
+		// note modes
+		a.bindOverlayVariants()
+
+		// Cmd+Shift+A opens the annotation overlay and toggles click-through
+		_ = a.bindHotkey("annotation", defaultHotkeys["annotation"], func() {
+			if err := a.withPermission(capScreenCapture, a.ToggleAnnotation); err != nil {
+				eventAnnotationError.emit(ctx, err.Error())
+			}
+		})
+
+		_ = a.bindHotkey("paste-last", defaultHotkeys["paste-last"], func() {
+			if err := a.withPermission(capKeystrokes, a.PasteLastResponse); err != nil {
+				println("Error pasting last response:", err.Error())
+			}
+		})
+		_ = a.bindHotkey("screenshot-prompt", defaultHotkeys["screenshot-prompt"], func() {
+			err := a.withPermission(capScreenCapture, func() error {
+				_, err := a.ScreenshotToPrompt()
+				return err
+			})
+			if err != nil {
+				println("Error sending screenshot:", err.Error())
+			}
+		})
+		_ = a.bindHotkey("translate", defaultHotkeys["translate"], func() {
+			err := a.withPermission(capKeystrokes, func() error {
+				_, err := a.TranslateSelection()
+				return err
+			})
+			if err != nil {
+				println("Error translating selection:", err.Error())
+			}
+		})
+		a.bindZoomHotkeys()
+
+		if a.GetSettings().SnapHotkeys {
+			a.registerSnapHotkeys()
+		}
+		if a.GetSettings().WindowHotkeys {
+			a.registerWindowHotkeys()
 		}
-		a.hk = hk
 
-		// Start hotkey listener in a separate goroutine
-		go func() {
-			for range hk.Keydown() {
-				// Call ShowOverlay directly from the main thread
-				wailsruntime.EventsEmit(ctx, "show-overlay")
+		go a.watchKeyboardLayout(watchCtx)
+
+		if cfg := a.GetSettings().WakeWord; cfg.Enabled {
+			if err := a.startWakeWord(cfg); err != nil {
+				println("Error starting wake word detector:", err.Error())
 			}
-		}()
+		}
+
+		if spec := a.GetSettings().MouseTrigger; spec != "" {
+			if err := a.startMouseTrigger(spec); err != nil {
+				println("Error starting mouse trigger:", err.Error())
+			}
+		}
 
 		// Start event handler
 		go func() {
@@ -64,25 +281,57 @@ func (a *App) startup(ctx context.Context) {
 	})
 }
 
+// emitShowOverlay asks the frontend to open the overlay. The context is
+// resolved here while the user's app is still frontmost.
+func (a *App) emitShowOverlay(reason string) {
+	eventShowOverlay.emit(a.ctx, a.showOverlayEvent(reason))
+}
+
+func (a *App) showOverlayEvent(reason string) ShowOverlayEvent {
+	return a.overlayEventFor(reason, a.resolveShowContext())
+}
+
+// overlayEventFor builds a show-overlay event for an already resolved
+// context
+func (a *App) overlayEventFor(reason string, show ShowContext) ShowOverlayEvent {
+	ev := ShowOverlayEvent{Reason: reason, Context: show}
+	if displays, err := listDisplays(); err == nil {
+		if d, ok := cursorDisplay(displays); ok {
+			ev.Monitor = d.ID
+		}
+	}
+	return ev
+}
+
 func (a *App) ShowOverlay() {
+	a.cancelBlurHide()
+	// Restore the position remembered for the display under the cursor
+	placed := a.placeOverlay()
 	wailsruntime.WindowShow(a.ctx)
-	wailsruntime.WindowCenter(a.ctx)
-	wailsruntime.WindowSetAlwaysOnTop(a.ctx, true)
+	if !placed {
+		wailsruntime.WindowCenter(a.ctx)
+	}
+	wailsruntime.WindowSetAlwaysOnTop(a.ctx, a.GetWindowState().AlwaysOnTop)
+	a.updateWindowState(func(s *WindowState) { s.Visible = true })
 }
 
 func (a *App) HideOverlay() {
+	a.rememberPlacement()
 	wailsruntime.WindowHide(a.ctx)
-}
-
-// Let users click outside to close
-func (a *App) OnWindowBlur() {
-	a.HideOverlay()
+	a.updateWindowState(func(s *WindowState) { s.Visible = false })
 }
 
 // Cleanup is called when the app is about to exit
 func (a *App) Cleanup(ctx context.Context) bool {
-	if a.hk != nil {
-		_ = a.hk.Unregister()
+	if a.shuttingDown.CompareAndSwap(false, true) {
+		a.runShutdown(shutdownTimeout)
 	}
 	return false // false means allow the app to close
 }
+
+// stopWatchers ends the background pollers started in startup
+func (a *App) stopWatchers() {
+	if a.watchCancel != nil {
+		a.watchCancel()
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+/*
+#cgo LDFLAGS: -framework ApplicationServices -framework CoreFoundation
+#include <ApplicationServices/ApplicationServices.h>
+
+// ovAppWindow returns the focused, or else main, window of pid; the
+// caller releases it
+static AXUIElementRef ovAppWindow(int pid) {
+	AXUIElementRef app = AXUIElementCreateApplication(pid);
+	CFTypeRef win = NULL;
+	if (AXUIElementCopyAttributeValue(app, kAXFocusedWindowAttribute, &win) != kAXErrorSuccess || win == NULL) {
+		AXUIElementCopyAttributeValue(app, kAXMainWindowAttribute, &win);
+	}
+	CFRelease(app);
+	return (AXUIElementRef)win;
+}
+
+// ovGetAppWindowFrame returns -1 without the Accessibility permission and
+// 0 when pid has no window
+static int ovGetAppWindowFrame(int pid, int *x, int *y, int *w, int *h) {
+	if (!AXIsProcessTrusted()) return -1;
+	AXUIElementRef win = ovAppWindow(pid);
+	if (win == NULL) return 0;
+	CFTypeRef pos = NULL, size = NULL;
+	CGPoint p = CGPointZero;
+	CGSize s = CGSizeZero;
+	if (AXUIElementCopyAttributeValue(win, kAXPositionAttribute, &pos) == kAXErrorSuccess) {
+		AXValueGetValue((AXValueRef)pos, kAXValueCGPointType, &p);
+		CFRelease(pos);
+	}
+	if (AXUIElementCopyAttributeValue(win, kAXSizeAttribute, &size) == kAXErrorSuccess) {
+		AXValueGetValue((AXValueRef)size, kAXValueCGSizeType, &s);
+		CFRelease(size);
+	}
+	CFRelease(win);
+	*x = p.x;
+	*y = p.y;
+	*w = s.width;
+	*h = s.height;
+	return 1;
+}
+
+// ovSetAppWindowFrame positions before and after resizing, since apps
+// clamp a size that doesn't fit the display the window is still on
+static int ovSetAppWindowFrame(int pid, int x, int y, int w, int h) {
+	if (!AXIsProcessTrusted()) return -1;
+	AXUIElementRef win = ovAppWindow(pid);
+	if (win == NULL) return 0;
+	CGPoint p = CGPointMake(x, y);
+	CGSize s = CGSizeMake(w, h);
+	AXValueRef pos = AXValueCreate(kAXValueCGPointType, &p);
+	AXValueRef size = AXValueCreate(kAXValueCGSizeType, &s);
+	AXUIElementSetAttributeValue(win, kAXPositionAttribute, pos);
+	AXUIElementSetAttributeValue(win, kAXSizeAttribute, size);
+	AXError err = AXUIElementSetAttributeValue(win, kAXPositionAttribute, pos);
+	CFRelease(pos);
+	CFRelease(size);
+	CFRelease(win);
+	return err == kAXErrorSuccess ? 1 : 0;
+}
+*/
+import "C"
+
+import "errors"
+
+// appWindow is the owning process; the Accessibility API looks its focused
+// window up again for every call
+type appWindow = int
+
+var errAppWindowUntrusted = errors.New("arranging windows needs the Accessibility permission")
+
+// appWindowFrame returns pid's focused window in desktop points, which
+// share the top-left origin of Rect
+func appWindowFrame(pid int) (appWindow, Rect, error) {
+	var x, y, w, h C.int
+	switch C.ovGetAppWindowFrame(C.int(pid), &x, &y, &w, &h) {
+	case -1:
+		return 0, Rect{}, errAppWindowUntrusted
+	case 0:
+		return 0, Rect{}, errors.New("the app has no window to arrange")
+	}
+	return pid, Rect{X: int(x), Y: int(y), Width: int(w), Height: int(h)}, nil
+}
+
+func setAppWindowFrame(pid appWindow, r Rect) error {
+	switch C.ovSetAppWindowFrame(C.int(pid), C.int(r.X), C.int(r.Y), C.int(r.Width), C.int(r.Height)) {
+	case -1:
+		return errAppWindowUntrusted
+	case 0:
+		return errors.New("the app refused to move its window")
+	}
+	return nil
+}
+
+// maximizeAppWindow fills the work area; macOS has no maximized state
+// short of full screen
+func maximizeAppWindow(pid appWindow, work Rect) error {
+	return setAppWindowFrame(pid, work)
+}
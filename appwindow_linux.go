@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// appWindow is an X11 window ID as xdotool prints it
+type appWindow = string
+
+func runXdotool(args ...string) (string, error) {
+	out, err := exec.Command("xdotool", args...).Output()
+	return strings.TrimSpace(string(out)), err
+}
+
+// appWindowFrame finds pid's window with xdotool, preferring the active
+// one, and returns its geometry. Like activeApp it needs X11.
+func appWindowFrame(pid int) (appWindow, Rect, error) {
+	win, err := runXdotool("getactivewindow")
+	if err != nil {
+		return "", Rect{}, err
+	}
+	if owner, _ := runXdotool("getwindowpid", win); owner != strconv.Itoa(pid) {
+		list, err := runXdotool("search", "--onlyvisible", "--pid", strconv.Itoa(pid))
+		if err != nil || list == "" {
+			return "", Rect{}, errors.New("the app has no window to arrange")
+		}
+		win = strings.Fields(list)[0]
+	}
+	out, err := runXdotool("getwindowgeometry", "--shell", win)
+	if err != nil {
+		return "", Rect{}, err
+	}
+	var r Rect
+	for _, line := range strings.Split(out, "\n") {
+		k, v, _ := strings.Cut(line, "=")
+		n, _ := strconv.Atoi(v)
+		switch k {
+		case "X":
+			r.X = n
+		case "Y":
+			r.Y = n
+		case "WIDTH":
+			r.Width = n
+		case "HEIGHT":
+			r.Height = n
+		}
+	}
+	return win, r, nil
+}
+
+// setMaximized toggles the EWMH maximized state through wmctrl, when
+// installed
+func setMaximized(win appWindow, on bool) error {
+	op := "remove"
+	if on {
+		op = "add"
+	}
+	return exec.Command("wmctrl", "-i", "-r", win, "-b", op+",maximized_vert,maximized_horz").Run()
+}
+
+func setAppWindowFrame(win appWindow, r Rect) error {
+	// A maximized window ignores moves and resizes
+	_ = setMaximized(win, false)
+	if _, err := runXdotool("windowsize", win, strconv.Itoa(r.Width), strconv.Itoa(r.Height), "windowmove", win, strconv.Itoa(r.X), strconv.Itoa(r.Y)); err != nil {
+		return fmt.Errorf("xdotool: %w", err)
+	}
+	return nil
+}
+
+// maximizeAppWindow prefers the window manager's maximized state and falls
+// back to filling the work area
+func maximizeAppWindow(win appWindow, work Rect) error {
+	if setMaximized(win, true) == nil {
+		return nil
+	}
+	return setAppWindowFrame(win, work)
+}
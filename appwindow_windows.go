@@ -0,0 +1,109 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	dwmapi                    = syscall.NewLazyDLL("dwmapi.dll")
+	procDwmGetWindowAttribute = dwmapi.NewProc("DwmGetWindowAttribute")
+	procIsWindowVisible       = user32.NewProc("IsWindowVisible")
+	procIsZoomed              = user32.NewProc("IsZoomed")
+	procShowWindow            = user32.NewProc("ShowWindow")
+	procGetWindow             = user32.NewProc("GetWindow")
+)
+
+const (
+	swMaximize = 3
+	swRestore  = 9
+	gwOwner    = 4
+	// dwmwaExtendedFrameBounds is the visible frame, without the invisible
+	// resize borders GetWindowRect includes
+	dwmwaExtendedFrameBounds = 9
+)
+
+// appWindow is a top-level window handle
+type appWindow = uintptr
+
+func windowPID(hwnd uintptr) uint32 {
+	var pid uint32
+	procGetWindowThreadProcessId.Call(hwnd, uintptr(unsafe.Pointer(&pid)))
+	return pid
+}
+
+// findAppWindow returns the foreground window if pid owns it, otherwise
+// pid's topmost visible unowned window
+func findAppWindow(pid int) (uintptr, error) {
+	if hwnd, _, _ := procGetForegroundWindow.Call(); hwnd != 0 && windowPID(hwnd) == uint32(pid) {
+		return hwnd, nil
+	}
+	var found uintptr
+	enumWindows(func(hwnd uintptr) bool {
+		if windowPID(hwnd) != uint32(pid) {
+			return true
+		}
+		if visible, _, _ := procIsWindowVisible.Call(hwnd); visible == 0 {
+			return true
+		}
+		if owner, _, _ := procGetWindow.Call(hwnd, gwOwner); owner != 0 {
+			return true
+		}
+		found = hwnd
+		return false
+	})
+	if found == 0 {
+		return 0, errors.New("the app has no window to arrange")
+	}
+	return found, nil
+}
+
+// frameBorders returns how far GetWindowRect extends past the visible
+// frame on each side
+func frameBorders(hwnd uintptr) (outer, visible win32Rect) {
+	procGetWindowRect.Call(hwnd, uintptr(unsafe.Pointer(&outer)))
+	visible = outer
+	procDwmGetWindowAttribute.Call(hwnd, dwmwaExtendedFrameBounds, uintptr(unsafe.Pointer(&visible)), unsafe.Sizeof(visible))
+	return outer, visible
+}
+
+// appWindowFrame returns the visible frame of pid's window in physical
+// pixels
+func appWindowFrame(pid int) (appWindow, Rect, error) {
+	hwnd, err := findAppWindow(pid)
+	if err != nil {
+		return 0, Rect{}, err
+	}
+	_, visible := frameBorders(hwnd)
+	return hwnd, visible.toRect(), nil
+}
+
+// setAppWindowFrame makes the visible frame of hwnd r, restoring it first
+// if it is maximized. Like setOwnWindowFrame it moves before resizing so a
+// DPI change between monitors does not rescale the final size.
+func setAppWindowFrame(hwnd appWindow, r Rect) error {
+	if zoomed, _, _ := procIsZoomed.Call(hwnd); zoomed != 0 {
+		procShowWindow.Call(hwnd, swRestore)
+	}
+	if ok, _, err := procSetWindowPos.Call(hwnd, 0, uintptr(r.X), uintptr(r.Y), 0, 0, swpNoSize|swpNoZOrder|swpNoActivate); ok == 0 {
+		return fmt.Errorf("SetWindowPos: %w", err)
+	}
+	outer, visible := frameBorders(hwnd)
+	x := r.X - int(visible.Left-outer.Left)
+	y := r.Y - int(visible.Top-outer.Top)
+	w := r.Width + int(visible.Left-outer.Left) + int(outer.Right-visible.Right)
+	h := r.Height + int(visible.Top-outer.Top) + int(outer.Bottom-visible.Bottom)
+	if ok, _, err := procSetWindowPos.Call(hwnd, 0, uintptr(x), uintptr(y), uintptr(w), uintptr(h), swpNoZOrder|swpNoActivate); ok == 0 {
+		return fmt.Errorf("SetWindowPos: %w", err)
+	}
+	return nil
+}
+
+// maximizeAppWindow uses the native maximized state, so the window keeps
+// its restore size
+func maximizeAppWindow(hwnd appWindow, _ Rect) error {
+	procShowWindow.Call(hwnd, swMaximize)
+	return nil
+}
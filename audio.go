@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AudioDevice is a microphone or speaker the OS knows about
+type AudioDevice struct {
+	// ID is stable across reconnects: the CoreAudio UID on macOS, the
+	// PulseAudio name on Linux and the device name on Windows
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Kind is "input" or "output"
+	Kind    string `json:"kind"`
+	Default bool   `json:"default"`
+}
+
+// AudioSettings picks the devices voice input and speech use; an empty ID
+// follows the OS default
+type AudioSettings struct {
+	InputDevice  string `json:"inputDevice"`
+	OutputDevice string `json:"outputDevice"`
+}
+
+// AudioDeviceChange is the payload of the audio-devices-changed event
+type AudioDeviceChange struct {
+	Devices []AudioDevice `json:"devices"`
+	// Input and Output are the devices in use after the change, which
+	// is the OS default when the chosen one was unplugged
+	Input  AudioDevice `json:"input"`
+	Output AudioDevice `json:"output"`
+}
+
+// ListAudioDevices returns every input and output device
+func (a *App) ListAudioDevices() ([]AudioDevice, error) {
+	return listAudioDevices()
+}
+
+// SetAudioDevices chooses the input and output device by ID; empty means
+// the OS default
+func (a *App) SetAudioDevices(input, output string) error {
+	devices, err := listAudioDevices()
+	if err != nil {
+		return err
+	}
+	for _, want := range []struct{ id, kind string }{{input, "input"}, {output, "output"}} {
+		if want.id == "" {
+			continue
+		}
+		if _, ok := findAudioDevice(devices, want.id, want.kind); !ok {
+			return fmt.Errorf("no %s device %q", want.kind, want.id)
+		}
+	}
+	return a.updateSettings(func(s *Settings) { s.Audio = AudioSettings{InputDevice: input, OutputDevice: output} })
+}
+
+func findAudioDevice(devices []AudioDevice, id, kind string) (AudioDevice, bool) {
+	for _, d := range devices {
+		if d.Kind == kind && d.ID == id {
+			return d, true
+		}
+	}
+	return AudioDevice{}, false
+}
+
+// resolveAudioDevice returns the chosen device of kind if it is connected
+// and the OS default otherwise
+func resolveAudioDevice(devices []AudioDevice, id, kind string) AudioDevice {
+	if d, ok := findAudioDevice(devices, id, kind); ok {
+		return d
+	}
+	for _, d := range devices {
+		if d.Kind == kind && d.Default {
+			return d
+		}
+	}
+	return AudioDevice{Kind: kind}
+}
+
+// audioDevice returns the input or output device to use right now
+func (a *App) audioDevice(kind string) (AudioDevice, error) {
+	devices, err := listAudioDevices()
+	if err != nil {
+		return AudioDevice{}, err
+	}
+	cfg := a.GetSettings().Audio
+	id := cfg.OutputDevice
+	if kind == "input" {
+		id = cfg.InputDevice
+	}
+	return resolveAudioDevice(devices, id, kind), nil
+}
+
+func audioSignature(devices []AudioDevice) string {
+	parts := make([]string, 0, len(devices))
+	for _, d := range devices {
+		parts = append(parts, fmt.Sprintf("%s/%s/%v", d.Kind, d.ID, d.Default))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, "|")
+}
+
+// watchAudioDevices polls for headsets being plugged in or out and for
+// default device changes, which no platform reports to Wails
+func (a *App) watchAudioDevices(ctx context.Context) {
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	lastSig := ""
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		devices, err := listAudioDevices()
+		if err != nil {
+			continue
+		}
+		sig := audioSignature(devices)
+		if lastSig == "" || sig == lastSig {
+			lastSig = sig
+			continue
+		}
+		lastSig = sig
+		cfg := a.GetSettings().Audio
+		eventAudioDevicesChanged.emit(a.ctx, AudioDeviceChange{
+			Devices: devices,
+			Input:   resolveAudioDevice(devices, cfg.InputDevice, "input"),
+			Output:  resolveAudioDevice(devices, cfg.OutputDevice, "output"),
+		})
+	}
+}
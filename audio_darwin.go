@@ -0,0 +1,102 @@
+package main
+
+/*
+#cgo LDFLAGS: -framework CoreAudio -framework CoreFoundation
+#include <CoreAudio/CoreAudio.h>
+#include <stdlib.h>
+
+typedef struct {
+	char uid[256];
+	char name[256];
+	int inputs, outputs;
+	int defaultInput, defaultOutput;
+} ovAudioDevice;
+
+static AudioObjectPropertyAddress ovAddr(AudioObjectPropertySelector sel, AudioObjectPropertyScope scope) {
+	return (AudioObjectPropertyAddress){sel, scope, kAudioObjectPropertyElementMain};
+}
+
+// ovChannels counts the channels a device has in scope; zero means it
+// does not record or play at all
+static int ovChannels(AudioObjectID dev, AudioObjectPropertyScope scope) {
+	AudioObjectPropertyAddress addr = ovAddr(kAudioDevicePropertyStreamConfiguration, scope);
+	UInt32 size = 0;
+	if (AudioObjectGetPropertyDataSize(dev, &addr, 0, NULL, &size) != noErr || size == 0) return 0;
+	AudioBufferList *list = malloc(size);
+	int channels = 0;
+	if (AudioObjectGetPropertyData(dev, &addr, 0, NULL, &size, list) == noErr) {
+		for (UInt32 i = 0; i < list->mNumberBuffers; i++) channels += list->mBuffers[i].mNumberChannels;
+	}
+	free(list);
+	return channels;
+}
+
+static AudioObjectID ovDefaultDevice(AudioObjectPropertySelector sel) {
+	AudioObjectPropertyAddress addr = ovAddr(sel, kAudioObjectPropertyScopeGlobal);
+	AudioObjectID dev = 0;
+	UInt32 size = sizeof(dev);
+	AudioObjectGetPropertyData(kAudioObjectSystemObject, &addr, 0, NULL, &size, &dev);
+	return dev;
+}
+
+static void ovDeviceString(AudioObjectID dev, AudioObjectPropertySelector sel, char *out, int max) {
+	AudioObjectPropertyAddress addr = ovAddr(sel, kAudioObjectPropertyScopeGlobal);
+	CFStringRef s = NULL;
+	UInt32 size = sizeof(s);
+	out[0] = 0;
+	if (AudioObjectGetPropertyData(dev, &addr, 0, NULL, &size, &s) == noErr && s != NULL) {
+		CFStringGetCString(s, out, max, kCFStringEncodingUTF8);
+		CFRelease(s);
+	}
+}
+
+static int ovListAudioDevices(ovAudioDevice *out, int max) {
+	AudioObjectPropertyAddress addr = ovAddr(kAudioHardwarePropertyDevices, kAudioObjectPropertyScopeGlobal);
+	UInt32 size = 0;
+	if (AudioObjectGetPropertyDataSize(kAudioObjectSystemObject, &addr, 0, NULL, &size) != noErr) return -1;
+	int count = size / sizeof(AudioObjectID);
+	AudioObjectID *ids = malloc(size);
+	if (AudioObjectGetPropertyData(kAudioObjectSystemObject, &addr, 0, NULL, &size, ids) != noErr) {
+		free(ids);
+		return -1;
+	}
+	AudioObjectID defIn = ovDefaultDevice(kAudioHardwarePropertyDefaultInputDevice);
+	AudioObjectID defOut = ovDefaultDevice(kAudioHardwarePropertyDefaultOutputDevice);
+	int n = 0;
+	for (int i = 0; i < count && n < max; i++) {
+		ovDeviceString(ids[i], kAudioDevicePropertyDeviceUID, out[n].uid, sizeof(out[n].uid));
+		ovDeviceString(ids[i], kAudioObjectPropertyName, out[n].name, sizeof(out[n].name));
+		out[n].inputs = ovChannels(ids[i], kAudioObjectPropertyScopeInput);
+		out[n].outputs = ovChannels(ids[i], kAudioObjectPropertyScopeOutput);
+		out[n].defaultInput = ids[i] == defIn;
+		out[n].defaultOutput = ids[i] == defOut;
+		n++;
+	}
+	free(ids);
+	return n;
+}
+*/
+import "C"
+
+import "errors"
+
+// listAudioDevices asks CoreAudio; a device with both inputs and outputs,
+// such as a headset, is listed once per direction
+func listAudioDevices() ([]AudioDevice, error) {
+	var raw [64]C.ovAudioDevice
+	n := int(C.ovListAudioDevices(&raw[0], C.int(len(raw))))
+	if n < 0 {
+		return nil, errors.New("could not list audio devices")
+	}
+	devices := []AudioDevice{}
+	for _, d := range raw[:n] {
+		id, name := C.GoString(&d.uid[0]), C.GoString(&d.name[0])
+		if d.inputs > 0 {
+			devices = append(devices, AudioDevice{ID: id, Name: name, Kind: "input", Default: d.defaultInput != 0})
+		}
+		if d.outputs > 0 {
+			devices = append(devices, AudioDevice{ID: id, Name: name, Kind: "output", Default: d.defaultOutput != 0})
+		}
+	}
+	return devices, nil
+}
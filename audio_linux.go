@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// listAudioDevices asks PulseAudio, or PipeWire through its Pulse shim.
+// Monitor sources only loop back an output and are left out of inputs.
+func listAudioDevices() ([]AudioDevice, error) {
+	if _, err := exec.LookPath("pactl"); err != nil {
+		return nil, errors.New("listing audio devices needs pactl")
+	}
+	info, err := pactl("info")
+	if err != nil {
+		return nil, err
+	}
+	defaults := map[string]string{}
+	for _, line := range strings.Split(info, "\n") {
+		if v, ok := strings.CutPrefix(line, "Default Sink: "); ok {
+			defaults["output"] = v
+		} else if v, ok := strings.CutPrefix(line, "Default Source: "); ok {
+			defaults["input"] = v
+		}
+	}
+
+	devices := []AudioDevice{}
+	for _, list := range []struct{ kind, object string }{{"input", "sources"}, {"output", "sinks"}} {
+		out, err := pactl("list", list.object)
+		if err != nil {
+			return nil, err
+		}
+		var cur *AudioDevice
+		flush := func() {
+			if cur != nil && cur.ID != "" && !strings.HasSuffix(cur.ID, ".monitor") {
+				cur.Default = cur.ID == defaults[list.kind]
+				devices = append(devices, *cur)
+			}
+			cur = nil
+		}
+		scanner := bufio.NewScanner(strings.NewReader(out))
+		for scanner.Scan() {
+			line := scanner.Text()
+			if !strings.HasPrefix(line, "\t") && line != "" {
+				// "Source #3" or "Sink #1" starts the next device
+				flush()
+				cur = &AudioDevice{Kind: list.kind}
+				continue
+			}
+			if cur == nil {
+				continue
+			}
+			field := strings.TrimSpace(line)
+			if v, ok := strings.CutPrefix(field, "Name: "); ok {
+				cur.ID = v
+			} else if v, ok := strings.CutPrefix(field, "Description: "); ok {
+				cur.Name = v
+			}
+		}
+		flush()
+	}
+	return devices, nil
+}
+
+// pactl runs with the C locale, since its field labels are translated
+func pactl(args ...string) (string, error) {
+	cmd := exec.Command("pactl", args...)
+	cmd.Env = append(os.Environ(), "LC_ALL=C")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", errors.New("pactl: " + strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	winmm                 = syscall.NewLazyDLL("winmm.dll")
+	procWaveInGetNumDevs  = winmm.NewProc("waveInGetNumDevs")
+	procWaveInGetDevCaps  = winmm.NewProc("waveInGetDevCapsW")
+	procWaveInMessage     = winmm.NewProc("waveInMessage")
+	procWaveOutGetNumDevs = winmm.NewProc("waveOutGetNumDevs")
+	procWaveOutGetDevCaps = winmm.NewProc("waveOutGetDevCapsW")
+	procWaveOutMessage    = winmm.NewProc("waveOutMessage")
+)
+
+const (
+	waveMapper             = 0xFFFFFFFF
+	drvmMapperPreferredGet = 0x2015
+	mmsysErrNoError        = 0
+)
+
+// waveCaps covers the shared prefix of WAVEINCAPSW and WAVEOUTCAPSW plus
+// room for the output variant's trailing dwSupport
+type waveCaps struct {
+	Mid           uint16
+	Pid           uint16
+	DriverVersion uint32
+	Pname         [32]uint16
+	Formats       uint32
+	Channels      uint16
+	Reserved1     uint16
+	Support       uint32
+}
+
+// listAudioDevices uses the waveIn/waveOut API, the one that needs no COM.
+// Device indices shift when something is plugged in, so names serve as
+// IDs; duplicates get a number.
+func listAudioDevices() ([]AudioDevice, error) {
+	devices := []AudioDevice{}
+	for _, api := range []struct {
+		kind     string
+		num      *syscall.LazyProc
+		caps     *syscall.LazyProc
+		message  *syscall.LazyProc
+		capsSize uintptr
+	}{
+		{"input", procWaveInGetNumDevs, procWaveInGetDevCaps, procWaveInMessage, unsafe.Offsetof(waveCaps{}.Support)},
+		{"output", procWaveOutGetNumDevs, procWaveOutGetDevCaps, procWaveOutMessage, unsafe.Sizeof(waveCaps{})},
+	} {
+		var preferred, flags uint32 = waveMapper, 0
+		api.message.Call(waveMapper, drvmMapperPreferredGet, uintptr(unsafe.Pointer(&preferred)), uintptr(unsafe.Pointer(&flags)))
+
+		count, _, _ := api.num.Call()
+		seen := map[string]int{}
+		for i := uintptr(0); i < count; i++ {
+			var caps waveCaps
+			if r, _, _ := api.caps.Call(i, uintptr(unsafe.Pointer(&caps)), api.capsSize); r != mmsysErrNoError {
+				continue
+			}
+			name := syscall.UTF16ToString(caps.Pname[:])
+			seen[name]++
+			id := name
+			if seen[name] > 1 {
+				id = fmt.Sprintf("%s (%d)", name, seen[name])
+			}
+			devices = append(devices, AudioDevice{ID: id, Name: name, Kind: api.kind, Default: uint32(i) == preferred})
+		}
+	}
+	return devices, nil
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	defaultHookTimeout = 10 * time.Second
+	maxHookTimeoutSec  = 60
+	// hookConcurrency bounds the hooks running at once; events beyond it
+	// wait their turn
+	hookConcurrency = 4
+	// hookOutputMax is how much of a hook's output or reply is logged
+	hookOutputMax = 4096
+	// automationLogFile is in the cache directory; it is moved aside to
+	// automationLogFile.1 past automationLogMaxBytes
+	automationLogFile     = "automation.log"
+	automationLogMaxBytes = 1 << 20
+	automationRunsKept    = 100
+	// automationHooksFile is not in syncedFiles: a hook runs commands on
+	// this machine, so only this install may configure it
+	automationHooksFile = "automation-hooks.json"
+)
+
+// hookableEvents are the events automation hooks may run on.
+// request-started comes before a prompt streams and response-done after.
+var hookableEvents = []string{
+	eventShowOverlay.name,
+	eventRequestStarted.name,
+	eventResponseDone.name,
+	eventNoteSaved.name,
+}
+
+// AutomationHook runs a command or calls a webhook whenever its event
+// fires, passing the event envelope as JSON
+type AutomationHook struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	// Event is "show-overlay", "request-started", "response-done" or
+	// "note-saved"
+	Event string `json:"event"`
+	// Command is a program and its arguments, run with the envelope on
+	// stdin and OVERLAE_EVENT set to the event name
+	Command []string `json:"command,omitempty"`
+	// URL is POSTed the envelope instead, when Command is empty
+	URL string `json:"url,omitempty"`
+	// TimeoutSec stops the hook after this long; 0 means 10 seconds
+	TimeoutSec int `json:"timeoutSec,omitempty"`
+}
+
+// HookRun is a log entry for one hook invocation
+type HookRun struct {
+	HookID     string    `json:"hookId"`
+	Name       string    `json:"name"`
+	Event      string    `json:"event"`
+	Started    time.Time `json:"started"`
+	DurationMs int64     `json:"durationMs"`
+	OK         bool      `json:"ok"`
+	// Output is the start of the command's output or the webhook's reply
+	Output string `json:"output,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// SetAutomationHooks validates and saves the hooks for this install; they
+// are never synced. Hooks that run commands need the shell permission.
+func (a *App) SetAutomationHooks(hooks []AutomationHook) error {
+	needsShell := false
+	for i := range hooks {
+		h := &hooks[i]
+		if h.ID == "" {
+			h.ID = newID()
+		}
+		if !slices.Contains(hookableEvents, h.Event) {
+			return fmt.Errorf("hook %d: unknown event %q", i+1, h.Event)
+		}
+		if h.TimeoutSec < 0 || h.TimeoutSec > maxHookTimeoutSec {
+			return fmt.Errorf("hook %d: timeout must be at most %d seconds", i+1, maxHookTimeoutSec)
+		}
+		switch {
+		case len(h.Command) > 0 && h.URL != "":
+			return fmt.Errorf("hook %d: set a command or a URL, not both", i+1)
+		case len(h.Command) > 0:
+			if h.Command[0] == "" {
+				return fmt.Errorf("hook %d: the command is empty", i+1)
+			}
+			needsShell = needsShell || h.Enabled
+		case h.URL != "":
+			u, err := url.Parse(h.URL)
+			if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+				return fmt.Errorf("hook %d: %q is not an http(s) URL", i+1, h.URL)
+			}
+		default:
+			return fmt.Errorf("hook %d: set a command or a URL", i+1)
+		}
+	}
+	if needsShell {
+		if err := a.requirePermission(capShell); err != nil {
+			return err
+		}
+	}
+	a.automationMu.Lock()
+	defer a.automationMu.Unlock()
+	if err := writeJSONConfig(automationHooksFile, hooks, 0o600); err != nil {
+		return err
+	}
+	a.automationHooks, a.automationLoaded = hooks, true
+	return nil
+}
+
+// GetAutomationHooks returns the configured hooks
+func (a *App) GetAutomationHooks() ([]AutomationHook, error) {
+	a.automationMu.Lock()
+	defer a.automationMu.Unlock()
+	if !a.automationLoaded {
+		hooks := []AutomationHook{}
+		if err := readJSONConfig(automationHooksFile, &hooks); err != nil {
+			return hooks, err
+		}
+		a.automationHooks, a.automationLoaded = hooks, true
+	}
+	return slices.Clone(a.automationHooks), nil
+}
+
+// runAutomation runs the hooks for each hookable event until ctx ends.
+// Stopping the local API closes every subscription, so it subscribes
+// again.
+func (a *App) runAutomation(ctx context.Context) {
+	sem := make(chan struct{}, hookConcurrency)
+	keep := func(env EventEnvelope) bool { return slices.Contains(hookableEvents, env.Name) }
+	for ctx.Err() == nil {
+		ch := bridge.subscribe(keep)
+		for open := true; open; {
+			select {
+			case <-ctx.Done():
+				bridge.unsubscribe(ch)
+				return
+			case env, ok := <-ch:
+				if open = ok; !ok {
+					break
+				}
+				hooks, err := a.GetAutomationHooks()
+				if err != nil {
+					println("Error loading automation hooks:", err.Error())
+				}
+				for _, h := range hooks {
+					if h.Enabled && h.Event == env.Name {
+						sem <- struct{}{}
+						go func() {
+							defer func() { <-sem }()
+							a.runHook(ctx, h, env)
+						}()
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestAutomationHook runs a hook once on a sample envelope and returns the
+// log entry
+func (a *App) TestAutomationHook(hook AutomationHook) HookRun {
+	env := EventEnvelope{Name: hook.Event, Version: eventVersion, Time: time.Now(), Data: map[string]bool{"test": true}}
+	return a.runHook(a.ctx, hook, env)
+}
+
+// runHook runs h for env within its timeout and logs the outcome
+func (a *App) runHook(ctx context.Context, h AutomationHook, env EventEnvelope) HookRun {
+	timeout := defaultHookTimeout
+	if h.TimeoutSec > 0 {
+		timeout = time.Duration(h.TimeoutSec) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	run := HookRun{HookID: h.ID, Name: h.Name, Event: env.Name, Started: time.Now()}
+	output, err := a.invokeHook(ctx, h, env)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("timed out after %s", timeout)
+	}
+	run.DurationMs = time.Since(run.Started).Milliseconds()
+	run.Output = truncateOutput(output)
+	run.OK = err == nil
+	if err != nil {
+		run.Error = err.Error()
+		println("Error running hook", h.Name+":", err.Error())
+	}
+	a.logHookRun(run)
+	return run
+}
+
+func (a *App) invokeHook(ctx context.Context, h AutomationHook, env EventEnvelope) (string, error) {
+	payload, err := json.Marshal(env)
+	if err != nil {
+		return "", err
+	}
+	if len(h.Command) > 0 {
+		// A revoked grant stops hooks that were saved before
+		if err := a.requirePermission(capShell); err != nil {
+			return "", err
+		}
+		cmd := exec.CommandContext(ctx, h.Command[0], h.Command[1:]...)
+		cmd.Stdin = bytes.NewReader(payload)
+		cmd.Env = append(os.Environ(), "OVERLAE_EVENT="+env.Name)
+		out, err := cmd.CombinedOutput()
+		return string(out), err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.URL, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Overlae-Event", env.Name)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, hookOutputMax))
+	if resp.StatusCode/100 != 2 {
+		return string(body), fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return string(body), nil
+}
+
+func truncateOutput(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) > hookOutputMax {
+		s = s[:hookOutputMax] + "…"
+	}
+	return s
+}
+
+// logHookRun keeps run for GetAutomationLog and appends it to the log file
+func (a *App) logHookRun(run HookRun) {
+	a.automationMu.Lock()
+	a.automationRuns = append(a.automationRuns, run)
+	if len(a.automationRuns) > automationRunsKept {
+		a.automationRuns = a.automationRuns[len(a.automationRuns)-automationRunsKept:]
+	}
+	a.automationMu.Unlock()
+
+	dir, err := cacheDir()
+	if err != nil {
+		return
+	}
+	path := filepath.Join(dir, automationLogFile)
+	if info, err := os.Stat(path); err == nil && info.Size() > automationLogMaxBytes {
+		_ = os.Rename(path, path+".1")
+	}
+	line, err := json.Marshal(run)
+	if err != nil {
+		return
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		println("Error writing automation log:", err.Error())
+		return
+	}
+	defer f.Close()
+	_, _ = f.Write(append(line, '\n'))
+}
+
+// GetAutomationLog returns the latest hook runs, newest first
+func (a *App) GetAutomationLog() []HookRun {
+	a.automationMu.Lock()
+	defer a.automationMu.Unlock()
+	runs := slices.Clone(a.automationRuns)
+	slices.Reverse(runs)
+	return runs
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// Values of Settings.HideOnBlur
+const (
+	hideOnBlurAlways    = "always"
+	hideOnBlurNever     = "never"
+	hideOnBlurWhenEmpty = "when-empty"
+)
+
+// OnWindowBlur lets users click outside to close, as Settings.HideOnBlur
+// allows. empty reports that nothing is typed or shown, which the
+// when-empty mode requires. The hide waits Settings.BlurGraceMs so a file
+// picker or permission prompt that briefly takes focus does not close the
+// overlay; see OnWindowFocus.
+func (a *App) OnWindowBlur(empty bool) {
+	switch a.GetSettings().HideOnBlur {
+	case hideOnBlurNever:
+		return
+	case hideOnBlurWhenEmpty:
+		if !empty {
+			return
+		}
+	}
+	grace := time.Duration(a.GetSettings().BlurGraceMs) * time.Millisecond
+	a.blurMu.Lock()
+	defer a.blurMu.Unlock()
+	if a.blurTimer != nil {
+		a.blurTimer.Stop()
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(grace, func() {
+		a.blurMu.Lock()
+		current := a.blurTimer == timer
+		a.blurTimer = nil
+		a.blurMu.Unlock()
+		if current {
+			a.HideOverlay()
+		}
+	})
+	a.blurTimer = timer
+}
+
+// OnWindowFocus cancels a hide scheduled by OnWindowBlur
+func (a *App) OnWindowFocus() {
+	a.cancelBlurHide()
+}
+
+func (a *App) cancelBlurHide() {
+	a.blurMu.Lock()
+	defer a.blurMu.Unlock()
+	if a.blurTimer != nil {
+		a.blurTimer.Stop()
+		a.blurTimer = nil
+	}
+}
+
+// SetHideOnBlur chooses whether losing focus hides the overlay: "always",
+// "never" or "when-empty"
+func (a *App) SetHideOnBlur(mode string) error {
+	switch mode {
+	case hideOnBlurAlways, hideOnBlurNever, hideOnBlurWhenEmpty:
+	default:
+		return fmt.Errorf("unknown hide-on-blur mode %q", mode)
+	}
+	return a.updateSettings(func(s *Settings) { s.HideOnBlur = mode })
+}
+
+// SetBlurGrace sets how long the overlay may be unfocused before a blur
+// hides it
+func (a *App) SetBlurGrace(ms int) error {
+	if ms < 0 || ms > 10000 {
+		return fmt.Errorf("grace period must be between 0 and 10000 ms")
+	}
+	return a.updateSettings(func(s *Settings) { s.BlurGraceMs = ms })
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// pageContextTTL is how long a reported tab stays current; the
+	// extension reports again on every tab switch and navigation
+	pageContextTTL    = 2 * time.Minute
+	browserAskTimeout = 3 * time.Minute
+	// maxBrowserBody bounds what the extension may post, selection included
+	maxBrowserBody = 1 << 20
+)
+
+// PageContext is the browser tab the companion extension reported
+type PageContext struct {
+	URL       string `json:"url"`
+	Title     string `json:"title"`
+	Selection string `json:"selection,omitempty"`
+	// Browser is the extension's name for its host, e.g. "chrome"
+	Browser    string    `json:"browser,omitempty"`
+	ReceivedAt time.Time `json:"receivedAt"`
+}
+
+// browserAsk is the body of POST /browser/ask
+type browserAsk struct {
+	Prompt string      `json:"prompt"`
+	Page   PageContext `json:"page"`
+}
+
+// browserAnswer is the reply to POST /browser/ask
+type browserAnswer struct {
+	RequestID string         `json:"requestId"`
+	Result    ResponseResult `json:"result"`
+}
+
+func (a *App) setPage(page PageContext) {
+	page.ReceivedAt = time.Now()
+	a.pageMu.Lock()
+	a.page = page
+	a.pageMu.Unlock()
+}
+
+// currentPage returns the last reported tab if it is recent and app, the
+// frontmost window, shows it. Browsers put the page title in the window
+// title, which tells the tab apart from an unrelated window.
+func (a *App) currentPage(app ActiveApp) *PageContext {
+	a.pageMu.Lock()
+	page := a.page
+	a.pageMu.Unlock()
+	if page.URL == "" || time.Since(page.ReceivedAt) > pageContextTTL {
+		return nil
+	}
+	if page.Title != "" && !strings.Contains(app.WindowTitle, page.Title) {
+		return nil
+	}
+	return &page
+}
+
+// browserRoutes is the companion extension protocol. The extension posts
+// the active tab to /browser/context as the user browses, and sends
+// prompts with their page to /browser/ask, which answers once the response
+// is complete. Live chunks are available from /events.
+func (a *App) browserRoutes(mux *http.ServeMux) {
+	mux.Handle("POST /browser/context", a.requireAPIToken(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var page PageContext
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBrowserBody)).Decode(&page); err != nil {
+			http.Error(w, "invalid page: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		a.setPage(page)
+		w.WriteHeader(http.StatusNoContent)
+	})))
+
+	mux.Handle("POST /browser/ask", a.requireAPIToken(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ask browserAsk
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBrowserBody)).Decode(&ask); err != nil {
+			http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if strings.TrimSpace(ask.Prompt) == "" {
+			http.Error(w, "prompt is empty", http.StatusBadRequest)
+			return
+		}
+		a.setPage(ask.Page)
+
+		ctx, cancel := context.WithTimeout(r.Context(), browserAskTimeout)
+		defer cancel()
+		answer, err := a.askFromBrowser(ctx, ask)
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			http.Error(w, "timed out waiting for the response", http.StatusGatewayTimeout)
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusBadGateway)
+		default:
+			writeAPIJSON(w, answer)
+		}
+	})))
+}
+
+// askFromBrowser sends ask with its page as context and waits for the
+// answer. Abandoning the call cancels the request.
+func (a *App) askFromBrowser(ctx context.Context, ask browserAsk) (browserAnswer, error) {
+	// Subscribe first so a fast or cached answer is not missed
+	done := bridge.subscribe(func(env EventEnvelope) bool { return env.Name == eventResponseDone.name })
+	defer bridge.unsubscribe(done)
+
+	show := ShowContext{Mode: a.GetSettings().DefaultMode, Selection: ask.Page.Selection, Page: &ask.Page}
+	id, err := a.sendPrompt(ask.Prompt, show)
+	if err != nil {
+		return browserAnswer{}, err
+	}
+	for {
+		select {
+		case env, ok := <-done:
+			if !ok {
+				return browserAnswer{}, errors.New("local API stopped")
+			}
+			if env.RequestID != id {
+				continue
+			}
+			if env.Error != "" {
+				return browserAnswer{}, errors.New(env.Error)
+			}
+			result, _ := env.Data.(ResponseResult)
+			return browserAnswer{RequestID: id, Result: result}, nil
+		case <-ctx.Done():
+			_ = a.CancelRequest(id)
+			return browserAnswer{}, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// calcFunctions are the functions calculator expressions may call
+var calcFunctions = map[string]func(float64) float64{
+	"sqrt": math.Sqrt, "abs": math.Abs, "round": math.Round,
+	"floor": math.Floor, "ceil": math.Ceil, "sin": math.Sin,
+	"cos": math.Cos, "tan": math.Tan, "log": math.Log10, "ln": math.Log,
+}
+
+var calcConstants = map[string]float64{"pi": math.Pi, "e": math.E}
+
+var errNotCalculation = errors.New("not a calculation")
+
+// calculator parses arithmetic by recursive descent:
+//
+//	expr   = term {("+" | "-") term}
+//	term   = unary {("*" | "/" | "%") unary}
+//	unary  = ("+" | "-") unary | power
+//	power  = atom ["^" unary]
+//	atom   = number | constant | function "(" expr ")" | "(" expr ")"
+type calculator struct {
+	src []rune
+	pos int
+	// ops counts operators and calls, so a bare number is not a result
+	ops int
+}
+
+// evaluate computes input as an arithmetic expression. It fails with
+// errNotCalculation for anything that is not one, such as a plain number
+// or a sentence.
+func evaluate(input string) (float64, error) {
+	c := &calculator{src: []rune(strings.ToLower(strings.TrimSuffix(strings.TrimSpace(input), "=")))}
+	v, err := c.expr()
+	if err != nil {
+		return 0, err
+	}
+	if c.skipSpace(); c.pos < len(c.src) || c.ops == 0 {
+		return 0, errNotCalculation
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, errors.New("the result is undefined")
+	}
+	return v, nil
+}
+
+// formatNumber prints v with at most 12 significant digits, which hides
+// floating point noise such as 0.1+0.2 = 0.30000000000000004
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'g', 12, 64)
+}
+
+func (c *calculator) skipSpace() {
+	for c.pos < len(c.src) && unicode.IsSpace(c.src[c.pos]) {
+		c.pos++
+	}
+}
+
+// next consumes op if it comes next
+func (c *calculator) next(ops ...rune) (rune, bool) {
+	c.skipSpace()
+	if c.pos < len(c.src) {
+		for _, op := range ops {
+			if c.src[c.pos] == op {
+				c.pos++
+				return op, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func (c *calculator) expr() (float64, error) {
+	v, err := c.term()
+	for err == nil {
+		op, ok := c.next('+', '-')
+		if !ok {
+			break
+		}
+		var r float64
+		if r, err = c.term(); op == '+' {
+			v += r
+		} else {
+			v -= r
+		}
+		c.ops++
+	}
+	return v, err
+}
+
+func (c *calculator) term() (float64, error) {
+	v, err := c.unary()
+	for err == nil {
+		op, ok := c.next('*', '×', '/', '÷', '%')
+		if !ok {
+			break
+		}
+		var r float64
+		r, err = c.unary()
+		switch op {
+		case '*', '×':
+			v *= r
+		case '/', '÷':
+			v /= r
+		case '%':
+			v = math.Mod(v, r)
+		}
+		c.ops++
+	}
+	return v, err
+}
+
+func (c *calculator) unary() (float64, error) {
+	if op, ok := c.next('+', '-'); ok {
+		v, err := c.unary()
+		if op == '-' {
+			v = -v
+		}
+		return v, err
+	}
+	return c.power()
+}
+
+func (c *calculator) power() (float64, error) {
+	v, err := c.atom()
+	if err != nil {
+		return 0, err
+	}
+	if _, ok := c.next('^'); ok {
+		exp, err := c.unary()
+		c.ops++
+		return math.Pow(v, exp), err
+	}
+	return v, nil
+}
+
+func (c *calculator) atom() (float64, error) {
+	c.skipSpace()
+	if _, ok := c.next('('); ok {
+		v, err := c.expr()
+		if err != nil {
+			return 0, err
+		}
+		if _, ok := c.next(')'); !ok {
+			return 0, errNotCalculation
+		}
+		return v, nil
+	}
+
+	start := c.pos
+	if c.pos < len(c.src) && unicode.IsLetter(c.src[c.pos]) {
+		for c.pos < len(c.src) && unicode.IsLetter(c.src[c.pos]) {
+			c.pos++
+		}
+		name := string(c.src[start:c.pos])
+		if v, ok := calcConstants[name]; ok {
+			return v, nil
+		}
+		fn, ok := calcFunctions[name]
+		if !ok {
+			return 0, errNotCalculation
+		}
+		if _, ok := c.next('('); !ok {
+			return 0, errNotCalculation
+		}
+		arg, err := c.expr()
+		if err != nil {
+			return 0, err
+		}
+		if _, ok := c.next(')'); !ok {
+			return 0, errNotCalculation
+		}
+		c.ops++
+		return fn(arg), nil
+	}
+
+	digits := func() {
+		for c.pos < len(c.src) && (unicode.IsDigit(c.src[c.pos]) || c.src[c.pos] == '.') {
+			c.pos++
+		}
+	}
+	digits()
+	// An exponent such as 1e-3
+	if c.pos > start && c.pos < len(c.src) && c.src[c.pos] == 'e' {
+		end := c.pos + 1
+		if end < len(c.src) && (c.src[end] == '-' || c.src[end] == '+') {
+			end++
+		}
+		if end < len(c.src) && unicode.IsDigit(c.src[end]) {
+			c.pos = end
+			digits()
+		}
+	}
+	v, err := strconv.ParseFloat(string(c.src[start:c.pos]), 64)
+	if err != nil {
+		return 0, errNotCalculation
+	}
+	return v, nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// errCaptureCancelled is returned when the user dismisses region selection
+var errCaptureCancelled = errors.New("capture cancelled")
+
+// captureScreenToTemp captures the main display into a fresh PNG in the
+// temp directory and returns its path
+func captureScreenToTemp() (string, error) {
+	return captureToTemp("screen", captureScreen)
+}
+
+// captureRegionToTemp lets the user drag out a region and saves it as a
+// fresh PNG in the temp directory
+func captureRegionToTemp() (string, error) {
+	return captureToTemp("region", captureRegion)
+}
+
+// captureTempDir holds captures until they are sent, attached or expire;
+// see sweepStorage
+func captureTempDir() string {
+	return filepath.Join(os.TempDir(), "overlae")
+}
+
+// tempCapturePath returns a fresh timestamped path in captureTempDir
+func tempCapturePath(prefix, ext string) (string, error) {
+	dir := captureTempDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, prefix+"-"+time.Now().Format("20060102-150405.000")+"."+ext), nil
+}
+
+func captureToTemp(prefix string, capture func(path string) error) (string, error) {
+	path, err := tempCapturePath(prefix, "png")
+	if err != nil {
+		return "", err
+	}
+	if err := capture(path); err != nil {
+		return "", err
+	}
+	// Interactive tools exit cleanly without writing when dismissed
+	if info, err := os.Stat(path); err != nil || info.Size() == 0 {
+		_ = os.Remove(path)
+		return "", errCaptureCancelled
+	}
+	return path, nil
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// captureScreen writes a PNG of the main display to path; it needs the
+// screen recording permission
+func captureScreen(path string) error {
+	out, err := exec.Command("screencapture", "-x", "-m", "-t", "png", path).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("screencapture: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// captureRegion lets the user select a region with the system crosshair;
+// Escape cancels without writing path
+func captureRegion(path string) error {
+	out, err := exec.Command("screencapture", "-i", "-x", "-t", "png", path).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("screencapture: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
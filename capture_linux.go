@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// screenshotTools are tried in order; which one exists depends on the
+// desktop environment and display server
+var screenshotTools = [][]string{
+	{"grim"},
+	{"gnome-screenshot", "-f"},
+	{"spectacle", "-b", "-n", "-o"},
+	{"scrot", "-o"},
+	{"import", "-window", "root"},
+}
+
+// captureScreen writes a PNG of the screen to path using the first
+// available screenshot tool
+func captureScreen(path string) error {
+	for _, tool := range screenshotTools {
+		if _, err := exec.LookPath(tool[0]); err != nil {
+			continue
+		}
+		args := append(append([]string{}, tool[1:]...), path)
+		return exec.Command(tool[0], args...).Run()
+	}
+	return fmt.Errorf("no screenshot tool found (install grim, gnome-screenshot or scrot)")
+}
+
+// regionTools select a region interactively; grim is paired with slurp
+var regionTools = [][]string{
+	{"gnome-screenshot", "-a", "-f"},
+	{"spectacle", "-r", "-b", "-n", "-o"},
+	{"scrot", "-s", "-o"},
+	{"import"},
+}
+
+// captureRegion writes a PNG of a region the user selects to path
+func captureRegion(path string) error {
+	if _, err := exec.LookPath("slurp"); err == nil {
+		if _, err := exec.LookPath("grim"); err == nil {
+			geometry, err := exec.Command("slurp").Output()
+			if err != nil {
+				// slurp exits non-zero when selection is cancelled
+				return errCaptureCancelled
+			}
+			return exec.Command("grim", "-g", strings.TrimSpace(string(geometry)), path).Run()
+		}
+	}
+	for _, tool := range regionTools {
+		if _, err := exec.LookPath(tool[0]); err != nil {
+			continue
+		}
+		args := append(append([]string{}, tool[1:]...), path)
+		if err := exec.Command(tool[0], args...).Run(); err != nil {
+			return errCaptureCancelled
+		}
+		return nil
+	}
+	return fmt.Errorf("no region capture tool found (install grim and slurp, gnome-screenshot or scrot)")
+}
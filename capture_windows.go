@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	gdi32                      = syscall.NewLazyDLL("gdi32.dll")
+	procGetDC                  = user32.NewProc("GetDC")
+	procReleaseDC              = user32.NewProc("ReleaseDC")
+	procGetSystemMetrics       = user32.NewProc("GetSystemMetrics")
+	procCreateCompatibleDC     = gdi32.NewProc("CreateCompatibleDC")
+	procCreateCompatibleBitmap = gdi32.NewProc("CreateCompatibleBitmap")
+	procSelectObject           = gdi32.NewProc("SelectObject")
+	procBitBlt                 = gdi32.NewProc("BitBlt")
+	procGetDIBits              = gdi32.NewProc("GetDIBits")
+	procDeleteObject           = gdi32.NewProc("DeleteObject")
+	procDeleteDC               = gdi32.NewProc("DeleteDC")
+)
+
+const (
+	smCxScreen   = 0
+	smCyScreen   = 1
+	srcCopy      = 0x00CC0020
+	captureBlt   = 0x40000000
+	dibRGBColors = 0
+)
+
+type bitmapInfoHeader struct {
+	Size          uint32
+	Width         int32
+	Height        int32
+	Planes        uint16
+	BitCount      uint16
+	Compression   uint32
+	SizeImage     uint32
+	XPelsPerMeter int32
+	YPelsPerMeter int32
+	ClrUsed       uint32
+	ClrImportant  uint32
+}
+
+// captureScreen writes a PNG of the primary monitor to path using GDI
+func captureScreen(path string) error {
+	w, _, _ := procGetSystemMetrics.Call(smCxScreen)
+	h, _, _ := procGetSystemMetrics.Call(smCyScreen)
+	if w == 0 || h == 0 {
+		return fmt.Errorf("could not determine screen size")
+	}
+
+	screenDC, _, _ := procGetDC.Call(0)
+	if screenDC == 0 {
+		return fmt.Errorf("GetDC failed")
+	}
+	defer procReleaseDC.Call(0, screenDC)
+
+	memDC, _, _ := procCreateCompatibleDC.Call(screenDC)
+	defer procDeleteDC.Call(memDC)
+	bitmap, _, _ := procCreateCompatibleBitmap.Call(screenDC, w, h)
+	defer procDeleteObject.Call(bitmap)
+	procSelectObject.Call(memDC, bitmap)
+
+	if r, _, err := procBitBlt.Call(memDC, 0, 0, w, h, screenDC, 0, 0, srcCopy|captureBlt); r == 0 {
+		return fmt.Errorf("BitBlt: %w", err)
+	}
+
+	header := bitmapInfoHeader{
+		Width:    int32(w),
+		Height:   -int32(h), // negative height gives a top-down bitmap
+		Planes:   1,
+		BitCount: 32,
+	}
+	header.Size = uint32(unsafe.Sizeof(header))
+	img := image.NewRGBA(image.Rect(0, 0, int(w), int(h)))
+	if r, _, err := procGetDIBits.Call(memDC, bitmap, 0, h, uintptr(unsafe.Pointer(&img.Pix[0])), uintptr(unsafe.Pointer(&header)), dibRGBColors); r == 0 {
+		return fmt.Errorf("GetDIBits: %w", err)
+	}
+	// GDI hands out BGRA with an undefined alpha channel
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+2], img.Pix[i+3] = img.Pix[i+2], img.Pix[i], 0xff
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return png.Encode(f, img)
+}
+
+// captureRegion falls back to the whole primary monitor; Windows has no
+// region picker a program can drive and wait for
+func captureRegion(path string) error {
+	return captureScreen(path)
+}
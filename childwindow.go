@@ -0,0 +1,193 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Wails v2 only supports a single window per process, so secondary windows
+// run as child processes of the same binary started with --window=<kind>.
+// The parent drives them with JSON lines on their stdin; children answer
+// with marked JSON lines on their stdout.
+
+// childMessagePrefix marks the stdout lines a child sends its parent, so
+// they are told apart from log output passed through
+const childMessagePrefix = "\x1eoverlae:"
+
+// childCommand is one line sent between the parent and a child window
+type childCommand struct {
+	Cmd     string          `json:"cmd"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+type childWindow struct {
+	kind  string
+	cmd   *exec.Cmd
+	mu    sync.Mutex
+	stdin io.WriteCloser
+	done  chan struct{}
+}
+
+// spawnChildWindow starts a child window process; args are passed as
+// --key=value flags. onMessage, if set, receives what the child sends with
+// sendParent.
+func spawnChildWindow(kind string, args map[string]string, onMessage func(childCommand)) (*childWindow, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	argv := []string{"--window=" + kind}
+	for k, v := range args {
+		argv = append(argv, "--"+k+"="+v)
+	}
+
+	cmd := exec.Command(exe, argv...)
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	go readChildMessages(stdout, onMessage)
+
+	c := &childWindow{kind: kind, cmd: cmd, stdin: stdin, done: make(chan struct{})}
+	go func() {
+		_ = cmd.Wait()
+		close(c.done)
+	}()
+	return c, nil
+}
+
+func (c *childWindow) running() bool {
+	if c == nil {
+		return false
+	}
+	select {
+	case <-c.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// send writes a command to the child's stdin
+func (c *childWindow) send(cmd string, payload any) error {
+	line := childCommand{Cmd: cmd}
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		line.Payload = data
+	}
+	data, err := json.Marshal(line)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err = c.stdin.Write(append(data, '\n'))
+	return err
+}
+
+// close asks the child to quit and kills it if it does not within a second
+func (c *childWindow) close() {
+	if !c.running() {
+		return
+	}
+	_ = c.send("close", nil)
+	select {
+	case <-c.done:
+	case <-time.After(time.Second):
+		_ = c.cmd.Process.Kill()
+	}
+}
+
+// parseChildWindowArgs reports whether this process was started as a
+// child window and returns its kind and --key=value flags
+func parseChildWindowArgs(argv []string) (string, map[string]string, bool) {
+	flags := map[string]string{}
+	for _, arg := range argv {
+		if k, v, ok := strings.Cut(strings.TrimPrefix(arg, "--"), "="); ok && strings.HasPrefix(arg, "--") {
+			flags[k] = v
+		}
+	}
+	kind, ok := flags["window"]
+	return kind, flags, ok && kind != ""
+}
+
+// readParentCommands calls handle for every command from the parent and
+// calls onEOF once the parent closes the pipe or exits
+func readParentCommands(handle func(childCommand), onEOF func()) {
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var cmd childCommand
+		if err := json.Unmarshal(scanner.Bytes(), &cmd); err == nil {
+			handle(cmd)
+		}
+	}
+	onEOF()
+}
+
+// readChildMessages hands the child's marked lines to onMessage and copies
+// everything else to our stdout
+func readChildMessages(r io.Reader, onMessage func(childCommand)) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line, ok := strings.CutPrefix(scanner.Text(), childMessagePrefix)
+		if !ok {
+			os.Stdout.WriteString(line + "\n")
+			continue
+		}
+		var msg childCommand
+		if err := json.Unmarshal([]byte(line), &msg); err == nil && onMessage != nil {
+			onMessage(msg)
+		}
+	}
+}
+
+var sendParentMu sync.Mutex
+
+// sendParent writes a message for the parent to this child's stdout
+func sendParent(cmd string, payload any) error {
+	msg := childCommand{Cmd: cmd}
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		msg.Payload = data
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	sendParentMu.Lock()
+	defer sendParentMu.Unlock()
+	_, err = os.Stdout.WriteString(childMessagePrefix + string(data) + "\n")
+	return err
+}
+
+// WindowInfo tells the frontend which kind of window it is rendering in
+type WindowInfo struct {
+	kind string
+}
+
+// Kind returns "main" for the overlay or the child window kind
+func (w *WindowInfo) Kind() string {
+	return w.kind
+}
@@ -0,0 +1,27 @@
+package main
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Cocoa
+#import <Cocoa/Cocoa.h>
+
+static void setIgnoresMouseEvents(int ignore) {
+	dispatch_async(dispatch_get_main_queue(), ^{
+		for (NSWindow *window in [NSApp windows]) {
+			[window setIgnoresMouseEvents:(ignore ? YES : NO)];
+		}
+	});
+}
+*/
+import "C"
+
+// setClickThrough makes this process' windows pass mouse events through
+// to whatever is underneath
+func setClickThrough(enabled bool) error {
+	ignore := 0
+	if enabled {
+		ignore = 1
+	}
+	C.setIgnoresMouseEvents(C.int(ignore))
+	return nil
+}
@@ -0,0 +1,7 @@
+package main
+
+import "fmt"
+
+func setClickThrough(bool) error {
+	return fmt.Errorf("click-through windows are not supported on Linux")
+}
@@ -0,0 +1,33 @@
+package main
+
+var (
+	procGetWindowLongW             = user32.NewProc("GetWindowLongW")
+	procSetWindowLongW             = user32.NewProc("SetWindowLongW")
+	procSetLayeredWindowAttributes = user32.NewProc("SetLayeredWindowAttributes")
+)
+
+const (
+	gwlExStyle      = ^uintptr(19) // -20
+	wsExLayered     = 0x00080000
+	wsExTransparent = 0x00000020
+	lwaAlpha        = 0x2
+)
+
+// setClickThrough toggles WS_EX_TRANSPARENT so clicks fall through to
+// the windows underneath
+func setClickThrough(enabled bool) error {
+	hwnd, err := ownWindow()
+	if err != nil {
+		return err
+	}
+	style, _, _ := procGetWindowLongW.Call(hwnd, gwlExStyle)
+	style |= wsExLayered
+	if enabled {
+		style |= wsExTransparent
+	} else {
+		style &^= wsExTransparent
+	}
+	procSetWindowLongW.Call(hwnd, gwlExStyle, style)
+	procSetLayeredWindowAttributes.Call(hwnd, 0, 255, lwaAlpha)
+	return nil
+}
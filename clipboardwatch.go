@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	// clipboardScanMax skips huge copies; rules target short snippets
+	clipboardScanMax = 10000
+	// ownClipboardMax is how many of overlae's own writes are remembered
+	ownClipboardMax = 4
+)
+
+// ClipboardRule suggests a prompt when copied text matches Pattern
+type ClipboardRule struct {
+	Name string `json:"name"`
+	// Pattern is a Go regular expression searched in the copied text
+	Pattern string `json:"pattern"`
+	// Title is shown to the user, e.g. "Summarize this link?"
+	Title string `json:"title"`
+	// Prompt prefills the overlay; {{match}} is the matched text and
+	// {{clipboard}} the whole copy
+	Prompt string `json:"prompt"`
+	// Action is "notify" for a suggestion event or "overlay" to open the
+	// overlay with the prompt filled in
+	Action string `json:"action"`
+}
+
+// ClipboardSuggestionSettings turns the rules on; copies are only read
+// while it is enabled
+type ClipboardSuggestionSettings struct {
+	Enabled bool            `json:"enabled"`
+	Rules   []ClipboardRule `json:"rules"`
+}
+
+// ClipboardSuggestion is the payload of the clipboard-suggestion event
+type ClipboardSuggestion struct {
+	Rule   string `json:"rule"`
+	Title  string `json:"title"`
+	Prompt string `json:"prompt"`
+	Match  string `json:"match"`
+}
+
+func defaultClipboardRules() []ClipboardRule {
+	return []ClipboardRule{
+		{Name: "link", Pattern: `^https?://\S+$`, Title: "Summarize this link?", Prompt: "Summarize {{match}}", Action: "notify"},
+		{Name: "error", Pattern: `(?i)\b(error|exception|traceback|panic):`, Title: "Explain this error?", Prompt: "Explain this error:\n{{clipboard}}", Action: "notify"},
+		{Name: "tracking", Pattern: `\b1Z[0-9A-Z]{16}\b`, Title: "Track this package?", Prompt: "Where is the UPS package {{match}}?", Action: "notify"},
+	}
+}
+
+// SetClipboardSuggestions validates and saves the clipboard rules
+func (a *App) SetClipboardSuggestions(cfg ClipboardSuggestionSettings) error {
+	for i, r := range cfg.Rules {
+		if _, err := regexp.Compile(r.Pattern); err != nil {
+			return fmt.Errorf("rule %d: invalid pattern: %w", i+1, err)
+		}
+		switch r.Action {
+		case "notify", "overlay":
+		default:
+			return fmt.Errorf("rule %d: unknown action %q", i+1, r.Action)
+		}
+	}
+	return a.updateSettings(func(s *Settings) { s.ClipboardSuggestions = cfg })
+}
+
+// markOwnClipboard records text overlae is about to put on the clipboard,
+// such as a generated password, so the watcher never evaluates it
+func (a *App) markOwnClipboard(text string) {
+	a.clipboardMu.Lock()
+	defer a.clipboardMu.Unlock()
+	a.clipboardOwn = append(a.clipboardOwn, text)
+	if len(a.clipboardOwn) > ownClipboardMax {
+		a.clipboardOwn = a.clipboardOwn[1:]
+	}
+}
+
+func (a *App) isOwnClipboard(text string) bool {
+	a.clipboardMu.Lock()
+	defer a.clipboardMu.Unlock()
+	return slices.Contains(a.clipboardOwn, text)
+}
+
+// matchClipboardRule returns the first rule matching text and what it
+// matched
+func matchClipboardRule(rules []ClipboardRule, text string) (ClipboardRule, string, bool) {
+	for _, r := range rules {
+		re, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			continue
+		}
+		if m := re.FindString(text); m != "" {
+			return r, m, true
+		}
+	}
+	return ClipboardRule{}, "", false
+}
+
+// watchClipboard polls the clipboard, since no platform reports copies to
+// Wails, and evaluates the rules once per new copy
+func (a *App) watchClipboard(ctx context.Context) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	last := ""
+	primed := false
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		cfg := a.GetSettings().ClipboardSuggestions
+		if !cfg.Enabled {
+			if primed {
+				a.clearClipboardHistory()
+			}
+			primed = false
+			continue
+		}
+		text, err := wailsruntime.ClipboardGetText(a.ctx)
+		if err != nil || text == last {
+			continue
+		}
+		last = text
+		// Whatever was copied before enabling is not a new copy
+		if !primed {
+			primed = true
+			continue
+		}
+		text = strings.TrimSpace(text)
+		if text == "" || len(text) > clipboardScanMax || a.isOwnClipboard(last) {
+			continue
+		}
+		a.rememberCopy(text)
+		rule, match, ok := matchClipboardRule(cfg.Rules, text)
+		if !ok {
+			continue
+		}
+		prompt := strings.NewReplacer("{{match}}", match, "{{clipboard}}", text).Replace(rule.Prompt)
+		a.suggestFromClipboard(rule, ClipboardSuggestion{Rule: rule.Name, Title: rule.Title, Prompt: prompt, Match: match})
+	}
+}
+
+func (a *App) suggestFromClipboard(rule ClipboardRule, s ClipboardSuggestion) {
+	if rule.Action == "overlay" {
+		ev := a.showOverlayEvent("clipboard")
+		ev.Variant, ev.Prompt = "clipboard-suggestion", s.Prompt
+		eventShowOverlay.emit(a.ctx, ev)
+		return
+	}
+	eventClipboardSuggestion.emit(a.ctx, s)
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/wailsapp/wails/v2"
+	"github.com/wailsapp/wails/v2/pkg/options"
+	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	companionWidth  = 280
+	companionHeight = 90
+	companionMargin = 16
+)
+
+var companionCorners = []string{"top-left", "top-right", "bottom-left", "bottom-right"}
+
+// CompanionStatus is what the companion window currently displays
+type CompanionStatus struct {
+	Title string `json:"title"`
+	Text  string `json:"text"`
+	// Busy shows an activity indicator, e.g. while a response streams
+	Busy bool `json:"busy"`
+}
+
+// ToggleCompanion opens or closes the compact always-on-top companion
+// window and reports whether it is now open
+func (a *App) ToggleCompanion() (bool, error) {
+	a.companionMu.Lock()
+	defer a.companionMu.Unlock()
+
+	if a.companion.running() {
+		a.companion.close()
+		return false, nil
+	}
+
+	child, err := spawnChildWindow("companion", map[string]string{"corner": a.GetSettings().CompanionCorner}, nil)
+	if err != nil {
+		return false, err
+	}
+	a.companion = child
+	if err := child.send("status", a.companionStatus); err != nil {
+		return true, err
+	}
+	go func() {
+		<-child.done
+		eventCompanionToggled.emit(a.ctx, false)
+	}()
+	eventCompanionToggled.emit(a.ctx, true)
+	return true, nil
+}
+
+// MoveCompanion snaps the companion window to a screen corner and
+// remembers the choice
+func (a *App) MoveCompanion(corner string) error {
+	if !validCompanionCorner(corner) {
+		return fmt.Errorf("unknown corner %q", corner)
+	}
+	if err := a.updateSettings(func(s *Settings) { s.CompanionCorner = corner }); err != nil {
+		return err
+	}
+
+	a.companionMu.Lock()
+	defer a.companionMu.Unlock()
+	if !a.companion.running() {
+		return nil
+	}
+	return a.companion.send("move", corner)
+}
+
+// SetCompanionStatus updates what the companion window shows
+func (a *App) SetCompanionStatus(status CompanionStatus) error {
+	a.companionMu.Lock()
+	defer a.companionMu.Unlock()
+
+	a.companionStatus = status
+	if !a.companion.running() {
+		return nil
+	}
+	return a.companion.send("status", status)
+}
+
+func validCompanionCorner(corner string) bool {
+	for _, c := range companionCorners {
+		if c == corner {
+			return true
+		}
+	}
+	return false
+}
+
+// CompanionWindow is bound in the companion child process
+type CompanionWindow struct {
+	ctx    context.Context
+	corner string
+
+	mu     sync.Mutex
+	status CompanionStatus
+}
+
+// runCompanionWindow runs the small frameless companion window
+func runCompanionWindow(flags map[string]string) error {
+	w := &CompanionWindow{corner: flags["corner"]}
+	return wails.Run(&options.App{
+		Title:            "Overlae Companion",
+		Width:            companionWidth,
+		Height:           companionHeight,
+		DisableResize:    true,
+		Frameless:        true,
+		AlwaysOnTop:      true,
+		BackgroundColour: &options.RGBA{R: 27, G: 38, B: 54, A: 1},
+		AssetServer: &assetserver.Options{
+			Assets: assets,
+		},
+		OnStartup: w.startup,
+		Bind: []interface{}{
+			w,
+			&WindowInfo{kind: "companion"},
+		},
+	})
+}
+
+func (w *CompanionWindow) startup(ctx context.Context) {
+	w.ctx = ctx
+	w.moveTo(w.corner)
+
+	go readParentCommands(func(cmd childCommand) {
+		switch cmd.Cmd {
+		case "status":
+			var status CompanionStatus
+			if json.Unmarshal(cmd.Payload, &status) == nil {
+				w.mu.Lock()
+				w.status = status
+				w.mu.Unlock()
+				eventCompanionStatus.emit(ctx, status)
+			}
+		case "move":
+			var corner string
+			if json.Unmarshal(cmd.Payload, &corner) == nil {
+				w.moveTo(corner)
+			}
+		case "close":
+			wailsruntime.Quit(ctx)
+		}
+	}, func() { wailsruntime.Quit(ctx) })
+}
+
+// moveTo places the window in a corner of the current screen
+func (w *CompanionWindow) moveTo(corner string) {
+	screens, err := wailsruntime.ScreenGetAll(w.ctx)
+	if err != nil {
+		return
+	}
+	for _, s := range screens {
+		if !s.IsCurrent {
+			continue
+		}
+		x, y := companionMargin, companionMargin
+		switch corner {
+		case "top-left":
+		case "bottom-left":
+			y = s.Size.Height - companionHeight - companionMargin
+		case "bottom-right":
+			x = s.Size.Width - companionWidth - companionMargin
+			y = s.Size.Height - companionHeight - companionMargin
+		default: // top-right
+			x = s.Size.Width - companionWidth - companionMargin
+		}
+		wailsruntime.WindowSetPosition(w.ctx, x, y)
+	}
+}
+
+// GetStatus returns the status last pushed by the main overlay
+func (w *CompanionWindow) GetStatus() CompanionStatus {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.status
+}
+
+// Close quits the companion window
+func (w *CompanionWindow) Close() {
+	wailsruntime.Quit(w.ctx)
+}
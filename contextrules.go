@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ContextRule picks the initial overlay mode when the hotkey is pressed
+// while a matching application is frontmost
+type ContextRule struct {
+	// App is matched case-insensitively against the app name, its ID and
+	// the base name of the ID, and may contain * wildcards
+	App           string `json:"app"`
+	TitleContains string `json:"titleContains"`
+	Mode          string `json:"mode"`
+	// AttachSelection captures the selection in the matching app. It only
+	// applies while the selection privacy toggle allows it.
+	AttachSelection bool `json:"attachSelection"`
+}
+
+// ShowContext is the payload of the show-overlay event
+type ShowContext struct {
+	Mode      string    `json:"mode"`
+	App       ActiveApp `json:"app"`
+	Selection string    `json:"selection,omitempty"`
+	// Page is the browser tab in front, when the extension reported it
+	Page *PageContext `json:"page,omitempty"`
+}
+
+func (r ContextRule) matches(app ActiveApp) bool {
+	pattern := strings.ToLower(r.App)
+	if pattern == "" {
+		return false
+	}
+	candidates := []string{app.Name, app.ID, filepath.Base(app.ID)}
+	matched := false
+	for _, c := range candidates {
+		if ok, _ := path.Match(pattern, strings.ToLower(c)); ok && c != "" {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return false
+	}
+	return r.TitleContains == "" || strings.Contains(strings.ToLower(app.WindowTitle), strings.ToLower(r.TitleContains))
+}
+
+// matchContextRule returns the first rule matching app
+func matchContextRule(rules []ContextRule, app ActiveApp) (ContextRule, bool) {
+	for _, r := range rules {
+		if r.matches(app) {
+			return r, true
+		}
+	}
+	return ContextRule{}, false
+}
+
+// resolveShowContext inspects the frontmost app and decides which mode the
+// overlay should open in. It must run before the overlay takes focus.
+func (a *App) resolveShowContext() ShowContext {
+	s := a.GetSettings()
+	show := ShowContext{Mode: s.DefaultMode}
+	defer func() { a.rememberShowContext(show) }()
+
+	app, err := activeApp()
+	if err != nil {
+		return show
+	}
+	show.App = app
+	show.Page = a.currentPage(app)
+	a.noteRecentApp(app)
+
+	// The privacy toggle wins over a rule asking for the selection
+	attach := s.ContextPrivacy.Selection
+	if rule, ok := matchContextRule(s.ContextRules, app); ok {
+		show.Mode = rule.Mode
+		attach = attach && rule.AttachSelection
+	}
+	if attach {
+		show.Selection, _ = a.captureSelection()
+	}
+	return show
+}
+
+// SetContextRules validates and persists the per-application rules
+func (a *App) SetContextRules(rules []ContextRule) error {
+	for i, r := range rules {
+		if r.App == "" || r.Mode == "" {
+			return fmt.Errorf("rule %d: app and mode are required", i+1)
+		}
+		if _, err := path.Match(strings.ToLower(r.App), ""); err != nil {
+			return fmt.Errorf("rule %d: invalid app pattern %q", i+1, r.App)
+		}
+	}
+	return a.updateSettings(func(s *Settings) { s.ContextRules = rules })
+}
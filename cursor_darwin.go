@@ -0,0 +1,23 @@
+package main
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Cocoa
+#import <Cocoa/Cocoa.h>
+
+static void ovCursorPosition(int *x, int *y) {
+	NSPoint p = [NSEvent mouseLocation];
+	NSArray<NSScreen *> *screens = [NSScreen screens];
+	CGFloat primaryHeight = screens.count > 0 ? screens[0].frame.size.height : 0;
+	*x = p.x;
+	*y = primaryHeight - p.y;
+}
+*/
+import "C"
+
+// cursorPosition returns the mouse location in desktop points
+func cursorPosition() (int, int, error) {
+	var x, y C.int
+	C.ovCursorPosition(&x, &y)
+	return int(x), int(y), nil
+}
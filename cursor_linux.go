@@ -0,0 +1,26 @@
+package main
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// cursorPosition asks xdotool for the mouse location
+func cursorPosition() (int, int, error) {
+	out, err := exec.Command("xdotool", "getmouselocation", "--shell").Output()
+	if err != nil {
+		return 0, 0, err
+	}
+	var x, y int
+	for _, line := range strings.Fields(string(out)) {
+		k, v, _ := strings.Cut(line, "=")
+		switch k {
+		case "X":
+			x, _ = strconv.Atoi(v)
+		case "Y":
+			y, _ = strconv.Atoi(v)
+		}
+	}
+	return x, y, nil
+}
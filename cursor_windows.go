@@ -0,0 +1,17 @@
+package main
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+var procGetCursorPos = user32.NewProc("GetCursorPos")
+
+// cursorPosition returns the mouse location in physical pixels
+func cursorPosition() (int, int, error) {
+	var p struct{ X, Y int32 }
+	if ok, _, err := procGetCursorPos.Call(uintptr(unsafe.Pointer(&p))); ok == 0 {
+		return 0, 0, fmt.Errorf("GetCursorPos: %w", err)
+	}
+	return int(p.X), int(p.Y), nil
+}
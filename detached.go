@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2"
+	"github.com/wailsapp/wails/v2/pkg/options"
+	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const detachedWindowsFile = "detached-windows.json"
+
+// DetachedContent is a response or note shown in its own window
+type DetachedContent struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Content string `json:"content"`
+	// Format is "markdown" or "text"
+	Format    string    `json:"format"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type detachedEntry struct {
+	content DetachedContent
+	child   *childWindow
+}
+
+// DetachWindow opens content in its own persistent window. Detached
+// windows are reopened on the next launch until they are closed.
+func (a *App) DetachWindow(title, content, format string) (DetachedContent, error) {
+	if format == "" {
+		format = "markdown"
+	}
+	d := DetachedContent{
+		ID:        newID(),
+		Title:     title,
+		Content:   content,
+		Format:    format,
+		CreatedAt: time.Now(),
+	}
+	if err := a.openDetached(d); err != nil {
+		return DetachedContent{}, err
+	}
+	return d, a.saveDetached()
+}
+
+// CloseDetachedWindow closes a detached window and forgets it
+func (a *App) CloseDetachedWindow(id string) error {
+	a.detachedMu.Lock()
+	entry, ok := a.detached[id]
+	delete(a.detached, id)
+	a.detachedMu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("no detached window %q", id)
+	}
+	entry.child.close()
+	return a.saveDetached()
+}
+
+// ListDetachedWindows returns the open detached windows, oldest first
+func (a *App) ListDetachedWindows() []DetachedContent {
+	a.detachedMu.Lock()
+	defer a.detachedMu.Unlock()
+
+	list := make([]DetachedContent, 0, len(a.detached))
+	for _, e := range a.detached {
+		list = append(list, e.content)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+	return list
+}
+
+// restoreDetachedWindows reopens the windows that were open at last exit
+func (a *App) restoreDetachedWindows() {
+	var saved []DetachedContent
+	if err := readJSONConfig(detachedWindowsFile, &saved); err != nil {
+		return
+	}
+	for _, d := range saved {
+		_ = a.openDetached(d)
+	}
+}
+
+func (a *App) openDetached(d DetachedContent) error {
+	child, err := spawnChildWindow("detached", map[string]string{"id": d.ID}, nil)
+	if err != nil {
+		return err
+	}
+	if err := child.send("content", d); err != nil {
+		child.close()
+		return err
+	}
+
+	a.detachedMu.Lock()
+	if a.detached == nil {
+		a.detached = map[string]*detachedEntry{}
+	}
+	a.detached[d.ID] = &detachedEntry{content: d, child: child}
+	a.detachedMu.Unlock()
+
+	go func() {
+		<-child.done
+		// Windows closed by the user are forgotten; windows closed because
+		// the app is quitting are kept so they come back on next launch
+		if a.shuttingDown.Load() {
+			return
+		}
+		a.detachedMu.Lock()
+		entry, ok := a.detached[d.ID]
+		if ok && entry.child == child {
+			delete(a.detached, d.ID)
+		}
+		a.detachedMu.Unlock()
+		if ok {
+			_ = a.saveDetached()
+		}
+		eventDetachedChanged.emit(a.ctx, a.ListDetachedWindows())
+	}()
+	eventDetachedChanged.emit(a.ctx, a.ListDetachedWindows())
+	return nil
+}
+
+func (a *App) saveDetached() error {
+	return writeJSONConfig(detachedWindowsFile, a.ListDetachedWindows(), 0o644)
+}
+
+// closeDetachedWindows closes every detached window without forgetting them
+func (a *App) closeDetachedWindows() {
+	a.detachedMu.Lock()
+	entries := make([]*detachedEntry, 0, len(a.detached))
+	for _, e := range a.detached {
+		entries = append(entries, e)
+	}
+	a.detachedMu.Unlock()
+
+	for _, e := range entries {
+		e.child.close()
+	}
+}
+
+// DetachedWindow is bound in a detached child process
+type DetachedWindow struct {
+	ctx context.Context
+
+	mu      sync.Mutex
+	content DetachedContent
+}
+
+// runDetachedWindow runs a regular resizable window showing one piece of
+// content sent by the parent
+func runDetachedWindow(map[string]string) error {
+	w := &DetachedWindow{}
+	return wails.Run(&options.App{
+		Title:            "Overlae",
+		Width:            480,
+		Height:           600,
+		MinWidth:         240,
+		MinHeight:        160,
+		BackgroundColour: &options.RGBA{R: 27, G: 38, B: 54, A: 1},
+		AssetServer: &assetserver.Options{
+			Assets: assets,
+		},
+		OnStartup: w.startup,
+		Bind: []interface{}{
+			w,
+			&WindowInfo{kind: "detached"},
+		},
+	})
+}
+
+func (w *DetachedWindow) startup(ctx context.Context) {
+	w.ctx = ctx
+
+	go readParentCommands(func(cmd childCommand) {
+		switch cmd.Cmd {
+		case "content":
+			var d DetachedContent
+			if json.Unmarshal(cmd.Payload, &d) == nil {
+				w.mu.Lock()
+				w.content = d
+				w.mu.Unlock()
+				wailsruntime.WindowSetTitle(ctx, d.Title)
+				eventDetachedContent.emit(ctx, d)
+			}
+		case "close":
+			wailsruntime.Quit(ctx)
+		}
+	}, func() { wailsruntime.Quit(ctx) })
+}
+
+// GetContent returns the content this window shows
+func (w *DetachedWindow) GetContent() DetachedContent {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.content
+}
+
+// Close quits the detached window
+func (w *DetachedWindow) Close() {
+	wailsruntime.Quit(w.ctx)
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// dictionaryFile is the imported offline dataset in the config
+	// directory
+	dictionaryFile = "dictionary.json"
+	// dictionaryCacheDir keeps online lookups under the cache directory
+	dictionaryCacheDir = "dictionary"
+	dictionaryTimeout  = 5 * time.Second
+	// dictionaryAPI is the free dictionaryapi.dev endpoint
+	dictionaryAPI = "https://api.dictionaryapi.dev/api/v2/entries/"
+)
+
+// defineQuery matches "define ephemeral" and "synonyms for happy"
+var defineQuery = regexp.MustCompile(`(?i)^\s*(?:define|definition of|synonyms?(?: for| of)?|thesaurus)\s+(.+?)\s*$`)
+
+// DictionarySettings controls word lookups
+type DictionarySettings struct {
+	// Offline never fetches; only the imported dataset and earlier
+	// lookups answer
+	Offline bool `json:"offline"`
+	// Language is the dictionaryapi.dev language code
+	Language string `json:"language"`
+}
+
+// Definition is one sense of a word
+type Definition struct {
+	Text    string `json:"text"`
+	Example string `json:"example,omitempty"`
+}
+
+// Meaning groups the senses of one part of speech
+type Meaning struct {
+	PartOfSpeech string       `json:"partOfSpeech"`
+	Definitions  []Definition `json:"definitions"`
+}
+
+// DictionaryEntry is what a lookup returns. Source is "offline", "cache"
+// or "online".
+type DictionaryEntry struct {
+	Word     string    `json:"word"`
+	Phonetic string    `json:"phonetic,omitempty"`
+	AudioURL string    `json:"audioUrl,omitempty"`
+	Meanings []Meaning `json:"meanings"`
+	Synonyms []string  `json:"synonyms,omitempty"`
+	Antonyms []string  `json:"antonyms,omitempty"`
+	Source   string    `json:"source"`
+}
+
+// IsDefineQuery reports whether overlay input such as "define ephemeral"
+// should go to Define instead of the model
+func (a *App) IsDefineQuery(input string) bool {
+	return defineQuery.MatchString(input)
+}
+
+// Define looks up a word, given alone or as "define <word>", without
+// asking the model: the offline dataset first, then earlier lookups, then
+// dictionaryapi.dev unless offline mode is on
+func (a *App) Define(query string) (DictionaryEntry, error) {
+	word := query
+	if m := defineQuery.FindStringSubmatch(query); m != nil {
+		word = m[1]
+	}
+	word = strings.ToLower(strings.Trim(strings.TrimSpace(word), `"'?.!`))
+	if word == "" || strings.ContainsAny(word, "/\\") {
+		return DictionaryEntry{}, errors.New("enter a word to define")
+	}
+	cfg := a.GetSettings().Dictionary
+	if cfg.Language == "" {
+		cfg.Language = "en"
+	}
+
+	if e, ok := a.offlineEntry(word); ok {
+		return e, nil
+	}
+	cachePath := ""
+	if dir, err := cacheDir(); err == nil {
+		cachePath = filepath.Join(dir, dictionaryCacheDir, cfg.Language, url.PathEscape(word)+".json")
+		var e DictionaryEntry
+		if data, err := os.ReadFile(cachePath); err == nil && json.Unmarshal(data, &e) == nil {
+			e.Source = "cache"
+			return e, nil
+		}
+	}
+	if cfg.Offline {
+		return DictionaryEntry{}, fmt.Errorf("%q is not in the offline dictionary", word)
+	}
+
+	ctx, cancel := context.WithTimeout(a.ctx, dictionaryTimeout)
+	defer cancel()
+	e, err := fetchDefinition(ctx, cfg.Language, word)
+	if err != nil {
+		return DictionaryEntry{}, err
+	}
+	if cachePath != "" {
+		if data, err := json.Marshal(e); err == nil && os.MkdirAll(filepath.Dir(cachePath), 0o755) == nil {
+			_ = writeFileAtomic(cachePath, data, 0o600)
+		}
+	}
+	return e, nil
+}
+
+func fetchDefinition(ctx context.Context, lang, word string) (DictionaryEntry, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, dictionaryAPI+url.PathEscape(lang)+"/"+url.PathEscape(word), nil)
+	if err != nil {
+		return DictionaryEntry{}, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return DictionaryEntry{}, err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return DictionaryEntry{}, fmt.Errorf("no definitions found for %q", word)
+	case resp.StatusCode != http.StatusOK:
+		return DictionaryEntry{}, fmt.Errorf("dictionary returned %s", resp.Status)
+	}
+
+	var body []struct {
+		Word      string `json:"word"`
+		Phonetic  string `json:"phonetic"`
+		Phonetics []struct {
+			Text  string `json:"text"`
+			Audio string `json:"audio"`
+		} `json:"phonetics"`
+		Meanings []struct {
+			PartOfSpeech string `json:"partOfSpeech"`
+			Definitions  []struct {
+				Definition string   `json:"definition"`
+				Example    string   `json:"example"`
+				Synonyms   []string `json:"synonyms"`
+				Antonyms   []string `json:"antonyms"`
+			} `json:"definitions"`
+			Synonyms []string `json:"synonyms"`
+			Antonyms []string `json:"antonyms"`
+		} `json:"meanings"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil || len(body) == 0 {
+		return DictionaryEntry{}, fmt.Errorf("invalid dictionary response for %q", word)
+	}
+
+	// The API splits homographs into several entries; merge them
+	e := DictionaryEntry{Word: body[0].Word, Meanings: []Meaning{}, Source: "online"}
+	for _, b := range body {
+		if e.Phonetic == "" {
+			e.Phonetic = b.Phonetic
+		}
+		for _, p := range b.Phonetics {
+			if e.Phonetic == "" {
+				e.Phonetic = p.Text
+			}
+			if e.AudioURL == "" {
+				e.AudioURL = p.Audio
+			}
+		}
+		for _, m := range b.Meanings {
+			meaning := Meaning{PartOfSpeech: m.PartOfSpeech}
+			e.Synonyms = appendUnique(e.Synonyms, m.Synonyms...)
+			e.Antonyms = appendUnique(e.Antonyms, m.Antonyms...)
+			for _, d := range m.Definitions {
+				meaning.Definitions = append(meaning.Definitions, Definition{Text: d.Definition, Example: d.Example})
+				e.Synonyms = appendUnique(e.Synonyms, d.Synonyms...)
+				e.Antonyms = appendUnique(e.Antonyms, d.Antonyms...)
+			}
+			e.Meanings = append(e.Meanings, meaning)
+		}
+	}
+	return e, nil
+}
+
+func appendUnique(list []string, items ...string) []string {
+	for _, s := range items {
+		if !slices.Contains(list, s) {
+			list = append(list, s)
+		}
+	}
+	return list
+}
+
+// offlineEntry looks word up in the imported dataset, loading it on first
+// use
+func (a *App) offlineEntry(word string) (DictionaryEntry, bool) {
+	a.dictionaryMu.Lock()
+	defer a.dictionaryMu.Unlock()
+	if a.dictionary == nil {
+		a.dictionary = map[string]DictionaryEntry{}
+		var entries []DictionaryEntry
+		if err := readJSONConfig(dictionaryFile, &entries); err != nil {
+			println("Error reading offline dictionary:", err.Error())
+		}
+		for _, e := range entries {
+			a.dictionary[strings.ToLower(e.Word)] = e
+		}
+	}
+	e, ok := a.dictionary[word]
+	e.Source = "offline"
+	return e, ok
+}
+
+// ImportDictionary installs an offline dataset: a JSON array of entries
+// shaped like DictionaryEntry. It returns how many words it holds.
+func (a *App) ImportDictionary(path string) (int, error) {
+	if err := a.requirePermission(capFiles); err != nil {
+		return 0, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, fmt.Errorf("%s does not exist", path)
+	}
+	if err != nil {
+		return 0, err
+	}
+	var entries []DictionaryEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return 0, fmt.Errorf("invalid dictionary: %w", err)
+	}
+	entries = slices.DeleteFunc(entries, func(e DictionaryEntry) bool { return strings.TrimSpace(e.Word) == "" })
+	if err := writeJSONConfig(dictionaryFile, entries, 0o644); err != nil {
+		return 0, err
+	}
+	a.dictionaryMu.Lock()
+	a.dictionary = nil
+	a.dictionaryMu.Unlock()
+	return len(entries), nil
+}
+
+// SetDictionary saves the lookup settings
+func (a *App) SetDictionary(cfg DictionarySettings) error {
+	if cfg.Language == "" {
+		cfg.Language = "en"
+	}
+	return a.updateSettings(func(s *Settings) { s.Dictionary = cfg })
+}
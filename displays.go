@@ -0,0 +1,48 @@
+package main
+
+// Display is a monitor in the global desktop coordinate space
+type Display struct {
+	// ID identifies the physical monitor across reconnects where the
+	// platform allows it
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Bounds Rect   `json:"bounds"`
+	// WorkArea excludes the menu bar, dock and taskbar
+	WorkArea Rect    `json:"workArea"`
+	Scale    float64 `json:"scale"`
+	Primary  bool    `json:"primary"`
+}
+
+// GetDisplays returns all connected displays
+func (a *App) GetDisplays() ([]Display, error) {
+	return listDisplays()
+}
+
+// toFrame converts logical units to the units window frames use on d
+func (d Display) toFrame(n int) int {
+	if !physicalFrames || d.Scale <= 0 {
+		return n
+	}
+	return int(float64(n)*d.Scale + 0.5)
+}
+
+// fromFrame converts window frame units on d to logical units
+func (d Display) fromFrame(n int) int {
+	if !physicalFrames || d.Scale <= 0 {
+		return n
+	}
+	return int(float64(n)/d.Scale + 0.5)
+}
+
+// cursorDisplay returns the display under the mouse cursor, falling back
+// to the primary display
+func cursorDisplay(displays []Display) (Display, bool) {
+	if x, y, err := cursorPosition(); err == nil {
+		for _, d := range displays {
+			if d.Bounds.contains(x, y) {
+				return d, true
+			}
+		}
+	}
+	return primaryDisplay(displays)
+}
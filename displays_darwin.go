@@ -0,0 +1,71 @@
+package main
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Cocoa
+#import <Cocoa/Cocoa.h>
+
+typedef struct {
+	int x, y, w, h;
+} ovRect;
+
+typedef struct {
+	unsigned int vendor, model, serial;
+	ovRect frame, visible;
+	double scale;
+	int primary;
+	char name[128];
+} ovDisplay;
+
+// Cocoa uses a bottom-left origin anchored at the primary screen; flip to
+// the top-left origin used everywhere else
+static ovRect ovFlip(NSRect r, CGFloat primaryHeight) {
+	return (ovRect){r.origin.x, primaryHeight - r.origin.y - r.size.height, r.size.width, r.size.height};
+}
+
+static int ovListDisplays(ovDisplay *out, int max) {
+	NSArray<NSScreen *> *screens = [NSScreen screens];
+	if (screens.count == 0) return 0;
+	CGFloat primaryHeight = screens[0].frame.size.height;
+	int n = 0;
+	for (NSScreen *s in screens) {
+		if (n >= max) break;
+		CGDirectDisplayID did = [[s.deviceDescription objectForKey:@"NSScreenNumber"] unsignedIntValue];
+		out[n].vendor = CGDisplayVendorNumber(did);
+		out[n].model = CGDisplayModelNumber(did);
+		out[n].serial = CGDisplaySerialNumber(did);
+		out[n].frame = ovFlip(s.frame, primaryHeight);
+		out[n].visible = ovFlip(s.visibleFrame, primaryHeight);
+		out[n].scale = s.backingScaleFactor;
+		out[n].primary = n == 0;
+		NSString *name = @"";
+		if (@available(macOS 10.15, *)) name = s.localizedName;
+		strlcpy(out[n].name, name.UTF8String, sizeof(out[n].name));
+		n++;
+	}
+	return n;
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+func listDisplays() ([]Display, error) {
+	var raw [16]C.ovDisplay
+	n := int(C.ovListDisplays(&raw[0], C.int(len(raw))))
+	displays := make([]Display, 0, n)
+	for _, d := range raw[:n] {
+		displays = append(displays, Display{
+			ID:       fmt.Sprintf("%x-%x-%x", uint32(d.vendor), uint32(d.model), uint32(d.serial)),
+			Name:     C.GoString((*C.char)(unsafe.Pointer(&d.name[0]))),
+			Bounds:   Rect{X: int(d.frame.x), Y: int(d.frame.y), Width: int(d.frame.w), Height: int(d.frame.h)},
+			WorkArea: Rect{X: int(d.visible.x), Y: int(d.visible.y), Width: int(d.visible.w), Height: int(d.visible.h)},
+			Scale:    float64(d.scale),
+			Primary:  d.primary != 0,
+		})
+	}
+	return displays, nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"os/exec"
+	"regexp"
+	"strconv"
+)
+
+var xrandrMonitor = regexp.MustCompile(`(?m)^(\S+) connected( primary)? (\d+)x(\d+)\+(\d+)\+(\d+)`)
+
+// listDisplays parses xrandr; panels are not reported so the work area
+// equals the monitor bounds
+func listDisplays() ([]Display, error) {
+	out, err := exec.Command("xrandr", "--query").Output()
+	if err != nil {
+		return nil, err
+	}
+	var displays []Display
+	for _, m := range xrandrMonitor.FindAllStringSubmatch(string(out), -1) {
+		w, _ := strconv.Atoi(m[3])
+		h, _ := strconv.Atoi(m[4])
+		x, _ := strconv.Atoi(m[5])
+		y, _ := strconv.Atoi(m[6])
+		bounds := Rect{X: x, Y: y, Width: w, Height: h}
+		displays = append(displays, Display{
+			ID:       m[1],
+			Name:     m[1],
+			Bounds:   bounds,
+			WorkArea: bounds,
+			Scale:    1,
+			Primary:  m[2] != "",
+		})
+	}
+	return displays, nil
+}
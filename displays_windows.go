@@ -0,0 +1,111 @@
+package main
+
+import (
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	shcore                  = syscall.NewLazyDLL("shcore.dll")
+	procEnumDisplayMonitors = user32.NewProc("EnumDisplayMonitors")
+	procGetMonitorInfoW     = user32.NewProc("GetMonitorInfoW")
+	procGetDpiForMonitor    = shcore.NewProc("GetDpiForMonitor")
+	procEnumDisplayDevicesW = user32.NewProc("EnumDisplayDevicesW")
+)
+
+const (
+	monitorInfoFPrimary       = 0x1
+	mdtEffectiveDPI           = 0
+	eddGetDeviceInterfaceName = 0x1
+)
+
+type displayDevice struct {
+	Size         uint32
+	DeviceName   [32]uint16
+	DeviceString [128]uint16
+	StateFlags   uint32
+	DeviceID     [128]uint16
+	DeviceKey    [128]uint16
+}
+
+// monitorIdentity returns the device interface path of the monitor
+// attached to an adapter output such as \\.\DISPLAY1. Unlike the output
+// name it contains the monitor's hardware ID, so it follows the physical
+// monitor when docking changes the output numbering.
+func monitorIdentity(device string) (string, string) {
+	name, err := syscall.UTF16PtrFromString(device)
+	if err != nil {
+		return device, device
+	}
+	dd := displayDevice{}
+	dd.Size = uint32(unsafe.Sizeof(dd))
+	if r, _, _ := procEnumDisplayDevicesW.Call(uintptr(unsafe.Pointer(name)), 0, uintptr(unsafe.Pointer(&dd)), eddGetDeviceInterfaceName); r == 0 {
+		return device, device
+	}
+	id := syscall.UTF16ToString(dd.DeviceID[:])
+	if id == "" {
+		id = device
+	}
+	return id, syscall.UTF16ToString(dd.DeviceString[:])
+}
+
+type monitorInfoEx struct {
+	Size    uint32
+	Monitor win32Rect
+	Work    win32Rect
+	Flags   uint32
+	Device  [32]uint16
+}
+
+// The runtime never frees a callback, and displays are listed every few
+// seconds, so EnumDisplayMonitors is always passed this one. It appends to
+// enumeratedDisplays, which listDisplaysMu guards.
+var (
+	monitorCallback = syscall.NewCallback(func(hmon, _, _, _ uintptr) uintptr {
+		if d, ok := monitorDisplay(hmon); ok {
+			enumeratedDisplays = append(enumeratedDisplays, d)
+		}
+		return 1
+	})
+	listDisplaysMu     sync.Mutex
+	enumeratedDisplays []Display
+)
+
+// listDisplays enumerates monitors in physical pixels, which matches the
+// per-monitor DPI awareness Wails enables
+func listDisplays() ([]Display, error) {
+	listDisplaysMu.Lock()
+	defer listDisplaysMu.Unlock()
+	enumeratedDisplays = nil
+	procEnumDisplayMonitors.Call(0, 0, monitorCallback, 0)
+	displays := enumeratedDisplays
+	enumeratedDisplays = nil
+	return displays, nil
+}
+
+func monitorDisplay(hmon uintptr) (Display, bool) {
+	info := monitorInfoEx{}
+	info.Size = uint32(unsafe.Sizeof(info))
+	if r, _, _ := procGetMonitorInfoW.Call(hmon, uintptr(unsafe.Pointer(&info))); r == 0 {
+		return Display{}, false
+	}
+
+	scale := 1.0
+	var dpiX, dpiY uint32
+	if procGetDpiForMonitor.Find() == nil {
+		if r, _, _ := procGetDpiForMonitor.Call(hmon, mdtEffectiveDPI, uintptr(unsafe.Pointer(&dpiX)), uintptr(unsafe.Pointer(&dpiY))); r == 0 && dpiX > 0 {
+			scale = float64(dpiX) / 96
+		}
+	}
+
+	id, name := monitorIdentity(syscall.UTF16ToString(info.Device[:]))
+	return Display{
+		ID:       id,
+		Name:     name,
+		Bounds:   info.Monitor.toRect(),
+		WorkArea: info.Work.toRect(),
+		Scale:    scale,
+		Primary:  info.Flags&monitorInfoFPrimary != 0,
+	}, true
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DisplayChange is the payload of the display-changed event
+type DisplayChange struct {
+	Displays []Display `json:"displays"`
+	// Current is the display the overlay is on after the change
+	Current Display `json:"current"`
+}
+
+// displaySignature changes whenever monitors are added, removed,
+// rearranged or rescaled
+func displaySignature(displays []Display) string {
+	parts := make([]string, 0, len(displays))
+	for _, d := range displays {
+		parts = append(parts, fmt.Sprintf("%s@%v:%v:%.2f", d.ID, d.Bounds, d.WorkArea, d.Scale))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, "|")
+}
+
+// watchDisplays polls the display configuration. Neither Wails nor the
+// hotkey loop get notified of monitor changes, and a window left on an
+// unplugged or rescaled monitor would otherwise keep a stale size or end
+// up off-screen.
+func (a *App) watchDisplays(ctx context.Context) {
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	var last []Display
+	lastSig := ""
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		displays, err := listDisplays()
+		if err != nil {
+			continue
+		}
+		sig := displaySignature(displays)
+		if lastSig == "" || sig == lastSig {
+			last, lastSig = displays, sig
+			continue
+		}
+
+		current := a.keepOnScreen(last, displays)
+		last, lastSig = displays, sig
+		eventDisplayChanged.emit(a.ctx, DisplayChange{Displays: displays, Current: current})
+	}
+}
+
+// keepOnScreen keeps the overlay on a connected display with the same
+// logical size after the display configuration changed from before to after
+func (a *App) keepOnScreen(before, after []Display) Display {
+	frame, err := ownWindowFrame()
+	if err != nil {
+		current, _ := primaryDisplay(after)
+		return current
+	}
+	old, _ := displayFor(before, frame)
+	current, _ := displayFor(after, frame)
+
+	width, height := frame.Width, frame.Height
+	if current.ID != old.ID {
+		// The overlay's monitor went away and the nearest remaining one was
+		// picked, so carry the logical size across. Scale changes of the
+		// same monitor are already applied by the OS (WM_DPICHANGED).
+		width = current.toFrame(old.fromFrame(frame.Width))
+		height = current.toFrame(old.fromFrame(frame.Height))
+	}
+	target := clampInto(Rect{X: frame.X, Y: frame.Y, Width: width, Height: height}, current.WorkArea)
+	if target != frame {
+		_ = setOwnWindowFrame(target)
+	}
+	return current
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// downloadProgressInterval throttles download-progress events
+const downloadProgressInterval = 100 * time.Millisecond
+
+// Download is a file being saved to the downloads folder
+type Download struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Path string `json:"path"`
+	// Size is -1 while unknown, e.g. for a server that sends no length
+	Size    int64 `json:"size"`
+	Written int64 `json:"written"`
+	// Status is "running", "done" or "failed"
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// downloadsDir returns the folder downloads go to, creating it if needed
+func (a *App) downloadsDir() (string, error) {
+	dir := a.GetSettings().DownloadsDir
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, "Downloads")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// SetDownloadsDir sets the default downloads folder; empty means the
+// user's Downloads folder
+func (a *App) SetDownloadsDir(dir string) error {
+	if dir != "" {
+		info, err := os.Stat(dir)
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("%s is not a folder", dir)
+		}
+	}
+	return a.updateSettings(func(s *Settings) { s.DownloadsDir = dir })
+}
+
+// createUnique creates name in dir, adding " (1)", " (2)" and so on
+// before the extension when the name is taken
+func createUnique(dir, name string) (*os.File, error) {
+	name = filepath.Base(strings.NewReplacer("/", "_", "\\", "_").Replace(name))
+	if name == "." || name == "" {
+		name = "download"
+	}
+	ext := filepath.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	for i := 0; i < 1000; i++ {
+		candidate := name
+		if i > 0 {
+			candidate = fmt.Sprintf("%s (%d)%s", stem, i, ext)
+		}
+		f, err := os.OpenFile(filepath.Join(dir, candidate), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		return f, err
+	}
+	return nil, fmt.Errorf("too many files named %s", name)
+}
+
+// startDownload saves r as name in the downloads folder in the background,
+// reporting download-progress events tagged with the returned ID. open is
+// called on the background goroutine, so slow sources do not block.
+func (a *App) startDownload(name string, open func() (io.ReadCloser, int64, error)) (string, error) {
+	if err := a.requirePermission(capFiles); err != nil {
+		return "", err
+	}
+	dir, err := a.downloadsDir()
+	if err != nil {
+		return "", err
+	}
+	f, err := createUnique(dir, name)
+	if err != nil {
+		return "", err
+	}
+	d := &Download{ID: newID(), Name: filepath.Base(f.Name()), Path: f.Name(), Size: -1, Status: "running", CreatedAt: time.Now()}
+	a.downloadsMu.Lock()
+	a.downloads = append(a.downloads, d)
+	a.downloadsMu.Unlock()
+
+	go func() {
+		err := a.runDownload(d, f, open)
+		a.downloadsMu.Lock()
+		if err != nil {
+			d.Status, d.Error = "failed", err.Error()
+			_ = os.Remove(d.Path)
+		} else {
+			d.Status = "done"
+		}
+		snapshot := *d
+		a.downloadsMu.Unlock()
+		eventDownloadProgress.respond(a.ctx, d.ID, snapshot, err)
+	}()
+	return d.ID, nil
+}
+
+func (a *App) runDownload(d *Download, f *os.File, open func() (io.ReadCloser, int64, error)) error {
+	defer f.Close()
+	src, size, err := open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	a.downloadsMu.Lock()
+	d.Size = size
+	a.downloadsMu.Unlock()
+
+	buf := make([]byte, 64*1024)
+	last := time.Now()
+	for {
+		n, rerr := src.Read(buf)
+		if n > 0 {
+			if _, err := f.Write(buf[:n]); err != nil {
+				return err
+			}
+			a.downloadsMu.Lock()
+			d.Written += int64(n)
+			snapshot := *d
+			a.downloadsMu.Unlock()
+			if time.Since(last) >= downloadProgressInterval {
+				last = time.Now()
+				eventDownloadProgress.respond(a.ctx, d.ID, snapshot, nil)
+			}
+		}
+		if rerr == io.EOF {
+			return f.Sync()
+		}
+		if rerr != nil {
+			return rerr
+		}
+	}
+}
+
+func bytesSource(data []byte) func() (io.ReadCloser, int64, error) {
+	return func() (io.ReadCloser, int64, error) {
+		return io.NopCloser(bytes.NewReader(data)), int64(len(data)), nil
+	}
+}
+
+// DownloadExport saves a history record as PNG, Markdown or PDF straight
+// into the downloads folder, without a save dialog
+func (a *App) DownloadExport(id, format string) (string, error) {
+	if _, ok := exportFilters[format]; !ok {
+		return "", fmt.Errorf("unknown export format %q", format)
+	}
+	doc, err := a.exportDocument(id)
+	if err != nil {
+		return "", err
+	}
+	data, err := doc.encode(format)
+	if err != nil {
+		return "", err
+	}
+	return a.startDownload("overlae-"+time.Now().Format("20060102-150405")+"."+format, bytesSource(data))
+}
+
+// DownloadAttachment copies a file attached to a history record, such as
+// a recording, into the downloads folder
+func (a *App) DownloadAttachment(id string, index int) (string, error) {
+	if a.history == nil {
+		return "", errors.New("history is unavailable")
+	}
+	r, ok := a.history.get(id)
+	if !ok {
+		return "", fmt.Errorf("no history record %q", id)
+	}
+	if index < 0 || index >= len(r.Attachments) || r.Attachments[index].Path == "" {
+		return "", fmt.Errorf("record %q has no file attachment %d", id, index)
+	}
+	src := r.Attachments[index].Path
+	return a.startDownload(r.Attachments[index].Kind+filepath.Ext(src), func() (io.ReadCloser, int64, error) {
+		f, err := os.Open(src)
+		if err != nil {
+			return nil, 0, err
+		}
+		info, err := f.Stat()
+		if err != nil {
+			f.Close()
+			return nil, 0, err
+		}
+		return f, info.Size(), nil
+	})
+}
+
+// DownloadURL fetches a file linked from a response, e.g. a generated
+// image, over http or https
+func (a *App) DownloadURL(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("only http and https links can be downloaded")
+	}
+	name := path.Base(u.Path)
+	if name == "/" || name == "." {
+		name = u.Hostname()
+	}
+	return a.startDownload(name, func() (io.ReadCloser, int64, error) {
+		resp, err := http.Get(u.String())
+		if err != nil {
+			return nil, 0, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, 0, fmt.Errorf("server returned %s", resp.Status)
+		}
+		return resp.Body, resp.ContentLength, nil
+	})
+}
+
+// ListDownloads returns this session's downloads, newest first
+func (a *App) ListDownloads() []Download {
+	a.downloadsMu.Lock()
+	defer a.downloadsMu.Unlock()
+	out := make([]Download, 0, len(a.downloads))
+	for i := len(a.downloads) - 1; i >= 0; i-- {
+		out = append(out, *a.downloads[i])
+	}
+	return out
+}
+
+// RevealDownload shows a finished download in the file manager
+func (a *App) RevealDownload(id string) error {
+	a.downloadsMu.Lock()
+	var found *Download
+	for _, d := range a.downloads {
+		if d.ID == id {
+			found = d
+		}
+	}
+	a.downloadsMu.Unlock()
+	if found == nil {
+		return fmt.Errorf("no download %q", id)
+	}
+	if _, err := os.Stat(found.Path); err != nil {
+		return err
+	}
+	return revealInFolder(found.Path)
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"crypto/subtle"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/websocket"
+)
+
+const localAPITokenFile = "local-api-token"
+
+// bridgedEvents are the events external subscribers may receive
+var bridgedEvents = []string{
+	eventShowOverlay.name,
+	eventWindowState.name,
+	eventRequestQueued.name,
+	eventRequestStarted.name,
+	eventResponseChunk.name,
+	eventResponseDone.name,
+	eventNoteSaved.name,
+}
+
+// eventHub fans events out to WebSocket subscribers. A subscriber that
+// falls behind loses events rather than stalling the emitter.
+type eventHub struct {
+	mu   sync.Mutex
+	subs map[chan EventEnvelope]func(EventEnvelope) bool
+}
+
+// bridge receives every event the backend emits; see eventType.send
+var bridge eventHub
+
+func (h *eventHub) publish(env EventEnvelope) {
+	if !slices.Contains(bridgedEvents, env.Name) {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch, keep := range h.subs {
+		if keep != nil && !keep(env) {
+			continue
+		}
+		select {
+		case ch <- env:
+		default:
+		}
+	}
+}
+
+// subscribe returns a channel of the bridged events keep accepts; nil
+// keeps all of them
+func (h *eventHub) subscribe(keep func(EventEnvelope) bool) chan EventEnvelope {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.subs == nil {
+		h.subs = map[chan EventEnvelope]func(EventEnvelope) bool{}
+	}
+	ch := make(chan EventEnvelope, 256)
+	h.subs[ch] = keep
+	return ch
+}
+
+func (h *eventHub) unsubscribe(ch chan EventEnvelope) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.subs[ch]; ok {
+		delete(h.subs, ch)
+		close(ch)
+	}
+}
+
+// closeAll ends every stream, e.g. when the local API stops
+func (h *eventHub) closeAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs {
+		delete(h.subs, ch)
+		close(ch)
+	}
+}
+
+// GetLocalAPIToken returns the token integrations must present, creating
+// it on first use. It stays on this machine and is never synced.
+func (a *App) GetLocalAPIToken() (string, error) {
+	path, err := configPath(localAPITokenFile)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if err == nil && len(strings.TrimSpace(string(data))) > 0 {
+		return strings.TrimSpace(string(data)), nil
+	}
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+	token := newID() + newID()
+	return token, writeFileAtomic(path, []byte(token+"\n"), 0o600)
+}
+
+// requireAPIToken accepts the token as a bearer header or, for browser
+// WebSocket clients that cannot set headers, as the token query parameter
+func (a *App) requireAPIToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, err := a.GetLocalAPIToken()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok {
+			got = r.URL.Query().Get("token")
+		}
+		if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// eventStreamHandler streams bridged events as JSON envelopes, one per
+// message. ?events=show-overlay,response-done narrows the stream.
+func (a *App) eventStreamHandler() http.Handler {
+	return websocket.Server{
+		// The token already authenticates the client; stream deck
+		// plugins and OBS browser sources send all kinds of origins
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(ws *websocket.Conn) {
+			defer ws.Close()
+			var keep func(EventEnvelope) bool
+			if q := ws.Request().URL.Query().Get("events"); q != "" {
+				only := strings.Split(q, ",")
+				keep = func(env EventEnvelope) bool { return slices.Contains(only, env.Name) }
+			}
+
+			ch := bridge.subscribe(keep)
+			defer bridge.unsubscribe(ch)
+			// Incoming messages are ignored; reading only notices the close
+			go func() {
+				_, _ = io.Copy(io.Discard, ws)
+				bridge.unsubscribe(ch)
+			}()
+			for env := range ch {
+				if err := websocket.JSON.Send(ws, env); err != nil {
+					return
+				}
+			}
+		},
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// eventVersion is bumped whenever an event payload changes incompatibly,
+// so the frontend can tell an old contract from a new one
+const eventVersion = 1
+
+// EventEnvelope wraps every event payload sent to the frontend; see
+// frontend/src/events.ts for the matching types
+type EventEnvelope struct {
+	Name    string    `json:"name"`
+	Version int       `json:"version"`
+	Time    time.Time `json:"time"`
+	// RequestID ties a response to the call that started it
+	RequestID string `json:"requestId,omitempty"`
+	Error     string `json:"error,omitempty"`
+	Data      any    `json:"data"`
+}
+
+// eventType is an event name bound to its payload type, so emitting the
+// wrong payload for a name fails to compile
+type eventType[T any] struct {
+	name string
+}
+
+func (e eventType[T]) emit(ctx context.Context, data T) {
+	e.send(ctx, EventEnvelope{Data: data})
+}
+
+// respond emits the result of the request identified by requestID; a
+// non-nil err is reported in the envelope instead of data
+func (e eventType[T]) respond(ctx context.Context, requestID string, data T, err error) {
+	env := EventEnvelope{RequestID: requestID, Data: data}
+	if err != nil {
+		env.Error = err.Error()
+	}
+	e.send(ctx, env)
+}
+
+func (e eventType[T]) send(ctx context.Context, env EventEnvelope) {
+	if ctx == nil {
+		return
+	}
+	env.Name, env.Version, env.Time = e.name, eventVersion, time.Now()
+	wailsruntime.EventsEmit(ctx, e.name, env)
+	bridge.publish(env)
+}
+
+// newRequestID identifies an asynchronous call whose result arrives later
+// as a response event
+func newRequestID() string {
+	return newID()
+}
+
+// ShowOverlayEvent asks the frontend to open the overlay
+type ShowOverlayEvent struct {
+	// Reason is what triggered it: "hotkey", "mouse", "voice" or
+	// "clipboard"
+	Reason string `json:"reason"`
+	// Variant is the hotkey binding pressed, e.g. "show-overlay" or
+	// "show-note"; see overlayVariants
+	Variant string `json:"variant,omitempty"`
+	// RequestID and Prompt are set when the backend already sent a
+	// prompt, e.g. for screenshot-prompt, and the overlay should open on
+	// its answer
+	RequestID string `json:"requestId,omitempty"`
+	Prompt    string `json:"prompt,omitempty"`
+	// Dictate asks the overlay to start dictation right away
+	Dictate bool `json:"dictate,omitempty"`
+	// Monitor is the ID of the display under the cursor, if known
+	Monitor string      `json:"monitor,omitempty"`
+	Context ShowContext `json:"context"`
+}
+
+// Every event the backend emits. Payloadless events carry struct{}.
+var (
+	eventShowOverlay            = eventType[ShowOverlayEvent]{"show-overlay"}
+	eventRequestQueued          = eventType[RequestQueued]{"request-queued"}
+	eventRequestStarted         = eventType[RequestStarted]{"request-started"}
+	eventResponseChunk          = eventType[ResponseChunk]{"response-chunk"}
+	eventResponseDone           = eventType[ResponseResult]{"response-done"}
+	eventSettingsChanged        = eventType[Settings]{"settings-changed"}
+	eventClipboardCleared       = eventType[struct{}]{"clipboard-cleared"}
+	eventSyncCompleted          = eventType[SyncResult]{"sync-completed"}
+	eventDisplayChanged         = eventType[DisplayChange]{"display-changed"}
+	eventOverlaySnapped         = eventType[string]{"overlay-snapped"}
+	eventPlacementsReset        = eventType[struct{}]{"overlay-placements-reset"}
+	eventHotkeysEnabled         = eventType[bool]{"hotkeys-enabled-changed"}
+	eventKeyboardLayout         = eventType[string]{"keyboard-layout-changed"}
+	eventHotkeyCaptured         = eventType[HotkeyCapture]{"hotkey-captured"}
+	eventHotkeyCaptureCancelled = eventType[string]{"hotkey-capture-cancelled"}
+	eventWindowState            = eventType[WindowState]{"window-state-changed"}
+	eventAnnotationOpened       = eventType[struct{}]{"annotation-opened"}
+	eventAnnotationClosed       = eventType[struct{}]{"annotation-closed"}
+	eventAnnotationError        = eventType[string]{"annotation-error"}
+	eventClickThrough           = eventType[bool]{"click-through-changed"}
+	eventCompanionToggled       = eventType[bool]{"companion-toggled"}
+	eventCompanionStatus        = eventType[CompanionStatus]{"companion-status"}
+	eventDetachedChanged        = eventType[[]DetachedContent]{"detached-windows-changed"}
+	eventDetachedContent        = eventType[DetachedContent]{"detached-content"}
+	eventThemeChanged           = eventType[Theme]{"theme-changed"}
+	eventZoomChanged            = eventType[AppearanceSettings]{"zoom-changed"}
+	eventKeyValidated           = eventType[ModelCatalog]{"provider-key-validated"}
+	eventRecordingDone          = eventType[Recording]{"recording-done"}
+	eventAudioDevicesChanged    = eventType[AudioDeviceChange]{"audio-devices-changed"}
+	eventWakeWordStatus         = eventType[WakeWordStatus]{"wake-word-status"}
+	eventClipboardSuggestion    = eventType[ClipboardSuggestion]{"clipboard-suggestion"}
+	eventDownloadProgress       = eventType[Download]{"download-progress"}
+	eventTranslation            = eventType[Translation]{"translation"}
+	eventNetToolOutput          = eventType[NetToolLine]{"net-tool-output"}
+	eventNetToolDone            = eventType[NetToolResult]{"net-tool-done"}
+	eventQueryLocal             = eventType[QueryLocalResults]{"query-local-results"}
+	eventQueryEscalated         = eventType[QueryEscalation]{"query-escalated"}
+	eventSelectionHintShown     = eventType[[]string]{"selection-hint-shown"}
+	eventKeymapUpdated          = eventType[[]KeyBinding]{"keymap-updated"}
+	eventSelfCheck              = eventType[SelfCheck]{"self-check"}
+	eventNoteSaved              = eventType[HistoryRecord]{"note-saved"}
+	eventOnboarding             = eventType[Onboarding]{"onboarding-updated"}
+	eventWhatsNew               = eventType[[]ReleaseNote]{"whats-new"}
+	eventRequestFailover        = eventType[RequestFailover]{"request-failover"}
+	eventGlobalSearch           = eventType[GlobalSearchResults]{"global-search-results"}
+)
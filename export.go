@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"strings"
+	"time"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// Exported images are laid out in points and rendered at exportScale so
+// they stay sharp on high-DPI screens
+const (
+	exportWidth   = 720
+	exportPadding = 32
+	exportScale   = 2
+)
+
+// exportDoc is a response prepared for rendering: the question it
+// answered, the text and a footer line with the model and date
+type exportDoc struct {
+	Title  string
+	Body   string
+	Footer string
+}
+
+// exportDocument looks up a record and the input it answered
+func (a *App) exportDocument(id string) (exportDoc, error) {
+	if a.history == nil {
+		return exportDoc{}, errors.New("history is unavailable")
+	}
+	r, ok := a.history.get(id)
+	if !ok {
+		return exportDoc{}, fmt.Errorf("no history record %q", id)
+	}
+	doc := exportDoc{Body: strings.TrimSpace(r.Text)}
+	if r.RequestID != "" && r.Kind != "input" {
+		inputs := a.history.list(func(in HistoryRecord) bool { return in.Kind == "input" && in.RequestID == r.RequestID })
+		if len(inputs) > 0 {
+			doc.Title, _, _ = strings.Cut(strings.TrimSpace(inputs[0].Text), "\n")
+			if len([]rune(doc.Title)) > 120 {
+				doc.Title = string([]rune(doc.Title)[:119]) + "…"
+			}
+		}
+	}
+	footer := []string{}
+	if r.Model != "" {
+		footer = append(footer, r.Model)
+	}
+	doc.Footer = strings.Join(append(footer, r.CreatedAt.Local().Format("2 Jan 2006 15:04")), " · ")
+	return doc, nil
+}
+
+// exportBlock is one run of text in the rendered image. Sizes are in
+// points; Space is the gap below the block.
+type exportBlock struct {
+	Text  string
+	Size  float64
+	Bold  bool
+	Muted bool
+	Space float64
+}
+
+// blocks is the layout every platform renderer draws, top to bottom
+func (d exportDoc) blocks() []exportBlock {
+	var blocks []exportBlock
+	if d.Title != "" {
+		blocks = append(blocks, exportBlock{Text: d.Title, Size: 17, Bold: true, Space: 14})
+	}
+	return append(blocks,
+		exportBlock{Text: d.Body, Size: 14, Space: 20},
+		exportBlock{Text: d.Footer, Size: 11, Muted: true},
+	)
+}
+
+func (d exportDoc) markdown() string {
+	var b strings.Builder
+	if d.Title != "" {
+		fmt.Fprintf(&b, "## %s\n\n", d.Title)
+	}
+	b.WriteString(d.Body)
+	fmt.Fprintf(&b, "\n\n---\n\n_%s_\n", d.Footer)
+	return b.String()
+}
+
+// encode renders d in format: "png", "md" or "pdf"
+func (d exportDoc) encode(format string) ([]byte, error) {
+	switch format {
+	case "md":
+		return []byte(d.markdown()), nil
+	case "png":
+		return renderDocumentPNG(d)
+	case "pdf":
+		return documentsPDF(d)
+	default:
+		return nil, fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+// documentsPDF renders docs into one PDF, each starting on a new page
+func documentsPDF(docs ...exportDoc) ([]byte, error) {
+	imgs := make([]image.Image, len(docs))
+	for i, d := range docs {
+		data, err := renderDocumentPNG(d)
+		if err != nil {
+			return nil, err
+		}
+		if imgs[i], err = png.Decode(bytes.NewReader(data)); err != nil {
+			return nil, err
+		}
+	}
+	var buf bytes.Buffer
+	err := writeImagePDF(&buf, imgs...)
+	return buf.Bytes(), err
+}
+
+var exportFilters = map[string]wailsruntime.FileFilter{
+	"png": {DisplayName: "PNG Image (*.png)", Pattern: "*.png"},
+	"md":  {DisplayName: "Markdown (*.md)", Pattern: "*.md"},
+	"pdf": {DisplayName: "PDF Document (*.pdf)", Pattern: "*.pdf"},
+}
+
+// ExportResponse renders a history record as a PNG, Markdown or PDF file
+// and saves it where the user picks. It returns the saved path, or "" if
+// the dialog was cancelled.
+func (a *App) ExportResponse(id, format string) (string, error) {
+	filter, ok := exportFilters[format]
+	if !ok {
+		return "", fmt.Errorf("unknown export format %q", format)
+	}
+	doc, err := a.exportDocument(id)
+	if err != nil {
+		return "", err
+	}
+	data, err := doc.encode(format)
+	if err != nil {
+		return "", err
+	}
+
+	path, err := wailsruntime.SaveFileDialog(a.ctx, wailsruntime.SaveDialogOptions{
+		Title:           "Export Response",
+		DefaultFilename: "overlae-" + time.Now().Format("20060102-150405") + "." + format,
+		Filters:         []wailsruntime.FileFilter{filter},
+	})
+	if err != nil || path == "" {
+		return "", err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// CopyResponseImage renders a history record as a PNG and puts it on the
+// clipboard for pasting into chats and documents
+func (a *App) CopyResponseImage(id string) error {
+	doc, err := a.exportDocument(id)
+	if err != nil {
+		return err
+	}
+	data, err := renderDocumentPNG(doc)
+	if err != nil {
+		return err
+	}
+	return copyImageToClipboard(data)
+}
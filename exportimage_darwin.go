@@ -0,0 +1,116 @@
+package main
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Cocoa
+#import <Cocoa/Cocoa.h>
+#include <stdlib.h>
+
+static void ovOnMain(void (^block)(void)) {
+	if ([NSThread isMainThread]) block();
+	else dispatch_sync(dispatch_get_main_queue(), block);
+}
+
+// ovRenderBlocks stacks the text blocks in a white image width points wide
+// and returns it as malloc'd PNG data
+static void *ovRenderBlocks(char **texts, double *sizes, int *bold, int *muted, double *space, int n,
+		double width, double pad, double scale, int *outLen) {
+	__block void *out = NULL;
+	ovOnMain(^{
+		@autoreleasepool {
+			NSMutableArray *strs = [NSMutableArray array];
+			double heights[n];
+			double total = pad * 2;
+			for (int i = 0; i < n; i++) {
+				NSFont *font = bold[i] ? [NSFont boldSystemFontOfSize:sizes[i]] : [NSFont systemFontOfSize:sizes[i]];
+				NSColor *color = [NSColor colorWithWhite:(muted[i] ? 0.5 : 0.1) alpha:1];
+				NSAttributedString *s = [[[NSAttributedString alloc]
+					initWithString:[NSString stringWithUTF8String:texts[i]]
+					attributes:@{NSFontAttributeName: font, NSForegroundColorAttributeName: color}] autorelease];
+				heights[i] = ceil([s boundingRectWithSize:NSMakeSize(width - pad * 2, CGFLOAT_MAX)
+					options:NSStringDrawingUsesLineFragmentOrigin].size.height);
+				total += heights[i] + space[i];
+				[strs addObject:s];
+			}
+
+			NSBitmapImageRep *rep = [[[NSBitmapImageRep alloc] initWithBitmapDataPlanes:NULL
+				pixelsWide:(NSInteger)(width * scale) pixelsHigh:(NSInteger)(total * scale)
+				bitsPerSample:8 samplesPerPixel:4 hasAlpha:YES isPlanar:NO
+				colorSpaceName:NSDeviceRGBColorSpace bytesPerRow:0 bitsPerPixel:0] autorelease];
+			rep.size = NSMakeSize(width, total);
+
+			[NSGraphicsContext saveGraphicsState];
+			[NSGraphicsContext setCurrentContext:[NSGraphicsContext graphicsContextWithBitmapImageRep:rep]];
+			[[NSColor whiteColor] setFill];
+			NSRectFill(NSMakeRect(0, 0, width, total));
+			double top = pad;
+			for (int i = 0; i < n; i++) {
+				// AppKit's origin is the bottom left
+				[strs[i] drawInRect:NSMakeRect(pad, total - top - heights[i], width - pad * 2, heights[i])];
+				top += heights[i] + space[i];
+			}
+			[NSGraphicsContext restoreGraphicsState];
+
+			NSData *png = [rep representationUsingType:NSBitmapImageFileTypePNG properties:@{}];
+			*outLen = (int)png.length;
+			out = malloc(png.length);
+			memcpy(out, png.bytes, png.length);
+		}
+	});
+	return out;
+}
+
+static void ovCopyPNG(const void *data, int n) {
+	ovOnMain(^{
+		NSPasteboard *pb = [NSPasteboard generalPasteboard];
+		[pb clearContents];
+		[pb setData:[NSData dataWithBytes:data length:n] forType:NSPasteboardTypePNG];
+	});
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// renderDocumentPNG draws doc with AppKit's text system in the system font
+func renderDocumentPNG(doc exportDoc) ([]byte, error) {
+	blocks := doc.blocks()
+	n := len(blocks)
+	texts := make([]*C.char, n)
+	sizes := make([]C.double, n)
+	bold := make([]C.int, n)
+	muted := make([]C.int, n)
+	space := make([]C.double, n)
+	for i, b := range blocks {
+		texts[i] = C.CString(b.Text)
+		defer C.free(unsafe.Pointer(texts[i]))
+		sizes[i], space[i] = C.double(b.Size), C.double(b.Space)
+		if b.Bold {
+			bold[i] = 1
+		}
+		if b.Muted {
+			muted[i] = 1
+		}
+	}
+
+	var length C.int
+	data := C.ovRenderBlocks(&texts[0], &sizes[0], &bold[0], &muted[0], &space[0], C.int(n),
+		exportWidth, exportPadding, exportScale, &length)
+	if data == nil {
+		return nil, errors.New("could not render image")
+	}
+	defer C.free(data)
+	return C.GoBytes(data, length), nil
+}
+
+// copyImageToClipboard puts PNG data on the general pasteboard
+func copyImageToClipboard(png []byte) error {
+	if len(png) == 0 {
+		return errors.New("image is empty")
+	}
+	C.ovCopyPNG(unsafe.Pointer(&png[0]), C.int(len(png)))
+	return nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// renderDocumentPNG draws doc with ImageMagick, stacking one caption per
+// block
+func renderDocumentPNG(doc exportDoc) ([]byte, error) {
+	tool := "magick"
+	if _, err := exec.LookPath(tool); err != nil {
+		tool = "convert"
+		if _, err := exec.LookPath(tool); err != nil {
+			return nil, fmt.Errorf("no image renderer found (install imagemagick)")
+		}
+	}
+
+	px := func(pt float64) string { return strconv.Itoa(int(pt * exportScale)) }
+	textWidth := px(exportWidth - 2*exportPadding)
+	args := []string{"-background", "white"}
+	for _, b := range doc.blocks() {
+		color, weight := "#1a1a1a", "400"
+		if b.Muted {
+			color = "#808080"
+		}
+		if b.Bold {
+			weight = "700"
+		}
+		args = append(args, "(", "-size", textWidth+"x", "-fill", color, "-weight", weight,
+			"-pointsize", px(b.Size), "caption:"+escapeMagickText(b.Text),
+			"-gravity", "south", "-splice", "0x"+px(b.Space), ")")
+	}
+	args = append(args, "-append", "-bordercolor", "white", "-border", px(exportPadding), "png:-")
+
+	var stderr bytes.Buffer
+	cmd := exec.Command(tool, args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", tool, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// escapeMagickText stops ImageMagick treating the text as a file
+// reference or expanding percent escapes in it
+func escapeMagickText(s string) string {
+	s = strings.ReplaceAll(s, "%", "%%")
+	if strings.HasPrefix(s, "@") {
+		s = `\` + s
+	}
+	return s
+}
+
+// copyImageToClipboard hands PNG data to wl-copy or xclip
+func copyImageToClipboard(png []byte) error {
+	tools := [][]string{
+		{"wl-copy", "--type", "image/png"},
+		{"xclip", "-selection", "clipboard", "-t", "image/png", "-i"},
+	}
+	for _, tool := range tools {
+		if _, err := exec.LookPath(tool[0]); err != nil {
+			continue
+		}
+		cmd := exec.Command(tool[0], tool[1:]...)
+		cmd.Stdin = bytes.NewReader(png)
+		return cmd.Run()
+	}
+	return fmt.Errorf("no clipboard tool found (install wl-clipboard or xclip)")
+}
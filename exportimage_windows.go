@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"runtime"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	procCreateFontW              = gdi32.NewProc("CreateFontW")
+	procSetTextColor             = gdi32.NewProc("SetTextColor")
+	procSetBkMode                = gdi32.NewProc("SetBkMode")
+	procPatBlt                   = gdi32.NewProc("PatBlt")
+	procDrawTextW                = user32.NewProc("DrawTextW")
+	procOpenClipboard            = user32.NewProc("OpenClipboard")
+	procEmptyClipboard           = user32.NewProc("EmptyClipboard")
+	procSetClipboardData         = user32.NewProc("SetClipboardData")
+	procCloseClipboard           = user32.NewProc("CloseClipboard")
+	procRegisterClipboardFormatW = user32.NewProc("RegisterClipboardFormatW")
+	procGlobalAlloc              = kernel32.NewProc("GlobalAlloc")
+	procGlobalLock               = kernel32.NewProc("GlobalLock")
+	procGlobalUnlock             = kernel32.NewProc("GlobalUnlock")
+	procGlobalFree               = kernel32.NewProc("GlobalFree")
+)
+
+const (
+	whiteness          = 0x00FF0062
+	bkTransparent      = 1
+	antialiasedQuality = 4
+	dtWordBreak        = 0x10
+	dtExpandTabs       = 0x40
+	dtNoPrefix         = 0x800
+	dtCalcRect         = 0x400
+	cfDIB              = 8
+	gmemMoveable       = 0x2
+)
+
+// renderDocumentPNG draws doc with GDI in Segoe UI
+func renderDocumentPNG(doc exportDoc) ([]byte, error) {
+	screenDC, _, _ := procGetDC.Call(0)
+	if screenDC == 0 {
+		return nil, fmt.Errorf("GetDC failed")
+	}
+	defer procReleaseDC.Call(0, screenDC)
+	memDC, _, _ := procCreateCompatibleDC.Call(screenDC)
+	defer procDeleteDC.Call(memDC)
+
+	face, _ := syscall.UTF16PtrFromString("Segoe UI")
+	blocks := doc.blocks()
+	fonts := make([]uintptr, len(blocks))
+	texts := make([][]uint16, len(blocks))
+	heights := make([]int32, len(blocks))
+	pad := int32(exportPadding * exportScale)
+	width := int32(exportWidth * exportScale)
+	flags := uintptr(dtWordBreak | dtExpandTabs | dtNoPrefix)
+
+	total := 2 * pad
+	for i, b := range blocks {
+		weight := 400
+		if b.Bold {
+			weight = 700
+		}
+		// A negative height asks for that character height in pixels
+		height := -int(b.Size * exportScale)
+		fonts[i], _, _ = procCreateFontW.Call(uintptr(height), 0, 0, 0, uintptr(weight), 0, 0, 0, 1, 0, 0, antialiasedQuality, 0, uintptr(unsafe.Pointer(face)))
+		defer procDeleteObject.Call(fonts[i])
+		texts[i], _ = syscall.UTF16FromString(b.Text)
+
+		procSelectObject.Call(memDC, fonts[i])
+		rc := win32Rect{Right: width - 2*pad}
+		procDrawTextW.Call(memDC, uintptr(unsafe.Pointer(&texts[i][0])), ^uintptr(0), uintptr(unsafe.Pointer(&rc)), flags|dtCalcRect)
+		heights[i] = rc.Bottom
+		total += heights[i] + int32(b.Space*exportScale)
+	}
+
+	bitmap, _, _ := procCreateCompatibleBitmap.Call(screenDC, uintptr(width), uintptr(total))
+	if bitmap == 0 {
+		return nil, fmt.Errorf("CreateCompatibleBitmap failed")
+	}
+	defer procDeleteObject.Call(bitmap)
+	procSelectObject.Call(memDC, bitmap)
+	procPatBlt.Call(memDC, 0, 0, uintptr(width), uintptr(total), whiteness)
+	procSetBkMode.Call(memDC, bkTransparent)
+
+	top := pad
+	for i, b := range blocks {
+		color := uintptr(0x1a1a1a)
+		if b.Muted {
+			color = 0x808080
+		}
+		procSelectObject.Call(memDC, fonts[i])
+		procSetTextColor.Call(memDC, color)
+		rc := win32Rect{Left: pad, Top: top, Right: width - pad, Bottom: top + heights[i]}
+		procDrawTextW.Call(memDC, uintptr(unsafe.Pointer(&texts[i][0])), ^uintptr(0), uintptr(unsafe.Pointer(&rc)), flags)
+		top += heights[i] + int32(b.Space*exportScale)
+	}
+
+	header := bitmapInfoHeader{Width: width, Height: -total, Planes: 1, BitCount: 32}
+	header.Size = uint32(unsafe.Sizeof(header))
+	img := image.NewRGBA(image.Rect(0, 0, int(width), int(total)))
+	if r, _, err := procGetDIBits.Call(memDC, bitmap, 0, uintptr(total), uintptr(unsafe.Pointer(&img.Pix[0])), uintptr(unsafe.Pointer(&header)), dibRGBColors); r == 0 {
+		return nil, fmt.Errorf("GetDIBits: %w", err)
+	}
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+2], img.Pix[i+3] = img.Pix[i+2], img.Pix[i], 0xff
+	}
+
+	var buf bytes.Buffer
+	err := png.Encode(&buf, img)
+	return buf.Bytes(), err
+}
+
+// copyImageToClipboard offers the image both as a DIB, which every app
+// understands, and as PNG for the apps that prefer it
+func copyImageToClipboard(data []byte) error {
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	if r, _, err := procOpenClipboard.Call(0); r == 0 {
+		return fmt.Errorf("OpenClipboard: %w", err)
+	}
+	defer procCloseClipboard.Call()
+	procEmptyClipboard.Call()
+
+	if err := setClipboardBytes(cfDIB, imageDIB(img)); err != nil {
+		return err
+	}
+	name, _ := syscall.UTF16PtrFromString("PNG")
+	if format, _, _ := procRegisterClipboardFormatW.Call(uintptr(unsafe.Pointer(name))); format != 0 {
+		return setClipboardBytes(format, data)
+	}
+	return nil
+}
+
+func setClipboardBytes(format uintptr, data []byte) error {
+	h, _, err := procGlobalAlloc.Call(gmemMoveable, uintptr(len(data)))
+	if h == 0 {
+		return fmt.Errorf("GlobalAlloc: %w", err)
+	}
+	p, _, _ := procGlobalLock.Call(h)
+	if p == 0 {
+		procGlobalFree.Call(h)
+		return errors.New("GlobalLock failed")
+	}
+	copy(unsafe.Slice(*(**byte)(unsafe.Pointer(&p)), len(data)), data)
+	procGlobalUnlock.Call(h)
+	// The clipboard owns the memory once SetClipboardData succeeds
+	if r, _, err := procSetClipboardData.Call(format, h); r == 0 {
+		procGlobalFree.Call(h)
+		return fmt.Errorf("SetClipboardData: %w", err)
+	}
+	return nil
+}
+
+// imageDIB encodes img as a bottom-up 32-bit packed DIB
+func imageDIB(img image.Image) []byte {
+	b := img.Bounds()
+	header := bitmapInfoHeader{Width: int32(b.Dx()), Height: int32(b.Dy()), Planes: 1, BitCount: 32}
+	header.Size = uint32(unsafe.Sizeof(header))
+	header.SizeImage = uint32(4 * b.Dx() * b.Dy())
+
+	dib := make([]byte, 0, int(header.Size)+int(header.SizeImage))
+	dib = append(dib, unsafe.Slice((*byte)(unsafe.Pointer(&header)), header.Size)...)
+	for y := b.Max.Y - 1; y >= b.Min.Y; y-- {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			r, g, bl, a := img.At(x, y).RGBA()
+			dib = append(dib, byte(bl>>8), byte(g>>8), byte(r>>8), byte(a>>8))
+		}
+	}
+	return dib
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// defaultFailoverTimeout is how long a provider may take to start
+// answering before the next one is tried
+const defaultFailoverTimeout = 30 * time.Second
+
+// FailoverSettings lists the providers a request moves on to, in order,
+// when the one before it fails or times out
+type FailoverSettings struct {
+	// Providers are tried after the primary. Empty fields are taken from
+	// the primary, except the key, which is only shared when the endpoint
+	// is too; a fallback that only sets Model switches models.
+	Providers []ProviderSettings `json:"providers"`
+	// TimeoutSec is how long one may take to send its first token before
+	// the next is tried; 0 means 30 seconds
+	TimeoutSec int `json:"timeoutSec,omitempty"`
+}
+
+// ProviderFailure is a provider a request gave up on
+type ProviderFailure struct {
+	Model string `json:"model"`
+	Error string `json:"error"`
+}
+
+// RequestFailover is the payload of the request-failover event, sent when
+// a request moves on to the next provider
+type RequestFailover struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Error string `json:"error"`
+}
+
+// providerCandidate is one provider a request may be sent to
+type providerCandidate struct {
+	p   provider
+	cfg ProviderSettings
+}
+
+// SetFailover saves the fallback providers, rejecting unknown kinds and
+// negative timeouts
+func (a *App) SetFailover(cfg FailoverSettings) error {
+	for i, fb := range cfg.Providers {
+		if _, err := newProvider(ProviderSettings{Kind: fb.Kind}, ProviderCredentials{}); err != nil {
+			return fmt.Errorf("fallback %d: %w", i+1, err)
+		}
+	}
+	if cfg.TimeoutSec < 0 {
+		return errors.New("timeout cannot be negative")
+	}
+	return a.updateSettings(func(s *Settings) { s.Failover = cfg })
+}
+
+// withFallback fills fb's empty fields from primary
+func withFallback(primary, fb ProviderSettings) ProviderSettings {
+	if fb.Kind != "" || fb.BaseURL != "" {
+		primary.KeyID = ""
+	}
+	if fb.Kind != "" {
+		primary.Kind = fb.Kind
+	}
+	if fb.BaseURL != "" {
+		primary.BaseURL = fb.BaseURL
+	}
+	if fb.Model != "" {
+		primary.Model = fb.Model
+	}
+	if fb.KeyID != "" {
+		primary.KeyID = fb.KeyID
+	}
+	return primary
+}
+
+// failoverChain returns the primary followed by the configured fallbacks.
+// A fallback that cannot be built, e.g. for a missing key, is left out.
+func (a *App) failoverChain(p provider, cfg ProviderSettings) []providerCandidate {
+	chain := []providerCandidate{{p, cfg}}
+	for _, fb := range a.GetSettings().Failover.Providers {
+		fp, fcfg, err := a.providerFor(withFallback(cfg, fb))
+		if err != nil {
+			println("Error preparing fallback provider:", err.Error())
+			continue
+		}
+		chain = append(chain, providerCandidate{fp, fcfg})
+	}
+	return chain
+}
+
+// streamWithFailover streams req from each candidate in turn until one
+// answers. Once a provider has streamed text the request stays with it,
+// since the text is already on screen. It returns the candidate that
+// answered, or the last one tried, and the ones given up on.
+func (a *App) streamWithFailover(ctx context.Context, id string, chain []providerCandidate, req chatRequest, onDelta func(string)) (chatResult, providerCandidate, []ProviderFailure, error) {
+	timeout := defaultFailoverTimeout
+	if s := a.GetSettings().Failover.TimeoutSec; s > 0 {
+		timeout = time.Duration(s) * time.Second
+	}
+	var failures []ProviderFailure
+	for i, c := range chain {
+		req.Model = c.cfg.Model
+		last := i == len(chain)-1
+		attemptCtx, cancel := context.WithCancel(ctx)
+		var firstToken *time.Timer
+		if !last {
+			firstToken = time.AfterFunc(timeout, cancel)
+		}
+		streamed := false
+		res, err := c.p.stream(attemptCtx, req, func(delta string) {
+			if !streamed && firstToken != nil {
+				firstToken.Stop()
+			}
+			streamed = true
+			onDelta(delta)
+		})
+		if firstToken != nil && !firstToken.Stop() && !streamed && ctx.Err() == nil {
+			err = fmt.Errorf("no answer within %s", timeout)
+		}
+		cancel()
+		// Cancelling the request, or streamed text, stops here
+		if last || streamed || ctx.Err() != nil || (err == nil && res.Text != "") {
+			return res, c, failures, err
+		}
+		if err == nil {
+			err = errors.New("empty response")
+		}
+		failures = append(failures, ProviderFailure{Model: c.cfg.Model, Error: err.Error()})
+		next := chain[i+1].cfg.Model
+		println("Error from provider", c.cfg.Model+", trying", next+":", err.Error())
+		eventRequestFailover.respond(a.ctx, id, RequestFailover{From: c.cfg.Model, To: next, Error: err.Error()}, nil)
+	}
+	return chatResult{}, chain[len(chain)-1], failures, errors.New("no provider to send the request to")
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// favoritesMode is the overlay mode that browses starred records
+const favoritesMode = "favorites"
+
+// SetFavorite stars or unstars a history record
+func (a *App) SetFavorite(id string, favorite bool) error {
+	if a.history == nil {
+		return errors.New("history is unavailable")
+	}
+	_, err := a.history.update(id, func(r *HistoryRecord) error {
+		r.Favorite = favorite
+		return nil
+	})
+	return err
+}
+
+// FavoriteSnippet stars part of a response as its own record, so a useful
+// paragraph can be kept without the rest of the answer
+func (a *App) FavoriteSnippet(sourceID, text string) (HistoryRecord, error) {
+	if a.history == nil {
+		return HistoryRecord{}, errors.New("history is unavailable")
+	}
+	if strings.TrimSpace(text) == "" {
+		return HistoryRecord{}, errors.New("snippet is empty")
+	}
+	src, ok := a.history.get(sourceID)
+	if !ok {
+		return HistoryRecord{}, fmt.Errorf("no history record %q", sourceID)
+	}
+	r := HistoryRecord{
+		ID:        newID(),
+		Kind:      "snippet",
+		Text:      text,
+		RequestID: src.RequestID,
+		Model:     src.Model,
+		SourceID:  src.ID,
+		Favorite:  true,
+		CreatedAt: time.Now(),
+	}
+	return r, a.history.put(r)
+}
+
+// ListFavorites returns starred responses and snippets, newest first
+func (a *App) ListFavorites() []HistoryRecord {
+	return a.queryHistory(func(r HistoryRecord) bool { return r.Favorite })
+}
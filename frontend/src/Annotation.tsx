@@ -0,0 +1,120 @@
+import { useEffect, useRef, useState } from 'react';
+import { Close, ExportAnnotated } from '../wailsjs/go/main/AnnotationWindow';
+import { onEvent } from './events';
+
+type Tool = 'pen' | 'arrow' | 'box' | 'text';
+type Point = { x: number; y: number };
+
+const COLOR = '#ef4444';
+
+function drawArrow(ctx: CanvasRenderingContext2D, from: Point, to: Point) {
+    const angle = Math.atan2(to.y - from.y, to.x - from.x);
+    const head = 16;
+    ctx.beginPath();
+    ctx.moveTo(from.x, from.y);
+    ctx.lineTo(to.x, to.y);
+    ctx.lineTo(to.x - head * Math.cos(angle - Math.PI / 6), to.y - head * Math.sin(angle - Math.PI / 6));
+    ctx.moveTo(to.x, to.y);
+    ctx.lineTo(to.x - head * Math.cos(angle + Math.PI / 6), to.y - head * Math.sin(angle + Math.PI / 6));
+    ctx.stroke();
+}
+
+function Annotation() {
+    const canvasRef = useRef<HTMLCanvasElement>(null);
+    const [tool, setTool] = useState<Tool>('pen');
+    const [clickThrough, setClickThrough] = useState(false);
+    const start = useRef<Point | null>(null);
+    const snapshot = useRef<ImageData | null>(null);
+
+    useEffect(() => {
+        const canvas = canvasRef.current!;
+        canvas.width = window.innerWidth;
+        canvas.height = window.innerHeight;
+        const ctx = canvas.getContext('2d')!;
+        ctx.strokeStyle = COLOR;
+        ctx.fillStyle = COLOR;
+        ctx.lineWidth = 4;
+        ctx.lineCap = 'round';
+        ctx.font = '24px sans-serif';
+
+        onEvent('click-through-changed', (enabled) => setClickThrough(enabled));
+
+        const handleKey = (e: KeyboardEvent) => {
+            if (e.key === 'Escape') {
+                Close();
+            }
+        };
+        window.addEventListener('keydown', handleKey);
+        return () => window.removeEventListener('keydown', handleKey);
+    }, []);
+
+    const point = (e: React.MouseEvent): Point => ({ x: e.clientX, y: e.clientY });
+
+    const onMouseDown = (e: React.MouseEvent) => {
+        const ctx = canvasRef.current!.getContext('2d')!;
+        const p = point(e);
+        if (tool === 'text') {
+            const text = window.prompt('Text');
+            if (text) {
+                ctx.fillText(text, p.x, p.y);
+            }
+            return;
+        }
+        start.current = p;
+        snapshot.current = ctx.getImageData(0, 0, ctx.canvas.width, ctx.canvas.height);
+        ctx.beginPath();
+        ctx.moveTo(p.x, p.y);
+    };
+
+    const onMouseMove = (e: React.MouseEvent) => {
+        if (!start.current) {
+            return;
+        }
+        const ctx = canvasRef.current!.getContext('2d')!;
+        const p = point(e);
+        if (tool === 'pen') {
+            ctx.lineTo(p.x, p.y);
+            ctx.stroke();
+            return;
+        }
+        ctx.putImageData(snapshot.current!, 0, 0);
+        if (tool === 'box') {
+            ctx.strokeRect(start.current.x, start.current.y, p.x - start.current.x, p.y - start.current.y);
+        } else {
+            drawArrow(ctx, start.current, p);
+        }
+    };
+
+    const onMouseUp = () => {
+        start.current = null;
+    };
+
+    const exportImage = () => {
+        ExportAnnotated(canvasRef.current!.toDataURL('image/png'));
+    };
+
+    return (
+        <div className="fixed inset-0">
+            <canvas
+                ref={canvasRef}
+                className="absolute inset-0"
+                onMouseDown={onMouseDown}
+                onMouseMove={onMouseMove}
+                onMouseUp={onMouseUp}
+            />
+            {!clickThrough && (
+                <div className="absolute top-4 left-1/2 -translate-x-1/2 flex gap-2 rounded-lg bg-gray-900/90 p-2 text-sm text-white">
+                    {(['pen', 'arrow', 'box', 'text'] as Tool[]).map((t) => (
+                        <button key={t} className={t === tool ? 'font-bold underline' : ''} onClick={() => setTool(t)}>
+                            {t}
+                        </button>
+                    ))}
+                    <button onClick={exportImage}>export</button>
+                    <button onClick={() => Close()}>close</button>
+                </div>
+            )}
+        </div>
+    );
+}
+
+export default Annotation;
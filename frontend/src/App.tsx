@@ -1,13 +1,136 @@
-import { useState, useEffect } from 'react';
-import { ShowOverlay, HideOverlay } from '../wailsjs/go/main/App';
-import { EventsOn } from '../wailsjs/runtime/runtime';
+import { useState, useEffect, useRef } from 'react';
+import { ShowOverlay, HideOverlay, SendPrompt, CancelRequest, GetPreviousInput, GetNextInput, ListFavorites, SetFavorite, FavoriteSnippet, ExportResponse, CopyResponseImage, PrintRecords, GetSpellCheck, GetLastSession, ResumeSession, SetScrollAnchor, SaveNote, IsDefineQuery, Define, OnWindowBlur, OnWindowFocus, GetWakeWordStatus, StopWakeWord, OpenURL, GetRecentItems, OpenRecentItem, SearchQuicklinks, OpenQuicklink, SearchSystemCommands, RunSystemCommand, Query, EscalateQuery, CancelQuery, GetKeymap, NewSession, GetWindowState, SetAlwaysOnTop, GetSelfCheck, RunSelfCheck, OpenSystemSettings, GetOnboarding, CompleteOnboardingStep, SkipOnboarding, MarkReleaseNotesSeen, GlobalSearch } from '../wailsjs/go/main/App';
+import { ClipboardGetText } from '../wailsjs/runtime/runtime';
+import { onEvent, ClipboardSuggestion, LocalResult, GlobalSearchResults } from './events';
+import { actionFor } from './keymap';
+import { watchTheme } from './theme';
+import { watchAppearance } from './appearance';
+import { main } from '../wailsjs/go/models';
+
+// formatDefinition renders a dictionary entry as text for the response pane
+function formatDefinition(e: main.DictionaryEntry): string {
+    const lines = [`${e.word}${e.phonetic ? ` ${e.phonetic}` : ''}`];
+    for (const m of e.meanings ?? []) {
+        lines.push('', m.partOfSpeech);
+        (m.definitions ?? []).forEach((d, i) => {
+            lines.push(`${i + 1}. ${d.text}`);
+            if (d.example) lines.push(`   "${d.example}"`);
+        });
+    }
+    if (e.synonyms?.length) lines.push('', `Synonyms: ${e.synonyms.join(', ')}`);
+    if (e.antonyms?.length) lines.push('', `Antonyms: ${e.antonyms.join(', ')}`);
+    return lines.join('\n');
+}
+
+// switch-mode cycles through these
+const modes = ['chat', 'note', 'favorites', 'clipboard', 'search'];
 
 function App() {
     const [query, setQuery] = useState('');
+    const [mode, setMode] = useState('chat');
+    const [response, setResponse] = useState('');
+    const [requestId, setRequestId] = useState<string | null>(null);
+    const [queuePosition, setQueuePosition] = useState(0);
+    const [historyId, setHistoryId] = useState<string | null>(null);
+    const [favorites, setFavorites] = useState<main.HistoryRecord[]>([]);
+    const [spellCheck, setSpellCheck] = useState<main.SpellCheckSettings>({ enabled: true, language: '' });
+    const [transcript, setTranscript] = useState<main.HistoryRecord[]>([]);
+    const [lastSession, setLastSession] = useState<main.Session | null>(null);
+    const [sentQuery, setSentQuery] = useState('');
+    const [wakeWord, setWakeWord] = useState<main.WakeWordStatus | null>(null);
+    const [suggestion, setSuggestion] = useState<ClipboardSuggestion | null>(null);
+    const [recent, setRecent] = useState<main.RecentItem[]>([]);
+    const [quicklinks, setQuicklinks] = useState<main.Quicklink[]>([]);
+    const [commands, setCommands] = useState<main.SystemCommand[]>([]);
+    const [localResults, setLocalResults] = useState<LocalResult[]>([]);
+    const [pendingQuery, setPendingQuery] = useState<string | null>(null);
+    const [keymap, setKeymap] = useState<main.KeyBinding[]>([]);
+    const [selfCheck, setSelfCheck] = useState<main.SelfCheck | null>(null);
+    const [onboarding, setOnboarding] = useState<main.Onboarding | null>(null);
+    const [answeredBy, setAnsweredBy] = useState<string | null>(null);
+    const [searchGroups, setSearchGroups] = useState<GlobalSearchResults[]>([]);
+    const currentSearch = useRef<string | null>(null);
+    const currentRequest = useRef<string | null>(null);
+    const currentQuery = useRef<string | null>(null);
+    const anchorTimer = useRef<number>();
     
+    useEffect(() => watchTheme(), []);
+    useEffect(() => watchAppearance(), []);
+    useEffect(() => {
+        GetSpellCheck().then(setSpellCheck);
+        return onEvent('settings-changed', (settings) => setSpellCheck(settings.spellCheck));
+    }, []);
+
+    useEffect(() => onEvent('clipboard-suggestion', setSuggestion), []);
+
+    // Missing OS permissions are explained up front rather than leaving
+    // features to fail silently
+    useEffect(() => {
+        GetSelfCheck().then(setSelfCheck);
+        return onEvent('self-check', setSelfCheck);
+    }, []);
+
+    useEffect(() => {
+        GetOnboarding().then(setOnboarding);
+        const offUpdated = onEvent('onboarding-updated', setOnboarding);
+        const offWhatsNew = onEvent('whats-new', (whatsNew) => setOnboarding((o) => (o ? { ...o, whatsNew } as main.Onboarding : o)));
+        return () => { offUpdated(); offWhatsNew(); };
+    }, []);
+
+    const nextStep = onboarding?.tourPending ? onboarding.steps.find((s) => !s.done) : undefined;
+
+    useEffect(() => {
+        GetKeymap().then(setKeymap);
+        return onEvent('keymap-updated', setKeymap);
+    }, []);
+
+    // Links never navigate the webview; the backend vets and opens them
+    useEffect(() => {
+        const handleClick = (e: MouseEvent) => {
+            const link = (e.target as HTMLElement).closest('a[href]') as HTMLAnchorElement | null;
+            if (!link) return;
+            e.preventDefault();
+            OpenURL(link.href);
+        };
+        document.addEventListener('click', handleClick);
+        return () => document.removeEventListener('click', handleClick);
+    }, []);
+
+    // The mic indicator stays visible whenever the wake word detector runs
+    useEffect(() => {
+        GetWakeWordStatus().then(setWakeWord);
+        return onEvent('wake-word-status', setWakeWord);
+    }, []);
+
+    useEffect(() => {
+        GetLastSession().then((session) => session.id && setLastSession(session));
+    }, []);
+
     useEffect(() => {
         // Listen for show-overlay event
-        EventsOn('show-overlay', () => {
+        onEvent('show-overlay', ({ context, requestId, prompt }) => {
+            setMode(context.mode || 'chat');
+            GetRecentItems().then(setRecent);
+            if (context.mode === 'favorites') {
+                ListFavorites().then(setFavorites);
+            }
+            // The backend already sent a prompt; follow its answer
+            if (requestId) {
+                currentRequest.current = requestId;
+                setRequestId(requestId);
+                setSentQuery(prompt || '');
+                setQuery(prompt || '');
+                setResponse('');
+                setHistoryId(null);
+            } else if (prompt) {
+                setQuery(prompt);
+            } else if (context.mode === 'clipboard') {
+                ClipboardGetText().then(setQuery);
+            } else if (context.mode === 'note') {
+                setQuery('');
+            } else if (context.selection) {
+                setQuery(context.selection);
+            }
             ShowOverlay();
             // Focus input when overlay appears
             setTimeout(() => {
@@ -15,27 +138,453 @@ function App() {
             }, 100);
         });
 
-        // Handle Escape key to close
-        const handleEscape = (e: KeyboardEvent) => {
-            if (e.key === 'Escape') {
-                HideOverlay();
+        // Local answers come first; the model's request follows if the
+        // query escalates
+        onEvent('query-local-results', ({ results }, envelope) => {
+            if (envelope.requestId !== currentQuery.current) return;
+            setLocalResults(results);
+            const calc = results.find((r) => r.kind === 'calculator');
+            if (calc) setResponse(`= ${calc.title}`);
+        });
+        onEvent('query-escalated', ({ requestId }, envelope) => {
+            if (envelope.requestId !== currentQuery.current) return;
+            currentQuery.current = null;
+            setPendingQuery(null);
+            setLocalResults([]);
+            if (envelope.error) {
+                setResponse(envelope.error);
+                return;
             }
-        };
-        
-        window.addEventListener('keydown', handleEscape);
-        return () => window.removeEventListener('keydown', handleEscape);
+            setResponse('');
+            setAnsweredBy(null);
+            currentRequest.current = requestId;
+            setRequestId(requestId);
+        });
+
+        onEvent('request-queued', ({ position }, envelope) => {
+            if (envelope.requestId === currentRequest.current) setQueuePosition(position);
+        });
+        onEvent('response-chunk', ({ delta }, envelope) => {
+            if (envelope.requestId !== currentRequest.current) return;
+            setQueuePosition(0);
+            setResponse((r) => r + delta);
+        });
+        onEvent('request-failover', ({ to }, envelope) => {
+            if (envelope.requestId === currentRequest.current) setAnsweredBy(`Retrying on ${to}…`);
+        });
+        onEvent('response-done', (result, envelope) => {
+            if (envelope.requestId !== currentRequest.current) return;
+            setHistoryId(result.historyId || null);
+            // Only worth a mention when the primary model did not answer
+            setAnsweredBy(result.failures?.length ? `Answered by ${result.model}` : null);
+            currentRequest.current = null;
+            setRequestId(null);
+            setQueuePosition(0);
+            // The final text has been through the post-processing pipeline
+            if (result.text) setResponse(result.text);
+            if (envelope.error) setResponse((r) => r + `\n\n${envelope.error}`);
+        });
     }, []);
+
+    // Search mode looks through everything stored at once; each source's
+    // group shows up as soon as it is searched
+    useEffect(() => onEvent('global-search-results', (group, envelope) => {
+        if (envelope.requestId !== currentSearch.current) return;
+        setSearchGroups((groups) => [...groups, group]);
+    }), []);
+
+    useEffect(() => {
+        currentSearch.current = null;
+        setSearchGroups([]);
+        if (mode !== 'search' || !query.trim()) return;
+        const timer = window.setTimeout(() => {
+            GlobalSearch(query).then((id) => { currentSearch.current = id; });
+        }, 150);
+        return () => window.clearTimeout(timer);
+    }, [query, mode]);
+
+    // Groups with the best match come first; a source that was not
+    // searched shows why at the end
+    const rankedGroups = searchGroups
+        .filter((g) => g.hits.length > 0 || g.notice)
+        .sort((a, b) => (b.hits[0]?.score ?? -1) - (a.hits[0]?.score ?? -1));
+
+    // Quicklinks and system commands matching the input are offered above
+    // the model's answer
+    useEffect(() => {
+        if (mode !== 'chat' || !query.trim()) {
+            setQuicklinks([]);
+            setCommands([]);
+            return;
+        }
+        SearchQuicklinks(query, 5).then(setQuicklinks).catch(() => setQuicklinks([]));
+        // The number in "volume 30" is the level; the letters find the command
+        const words = query.replace(/\d+/g, '').trim();
+        if (words) SearchSystemCommands(words, 3).then(setCommands);
+        else setCommands([]);
+    }, [query, mode]);
+
+    const runCommand = async (c: main.SystemCommand) => {
+        const value = Number(query.match(/\d+/)?.[0] ?? -1);
+        try {
+            if (await RunSystemCommand(c.id, value)) setQuery('');
+        } catch (err) {
+            setResponse(String(err));
+        }
+    };
+
+    // The backend decides from settings whether losing focus hides us
+    useEffect(() => {
+        const handleBlur = () => OnWindowBlur(!query.trim() && !response && !requestId);
+        const handleFocus = () => OnWindowFocus();
+        window.addEventListener('blur', handleBlur);
+        window.addEventListener('focus', handleFocus);
+        return () => {
+            window.removeEventListener('blur', handleBlur);
+            window.removeEventListener('focus', handleFocus);
+        };
+    }, [query, response, requestId]);
+
+    const newSession = async () => {
+        await NewSession();
+        setTranscript([]);
+        setResponse('');
+        setHistoryId(null);
+        setSentQuery('');
+        setQuery('');
+    };
+
+    const switchMode = () => {
+        const next = modes[(modes.indexOf(mode) + 1) % modes.length];
+        setMode(next);
+        if (next === 'favorites') ListFavorites().then(setFavorites);
+    };
+
+    // Overlay shortcuts come from the backend keymap. Printing goes through
+    // the backend too; the webview's own print only captures the frameless
+    // window.
+    useEffect(() => {
+        const handleShortcut = (e: KeyboardEvent) => {
+            switch (actionFor(keymap, e)) {
+                case 'close':
+                    HideOverlay();
+                    break;
+                case 'print':
+                    if (historyId) PrintRecords([historyId]);
+                    break;
+                case 'new-session':
+                    newSession();
+                    break;
+                case 'toggle-pin':
+                    GetWindowState().then((s) => SetAlwaysOnTop(!s.alwaysOnTop));
+                    break;
+                case 'switch-mode':
+                    switchMode();
+                    break;
+                case 'cancel-request':
+                    if (requestId) CancelRequest(requestId);
+                    break;
+                default:
+                    return;
+            }
+            e.preventDefault();
+        };
+        window.addEventListener('keydown', handleShortcut);
+        return () => window.removeEventListener('keydown', handleShortcut);
+    }, [keymap, historyId, requestId, mode]);
     
+    // A new query may be sent while another streams; the backend queues it
+    const submit = async () => {
+        if (!query.trim()) return;
+        // Enter again on the same input asks the model without waiting
+        if (pendingQuery && query === sentQuery) {
+            EscalateQuery(pendingQuery);
+            return;
+        }
+        // Enter in search mode opens the best match
+        if (mode === 'search') {
+            const top = rankedGroups[0]?.hits[0];
+            if (top) setResponse(top.text);
+            return;
+        }
+        // Note mode captures the text without asking the model
+        if (mode === 'note') {
+            await SaveNote('', query);
+            setQuery('');
+            HideOverlay();
+            return;
+        }
+        // The finished turn moves into the transcript above the new one
+        if (response) {
+            setTranscript((t) => [
+                ...t,
+                main.HistoryRecord.createFrom({ id: '', kind: 'input', text: sentQuery }),
+                main.HistoryRecord.createFrom({ id: historyId ?? '', kind: 'response', text: response }),
+            ]);
+        }
+        setSentQuery(query);
+        setResponse('');
+        setHistoryId(null);
+        // "define <word>" is answered from the dictionary, not the model
+        if (await IsDefineQuery(query)) {
+            currentRequest.current = null;
+            setRequestId(null);
+            try {
+                setResponse(formatDefinition(await Define(query)));
+            } catch (err) {
+                setResponse(String(err));
+            }
+            return;
+        }
+        // Chat input is looked up locally before it reaches the model
+        if (mode === 'chat') {
+            setLocalResults([]);
+            const id = await Query(query);
+            currentQuery.current = id;
+            setPendingQuery(id);
+            return;
+        }
+        const id = await SendPrompt(query);
+        currentRequest.current = id;
+        setRequestId(id);
+    };
+
+    // Editing the input stops the last query from escalating
+    const changeQuery = (value: string) => {
+        if (currentQuery.current) {
+            CancelQuery(currentQuery.current);
+            currentQuery.current = null;
+            setPendingQuery(null);
+            setLocalResults([]);
+        }
+        setQuery(value);
+    };
+
+    const openLocalResult = (r: LocalResult) => {
+        switch (r.kind) {
+            case 'app':
+            case 'file':
+                OpenRecentItem(main.RecentItem.createFrom({ kind: r.kind, name: r.title, id: r.kind === 'app' ? r.id : '', path: r.subtitle }));
+                break;
+            default:
+                changeQuery(r.title);
+        }
+    };
+
+    const resume = async () => {
+        if (!lastSession) return;
+        const session = await ResumeSession(lastSession.id);
+        setLastSession(null);
+        setTranscript(session.records);
+        requestAnimationFrame(() => {
+            document.getElementById(`record-${session.scrollAnchor}`)?.scrollIntoView();
+        });
+    };
+
+    // Reports the first record in view, at most twice a second
+    const handleTranscriptScroll = (e: React.UIEvent<HTMLDivElement>) => {
+        const container = e.currentTarget;
+        window.clearTimeout(anchorTimer.current);
+        anchorTimer.current = window.setTimeout(() => {
+            const items = Array.from(container.children) as HTMLElement[];
+            const top = items.find((el) => el.offsetTop + el.offsetHeight > container.scrollTop && el.dataset.id);
+            if (top?.dataset.id) SetScrollAnchor(top.dataset.id);
+        }, 500);
+    };
+
+    // Stars the selected part of the response, or all of it
+    const star = async () => {
+        if (!historyId) return;
+        const selected = window.getSelection()?.toString().trim();
+        if (selected) {
+            await FavoriteSnippet(historyId, selected);
+        } else {
+            await SetFavorite(historyId, true);
+        }
+    };
+
+    const unstar = async (id: string) => {
+        await SetFavorite(id, false);
+        setFavorites(await ListFavorites());
+    };
+
+    // Up/Down, by default, walk through past inputs like a shell history
+    const handleKeyDown = async (e: React.KeyboardEvent<HTMLInputElement>) => {
+        switch (actionFor(keymap, e)) {
+            case 'submit':
+                submit();
+                break;
+            case 'previous-input':
+                e.preventDefault();
+                setQuery(await GetPreviousInput(query));
+                break;
+            case 'next-input':
+                e.preventDefault();
+                setQuery(await GetNextInput(query));
+                break;
+        }
+    };
+
+    // The calculator answers in the response pane, and commands and
+    // quicklinks have their own lists
+    const otherResults = localResults.filter((r) => !['calculator', 'command', 'quicklink'].includes(r.kind));
+
     return (
-        <div className="overlay-container">
+        <div className="overlay-container" data-mode={mode}>
             <input
                 id="search"
                 type="text"
                 value={query}
-                onChange={(e) => setQuery(e.target.value)}
-                placeholder="Search..."
+                onChange={(e) => changeQuery(e.target.value)}
+                onKeyDown={handleKeyDown}
+                spellCheck={spellCheck.enabled}
+                lang={spellCheck.language.replace('_', '-') || undefined}
+                placeholder={mode === 'note' ? 'Write a note...' : 'Search...'}
             />
-            {/* Your results/actions here */}
+            {wakeWord?.listening && (
+                <div className="wake-word">
+                    <span>Mic on{wakeWord.phrase ? ` — say "${wakeWord.phrase}"` : ''}</span>
+                    <button onClick={() => StopWakeWord()}>Turn off</button>
+                </div>
+            )}
+            {suggestion && (
+                <div className="clipboard-suggestion">
+                    <span>{suggestion.title}</span>
+                    <button onClick={() => { setQuery(suggestion.prompt); setSuggestion(null); }}>Ask</button>
+                    <button onClick={() => setSuggestion(null)}>Dismiss</button>
+                </div>
+            )}
+            {wakeWord?.error && <div className="wake-word error">{wakeWord.error}</div>}
+            {selfCheck && selfCheck.missing > 0 && (
+                <div className="self-check">
+                    {selfCheck.checks
+                        .filter((c) => c.status === 'missing')
+                        .map((c) => (
+                            <div key={c.id}>
+                                <span title={c.repair}>{c.title} is off: {c.affects} won't work</span>
+                                {c.settingsUrl && <button onClick={() => OpenSystemSettings(c.id)}>Open Settings</button>}
+                            </div>
+                        ))}
+                    <button onClick={() => RunSelfCheck()}>Check Again</button>
+                    <button onClick={() => setSelfCheck(null)}>Dismiss</button>
+                </div>
+            )}
+            {nextStep && (
+                <div className="onboarding">
+                    <span>{nextStep.title}</span>
+                    <button onClick={() => CompleteOnboardingStep(nextStep.id)}>Done</button>
+                    <button onClick={() => SkipOnboarding()}>Skip Tour</button>
+                </div>
+            )}
+            {onboarding && onboarding.whatsNew.length > 0 && (
+                <div className="whats-new">
+                    {onboarding.whatsNew.map((r) => (
+                        <div key={r.version}>
+                            <strong>What's new in {r.version}</strong>
+                            <ul>{r.highlights.map((h) => <li key={h}>{h}</li>)}</ul>
+                        </div>
+                    ))}
+                    <button onClick={() => MarkReleaseNotesSeen()}>Got It</button>
+                </div>
+            )}
+            {lastSession && (
+                <div className="resume-session">
+                    <span>Resume last session ({lastSession.records.length} messages)?</span>
+                    <button onClick={resume}>Resume</button>
+                    <button onClick={() => setLastSession(null)}>Dismiss</button>
+                </div>
+            )}
+            {transcript.length > 0 && (
+                <div className="transcript" onScroll={handleTranscriptScroll}>
+                    {transcript.map((r, i) => (
+                        <div
+                            key={r.id || i}
+                            id={r.id ? `record-${r.id}` : undefined}
+                            data-id={r.id || undefined}
+                            data-kind={r.kind}
+                            className="whitespace-pre-wrap"
+                        >
+                            {r.text}
+                        </div>
+                    ))}
+                </div>
+            )}
+            {requestId && <button onClick={() => CancelRequest(requestId)}>Stop</button>}
+            {queuePosition > 0 && <div className="text-xs opacity-70">Queued (#{queuePosition})</div>}
+            {historyId && (
+                <div className="response-actions">
+                    <button onClick={star}>Star</button>
+                    <button onClick={() => CopyResponseImage(historyId)}>Copy Image</button>
+                    <button onClick={() => PrintRecords([historyId])}>Print</button>
+                    {['png', 'pdf', 'md'].map((format) => (
+                        <button key={format} onClick={() => ExportResponse(historyId, format)}>
+                            Save {format.toUpperCase()}
+                        </button>
+                    ))}
+                </div>
+            )}
+            {response && <div className="whitespace-pre-wrap">{response}</div>}
+            {answeredBy && <div className="answered-by">{answeredBy}</div>}
+            {!response && quicklinks.length > 0 && (
+                <ul className="quicklinks">
+                    {quicklinks.map((l) => (
+                        <li key={l.id} data-kind={l.kind} onClick={() => OpenQuicklink(l.id)}>
+                            {l.name}
+                        </li>
+                    ))}
+                </ul>
+            )}
+            {!response && commands.length > 0 && (
+                <ul className="system-commands">
+                    {commands.map((c) => (
+                        <li key={c.id} data-destructive={c.destructive || undefined} onClick={() => runCommand(c)}>
+                            {c.title}
+                        </li>
+                    ))}
+                </ul>
+            )}
+            {otherResults.length > 0 && (
+                <ul className="local-results">
+                    {otherResults.map((r) => (
+                        <li key={r.kind + r.id} data-kind={r.kind} title={r.subtitle} onClick={() => openLocalResult(r)}>
+                            {r.title}
+                        </li>
+                    ))}
+                </ul>
+            )}
+            {pendingQuery && <button onClick={() => EscalateQuery(pendingQuery)}>Ask the model</button>}
+            {mode === 'chat' && !query && !response && !requestId && transcript.length === 0 && recent.length > 0 && (
+                <ul className="recent-items">
+                    {recent.map((item) => (
+                        <li key={item.kind + (item.path || item.id)} data-kind={item.kind} onClick={() => OpenRecentItem(item)}>
+                            {item.name}
+                        </li>
+                    ))}
+                </ul>
+            )}
+            {mode === 'search' && rankedGroups.map((g) => (
+                <div key={g.source} className="search-group" data-source={g.source}>
+                    <div className="search-group-title">{g.source}</div>
+                    {g.notice && <div className="search-group-notice">{g.notice}</div>}
+                    <ul>
+                        {g.hits.map((h) => (
+                            <li key={h.id} title={h.text} onClick={() => setResponse(h.text)}>{h.title}</li>
+                        ))}
+                    </ul>
+                </div>
+            ))}
+            {mode === 'favorites' && (
+                <ul className="favorites">
+                    {favorites
+                        .filter((f) => f.text.toLowerCase().includes(query.toLowerCase()))
+                        .map((f) => (
+                            <li key={f.id}>
+                                <div className="whitespace-pre-wrap" onClick={() => setResponse(f.text)}>{f.text}</div>
+                                <button onClick={() => unstar(f.id)}>Unstar</button>
+                            </li>
+                        ))}
+                </ul>
+            )}
         </div>
     );
 }
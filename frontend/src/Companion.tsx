@@ -0,0 +1,26 @@
+import { useEffect, useState } from 'react';
+import { main } from '../wailsjs/go/models';
+import { Close, GetStatus } from '../wailsjs/go/main/CompanionWindow';
+import { onEvent } from './events';
+
+function Companion() {
+    const [status, setStatus] = useState<main.CompanionStatus | null>(null);
+
+    useEffect(() => {
+        GetStatus().then(setStatus);
+        onEvent('companion-status', (s) => setStatus(s));
+    }, []);
+
+    return (
+        <div className="flex h-screen items-center gap-3 px-4 text-white" style={{ '--wails-draggable': 'drag' } as React.CSSProperties}>
+            {status?.busy && <span className="h-2 w-2 animate-pulse rounded-full bg-emerald-400" />}
+            <div className="min-w-0 flex-1">
+                <div className="truncate text-sm font-semibold">{status?.title || 'Overlae'}</div>
+                <div className="truncate text-xs opacity-70">{status?.text || 'Idle'}</div>
+            </div>
+            <button className="text-xs opacity-60" onClick={() => Close()}>✕</button>
+        </div>
+    );
+}
+
+export default Companion;
@@ -0,0 +1,22 @@
+import { useEffect, useState } from 'react';
+import { main } from '../wailsjs/go/models';
+import { GetContent } from '../wailsjs/go/main/DetachedWindow';
+import { onEvent } from './events';
+
+function Detached() {
+    const [content, setContent] = useState<main.DetachedContent | null>(null);
+
+    useEffect(() => {
+        GetContent().then((c) => c.id && setContent(c));
+        onEvent('detached-content', (c) => setContent(c));
+    }, []);
+
+    return (
+        <div className="h-screen overflow-auto p-4 text-sm text-white">
+            <h1 className="mb-3 font-semibold">{content?.title}</h1>
+            <div className="whitespace-pre-wrap">{content?.content}</div>
+        </div>
+    );
+}
+
+export default Detached;
@@ -0,0 +1,36 @@
+import { useEffect, useState } from 'react';
+import { Choose, Dismiss, GetActions } from '../wailsjs/go/main/SelectionHintWindow';
+import { onEvent } from './events';
+
+const labels: Record<string, string> = {
+    translate: 'Translate',
+    explain: 'Explain',
+    'copy-clean': 'Copy Clean',
+};
+
+function SelectionHint() {
+    const [actions, setActions] = useState<string[]>([]);
+
+    useEffect(() => {
+        GetActions().then((a) => setActions(a ?? []));
+        return onEvent('selection-hint-shown', setActions);
+    }, []);
+
+    useEffect(() => {
+        const handleEscape = (e: KeyboardEvent) => e.key === 'Escape' && Dismiss();
+        window.addEventListener('keydown', handleEscape);
+        return () => window.removeEventListener('keydown', handleEscape);
+    }, []);
+
+    return (
+        <div className="flex h-screen items-center gap-1 px-1 text-xs text-white">
+            {actions.map((action) => (
+                <button key={action} className="flex-1 rounded px-2 py-1 hover:bg-white/10" onClick={() => Choose(action)}>
+                    {labels[action] ?? action}
+                </button>
+            ))}
+        </div>
+    );
+}
+
+export default SelectionHint;
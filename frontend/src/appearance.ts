@@ -0,0 +1,16 @@
+import { main } from '../wailsjs/go/models';
+import { GetAppearance } from '../wailsjs/go/main/App';
+import { onEvent } from './events';
+
+function applyAppearance({ zoom, fontSize }: main.AppearanceSettings) {
+    const root = document.documentElement.style;
+    root.setProperty('zoom', String(zoom || 1));
+    root.fontSize = `${fontSize || 14}px`;
+}
+
+// watchAppearance applies the saved zoom and font size and follows changes
+// made from the zoom shortcuts or settings
+export function watchAppearance(): () => void {
+    GetAppearance().then(applyAppearance);
+    return onEvent('zoom-changed', applyAppearance);
+}
@@ -0,0 +1,250 @@
+import { main } from '../wailsjs/go/models';
+import { EventsOn } from '../wailsjs/runtime/runtime';
+
+// Mirrors events.go; bump together with eventVersion there
+export const EVENT_VERSION = 1;
+
+export interface EventEnvelope<T> {
+    name: string;
+    version: number;
+    time: string;
+    requestId?: string;
+    error?: string;
+    data: T;
+}
+
+export interface PageContext {
+    url: string;
+    title: string;
+    selection?: string;
+    browser?: string;
+    receivedAt: string;
+}
+
+export interface ShowContext {
+    mode: string;
+    app: main.ActiveApp;
+    selection?: string;
+    page?: PageContext;
+}
+
+export interface ShowOverlayEvent {
+    reason: 'hotkey' | 'mouse' | 'voice' | 'clipboard';
+    variant?: string;
+    requestId?: string;
+    prompt?: string;
+    dictate?: boolean;
+    monitor?: string;
+    context: ShowContext;
+}
+
+export interface DisplayChange {
+    displays: main.Display[];
+    current: main.Display;
+}
+
+export interface HotkeyCapture {
+    name: string;
+    spec: string;
+    rawModifiers: number;
+    rawKey: number;
+    registered: boolean;
+}
+
+export interface RequestQueued {
+    position: number;
+    running: number;
+}
+
+export interface RequestStarted {
+    model: string;
+}
+
+export interface ResponseChunk {
+    seq: number;
+    delta: string;
+}
+
+export interface ResponseResult {
+    text: string;
+    model: string;
+    finishReason?: string;
+    cancelled: boolean;
+    cached: boolean;
+    failures?: ProviderFailure[];
+    durationMs: number;
+    historyId?: string;
+}
+
+export interface ProviderFailure {
+    model: string;
+    error: string;
+}
+
+export interface SearchHit {
+    source: string;
+    id: string;
+    kind?: string;
+    title: string;
+    text: string;
+    score: number;
+    positions?: number[];
+    createdAt: string;
+}
+
+export interface GlobalSearchResults {
+    query: string;
+    source: string;
+    hits: SearchHit[];
+    notice?: string;
+    remaining: number;
+}
+
+export interface RequestFailover {
+    from: string;
+    to: string;
+    error: string;
+}
+
+export interface Recording {
+    path: string;
+    format: string;
+    seconds: number;
+    size: number;
+}
+
+export interface AudioDeviceChange {
+    devices: main.AudioDevice[];
+    input: main.AudioDevice;
+    output: main.AudioDevice;
+}
+
+export interface NetToolLine {
+    tool: string;
+    text: string;
+}
+
+export interface NetToolResult {
+    tool: string;
+    lines: number;
+    durationMs: number;
+}
+
+export interface LocalResult {
+    kind: 'calculator' | 'command' | 'quicklink' | 'app' | 'file' | 'snippet' | 'history';
+    id?: string;
+    title: string;
+    subtitle?: string;
+}
+
+export interface QueryLocalResults {
+    input: string;
+    results: LocalResult[];
+    escalateInMs: number;
+}
+
+export interface QueryEscalation {
+    requestId: string;
+    reason: 'confirmed' | 'timeout';
+}
+
+export interface ClipboardSuggestion {
+    rule: string;
+    title: string;
+    prompt: string;
+    match: string;
+}
+
+// Payload type of every event the backend emits
+export interface EventMap {
+    'show-overlay': ShowOverlayEvent;
+    'request-queued': RequestQueued;
+    'request-started': RequestStarted;
+    'response-chunk': ResponseChunk;
+    'response-done': ResponseResult;
+    'settings-changed': main.Settings;
+    'clipboard-cleared': {};
+    'sync-completed': main.SyncResult;
+    'display-changed': DisplayChange;
+    'overlay-snapped': string;
+    'overlay-placements-reset': {};
+    'hotkeys-enabled-changed': boolean;
+    'keyboard-layout-changed': string;
+    'hotkey-captured': HotkeyCapture;
+    'hotkey-capture-cancelled': string;
+    'window-state-changed': main.WindowState;
+    'annotation-opened': {};
+    'annotation-closed': {};
+    'annotation-error': string;
+    'click-through-changed': boolean;
+    'companion-toggled': boolean;
+    'companion-status': main.CompanionStatus;
+    'detached-windows-changed': main.DetachedContent[];
+    'detached-content': main.DetachedContent;
+    'theme-changed': main.Theme;
+    'zoom-changed': main.AppearanceSettings;
+    'provider-key-validated': main.ModelCatalog;
+    'recording-done': Recording;
+    'audio-devices-changed': AudioDeviceChange;
+    'wake-word-status': main.WakeWordStatus;
+    'clipboard-suggestion': ClipboardSuggestion;
+    'download-progress': main.Download;
+    'translation': main.Translation;
+    'net-tool-output': NetToolLine;
+    'net-tool-done': NetToolResult;
+    'query-local-results': QueryLocalResults;
+    'query-escalated': QueryEscalation;
+    'selection-hint-shown': string[];
+    'keymap-updated': main.KeyBinding[];
+    'self-check': main.SelfCheck;
+    'note-saved': main.HistoryRecord;
+    'onboarding-updated': main.Onboarding;
+    'whats-new': main.ReleaseNote[];
+    'request-failover': RequestFailover;
+    'global-search-results': GlobalSearchResults;
+}
+
+export type EventName = keyof EventMap;
+
+// onEvent subscribes to a backend event and unwraps its envelope
+export function onEvent<K extends EventName>(
+    name: K,
+    callback: (data: EventMap[K], envelope: EventEnvelope<EventMap[K]>) => void,
+): () => void {
+    return EventsOn(name, (envelope: EventEnvelope<EventMap[K]>) => {
+        if (envelope.version !== EVENT_VERSION) {
+            console.warn(`event ${name} has version ${envelope.version}, expected ${EVENT_VERSION}`);
+        }
+        callback(envelope.data, envelope);
+    });
+}
+
+// awaitResponse resolves with the response event carrying requestId, as
+// returned by calls such as StartHotkeyCapture. Any of the rejectOn events
+// for the same request rejects instead.
+export function awaitResponse<K extends EventName>(
+    name: K,
+    requestId: string,
+    rejectOn: EventName[] = [],
+): Promise<EventMap[K]> {
+    return new Promise((resolve, reject) => {
+        const offs: (() => void)[] = [];
+        const done = () => offs.forEach((off) => off());
+        offs.push(
+            onEvent(name, (data, envelope) => {
+                if (envelope.requestId !== requestId) return;
+                done();
+                envelope.error ? reject(new Error(envelope.error)) : resolve(data);
+            }),
+        );
+        for (const other of rejectOn) {
+            offs.push(
+                onEvent(other, (_, envelope) => {
+                    if (envelope.requestId !== requestId) return;
+                    done();
+                    reject(new Error(`${other}`));
+                }),
+            );
+        }
+    });
+}
@@ -0,0 +1,13 @@
+import { main } from '../wailsjs/go/models';
+
+// actionFor returns the keymap action bound to the pressed keys, if any
+export function actionFor(keymap: main.KeyBinding[], e: KeyboardEvent | React.KeyboardEvent): string | undefined {
+    return keymap.find(
+        (b) =>
+            b.key.toLowerCase() === e.key.toLowerCase() &&
+            b.ctrl === e.ctrlKey &&
+            b.alt === e.altKey &&
+            b.shift === e.shiftKey &&
+            b.meta === e.metaKey,
+    )?.action;
+}
@@ -1,14 +1,30 @@
 import React from 'react'
 import {createRoot} from 'react-dom/client'
 import App from './App'
+import Annotation from './Annotation'
+import Companion from './Companion'
+import Detached from './Detached'
+import SelectionHint from './SelectionHint'
+import {Kind} from '../wailsjs/go/main/WindowInfo'
 import './main.css'
 
 const container = document.getElementById('root')
 
 const root = createRoot(container!)
 
-root.render(
-    <React.StrictMode>
-        <App/>
-    </React.StrictMode>
-)
+const views: Record<string, () => JSX.Element> = {
+    annotation: Annotation,
+    companion: Companion,
+    detached: Detached,
+    'selection-hint': SelectionHint,
+}
+
+// The same frontend is served to every window; pick the view for this one
+Kind().then((kind) => {
+    const View = views[kind] ?? App
+    root.render(
+        <React.StrictMode>
+            <View/>
+        </React.StrictMode>
+    )
+})
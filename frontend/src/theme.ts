@@ -0,0 +1,30 @@
+import { main } from '../wailsjs/go/models';
+import { GetTheme } from '../wailsjs/go/main/App';
+import { onEvent } from './events';
+
+let appliedVariables: string[] = [];
+
+// applyTheme swaps in the user's stylesheet and custom properties,
+// clearing whatever the previous theme set
+function applyTheme(theme: main.Theme) {
+    let style = document.getElementById('user-theme') as HTMLStyleElement | null;
+    if (!style) {
+        style = document.createElement('style');
+        style.id = 'user-theme';
+        document.head.appendChild(style);
+    }
+    style.textContent = theme.css;
+
+    const root = document.documentElement.style;
+    appliedVariables.forEach((name) => root.removeProperty(name));
+    appliedVariables = Object.keys(theme.variables ?? {});
+    appliedVariables.forEach((name) => root.setProperty(name, theme.variables[name]));
+
+    theme.errors?.forEach((err) => console.warn(`theme: ${err}`));
+}
+
+// watchTheme applies the current theme and every later change to it
+export function watchTheme(): () => void {
+    GetTheme().then(applyTheme);
+    return onEvent('theme-changed', applyTheme);
+}
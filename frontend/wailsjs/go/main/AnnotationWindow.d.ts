@@ -0,0 +1,8 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function Close():Promise<void>;
+
+export function ExportAnnotated(arg1:string):Promise<string>;
+
+export function SetClickThrough(arg1:boolean):Promise<void>;
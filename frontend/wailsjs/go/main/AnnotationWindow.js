@@ -0,0 +1,15 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function Close() {
+  return window['go']['main']['AnnotationWindow']['Close']();
+}
+
+export function ExportAnnotated(arg1) {
+  return window['go']['main']['AnnotationWindow']['ExportAnnotated'](arg1);
+}
+
+export function SetClickThrough(arg1) {
+  return window['go']['main']['AnnotationWindow']['SetClickThrough'](arg1);
+}
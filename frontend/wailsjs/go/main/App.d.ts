@@ -1,8 +1,385 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
+import {main} from '../models';
+
+export function AddProviderKey(arg1:string,arg2:string):Promise<main.ProviderKey>;
+
+export function AllowLinkHost(arg1:string):Promise<void>;
+
+export function ArrangeWindow(arg1:string):Promise<void>;
+
+export function AttachRecording(arg1:string,arg2:string):Promise<main.HistoryRecord>;
+
+export function CancelHotkeyCapture():Promise<void>;
+
+export function CancelNetTool(arg1:string):Promise<void>;
+
+export function CancelQuery(arg1:string):Promise<void>;
+
+export function CancelRequest(arg1:string):Promise<void>;
+
+export function CheckForUpdate():Promise<main.Release>;
+
+export function CheckPort(arg1:string,arg2:number):Promise<string>;
+
+export function ClearCache():Promise<main.CacheReport>;
+
+export function ClearResponseCache():Promise<number>;
+
+export function CloseAnnotation():Promise<void>;
+
+export function CloseDetachedWindow(arg1:string):Promise<void>;
+
+export function CompleteOnboardingStep(arg1:string):Promise<void>;
+
+export function CopyResponseImage(arg1:string):Promise<void>;
+
+export function CopySecret(arg1:string,arg2:number):Promise<void>;
+
+export function CreateTag(arg1:string,arg2:string):Promise<main.Tag>;
+
+export function DNSLookup(arg1:string,arg2:string):Promise<string>;
+
+export function Define(arg1:string):Promise<main.DictionaryEntry>;
+
+export function DeleteNote(arg1:string):Promise<void>;
+
+export function DeletePersona(arg1:string):Promise<void>;
+
+export function DeleteQuicklink(arg1:string):Promise<void>;
+
+export function DeleteTag(arg1:string):Promise<void>;
+
+export function DeleteTemplate(arg1:string):Promise<void>;
+
+export function DetachWindow(arg1:string,arg2:string,arg3:string):Promise<main.DetachedContent>;
+
+export function DownloadAttachment(arg1:string,arg2:number):Promise<string>;
+
+export function DownloadExport(arg1:string,arg2:string):Promise<string>;
+
+export function DownloadURL(arg1:string):Promise<string>;
+
+export function EscalateQuery(arg1:string):Promise<string>;
+
+export function ExportResponse(arg1:string,arg2:string):Promise<string>;
+
+export function FavoriteSnippet(arg1:string,arg2:string):Promise<main.HistoryRecord>;
+
+export function GeneratePassphrase(arg1:main.PassphraseOptions):Promise<main.GeneratedSecret>;
+
+export function GeneratePassword(arg1:main.PasswordOptions):Promise<main.GeneratedSecret>;
+
+export function GetActiveApp():Promise<main.ActiveApp>;
+
+export function GetAppearance():Promise<main.AppearanceSettings>;
+
+export function GetAutomationHooks():Promise<Array<main.AutomationHook>>;
+
+export function GetAutomationLog():Promise<Array<main.HookRun>>;
+
+export function GetAverageLatency(arg1:main.DateRange):Promise<main.LatencySummary>;
+
+export function GetDailyUsage(arg1:main.DateRange):Promise<Array<main.DailyUsage>>;
+
+export function GetDisplays():Promise<Array<main.Display>>;
+
+export function GetHotkeyCapabilities():Promise<main.HotkeyCapabilities>;
+
+export function GetKeymap():Promise<Array<main.KeyBinding>>;
+
+export function GetLastSession():Promise<main.Session>;
+
+export function GetLocalAPIToken():Promise<string>;
+
+export function GetLocalTranslator():Promise<Array<string>>;
+
+export function GetModels():Promise<main.ModelCatalog>;
+
+export function GetNextInput(arg1:string):Promise<string>;
+
+export function GetOnboarding():Promise<main.Onboarding>;
+
+export function GetPermissions():Promise<Array<main.PermissionStatus>>;
+
+export function GetPreviousInput(arg1:string):Promise<string>;
+
+export function GetRecentItems():Promise<Array<main.RecentItem>>;
+
+export function GetReleaseNotes():Promise<Array<main.ReleaseNote>>;
+
+export function GetSelfCheck():Promise<main.SelfCheck>;
+
+export function GetSessionPersona():Promise<main.Persona>;
+
+export function GetSettings():Promise<main.Settings>;
+
+export function GetSpellCheck():Promise<main.SpellCheckInfo>;
+
+export function GetStatus():Promise<main.AppStatus>;
+
+export function GetStorageUsage():Promise<Array<main.StorageUsage>>;
+
+export function GetTheme():Promise<main.Theme>;
+
+export function GetTopTemplates(arg1:main.DateRange,arg2:number):Promise<Array<main.TemplateUsage>>;
+
+export function GetWakeWordDetector():Promise<Array<string>>;
+
+export function GetWakeWordStatus():Promise<main.WakeWordStatus>;
+
+export function GetWindowState():Promise<main.WindowState>;
+
+export function GetWorkspaceMap():Promise<main.WorkspaceMap>;
+
+export function GlobalSearch(arg1:string):Promise<string>;
 
 export function HideOverlay():Promise<void>;
 
-export function OnWindowBlur():Promise<void>;
+export function HotkeysEnabled():Promise<boolean>;
+
+export function ImportBookmarks(arg1:string):Promise<number>;
+
+export function ImportDictionary(arg1:string):Promise<number>;
+
+export function IsDefineQuery(arg1:string):Promise<boolean>;
+
+export function ListAudioDevices():Promise<Array<main.AudioDevice>>;
+
+export function ListDetachedWindows():Promise<Array<main.DetachedContent>>;
+
+export function ListDownloads():Promise<Array<main.Download>>;
+
+export function ListFavorites():Promise<Array<main.HistoryRecord>>;
+
+export function ListFolder(arg1:string,arg2:main.DateRange):Promise<Array<main.HistoryRecord>>;
+
+export function ListFolders():Promise<Array<string>>;
+
+export function ListHistory(arg1:string,arg2:main.DateRange):Promise<Array<main.HistoryRecord>>;
+
+export function ListPersonas():Promise<Array<main.Persona>>;
+
+export function ListProcesses():Promise<Array<main.ProcessInfo>>;
+
+export function ListProviderKeys():Promise<Array<main.ProviderKeyInfo>>;
+
+export function ListQuicklinks():Promise<Array<main.Quicklink>>;
+
+export function ListSystemCommands():Promise<Array<main.SystemCommand>>;
+
+export function ListTags():Promise<Array<main.TagInfo>>;
+
+export function ListTemplates():Promise<Array<main.PromptTemplate>>;
+
+export function MarkReleaseNotesSeen():Promise<void>;
+
+export function Match(arg1:string,arg2:Array<main.MatchCandidate>,arg3:main.MatchOptions):Promise<Array<main.MatchResult>>;
+
+export function MinimizeToTray():Promise<void>;
+
+export function MoveCompanion(arg1:string):Promise<void>;
+
+export function MyIP():Promise<string>;
+
+export function NewSession():Promise<string>;
+
+export function OnWindowBlur(arg1:boolean):Promise<void>;
+
+export function OnWindowFocus():Promise<void>;
+
+export function OpenQuicklink(arg1:string):Promise<void>;
+
+export function OpenRecentItem(arg1:main.RecentItem):Promise<void>;
+
+export function OpenSystemSettings(arg1:string):Promise<void>;
+
+export function OpenURL(arg1:string):Promise<boolean>;
+
+export function PasteLastResponse():Promise<void>;
+
+export function PinVersion(arg1:string):Promise<void>;
+
+export function Ping(arg1:string,arg2:number):Promise<string>;
+
+export function PreviewPrompt(arg1:string):Promise<main.PromptPreview>;
+
+export function PrintRecords(arg1:Array<string>):Promise<void>;
+
+export function Query(arg1:string):Promise<string>;
+
+export function QuitApp(arg1:string,arg2:boolean):Promise<void>;
+
+export function ReadWorkspaceFile(arg1:string):Promise<string>;
+
+export function RemoveProviderKey(arg1:string):Promise<void>;
+
+export function RequestPermission(arg1:string):Promise<boolean>;
+
+export function ResetOnboarding():Promise<void>;
+
+export function ResetOverlayPlacements():Promise<void>;
+
+export function ResetZoom():Promise<main.AppearanceSettings>;
+
+export function ResumeSession(arg1:string):Promise<main.Session>;
+
+export function RevealDownload(arg1:string):Promise<void>;
+
+export function RevokePermission(arg1:string):Promise<void>;
+
+export function RunDiagnostics():Promise<string>;
+
+export function RunSelfCheck():Promise<main.SelfCheck>;
+
+export function RunSystemCommand(arg1:string,arg2:number):Promise<boolean>;
+
+export function SaveNote(arg1:string,arg2:string):Promise<main.HistoryRecord>;
+
+export function SavePersona(arg1:main.Persona):Promise<main.Persona>;
+
+export function SaveQuicklink(arg1:main.Quicklink):Promise<main.Quicklink>;
+
+export function SaveSettings(arg1:main.Settings):Promise<void>;
+
+export function SaveTemplate(arg1:main.PromptTemplate):Promise<main.PromptTemplate>;
+
+export function ScreenshotToPrompt():Promise<string>;
+
+export function SearchHistory(arg1:string,arg2:number):Promise<Array<main.HistoryRecord>>;
+
+export function SearchQuicklinks(arg1:string,arg2:number):Promise<Array<main.Quicklink>>;
+
+export function SearchSystemCommands(arg1:string,arg2:number):Promise<Array<main.SystemCommand>>;
+
+export function SelectSessionKey(arg1:string):Promise<void>;
+
+export function SemanticSearch(arg1:string,arg2:number):Promise<Array<main.SemanticMatch>>;
+
+export function SendPrompt(arg1:string):Promise<string>;
+
+export function SendTemplate(arg1:string):Promise<string>;
+
+export function SetAlwaysOnTop(arg1:boolean):Promise<void>;
+
+export function SetAudioDevices(arg1:string,arg2:string):Promise<void>;
+
+export function SetAutomationHooks(arg1:Array<main.AutomationHook>):Promise<void>;
+
+export function SetBlurGrace(arg1:number):Promise<void>;
+
+export function SetClipboardSuggestions(arg1:main.ClipboardSuggestionSettings):Promise<void>;
+
+export function SetCompanionStatus(arg1:main.CompanionStatus):Promise<void>;
+
+export function SetContextPrivacy(arg1:main.ContextPrivacy):Promise<void>;
+
+export function SetContextRules(arg1:Array<main.ContextRule>):Promise<void>;
+
+export function SetDefaultProviderKey(arg1:string):Promise<void>;
+
+export function SetDictionary(arg1:main.DictionarySettings):Promise<void>;
+
+export function SetDownloadsDir(arg1:string):Promise<void>;
+
+export function SetFailover(arg1:main.FailoverSettings):Promise<void>;
+
+export function SetFavorite(arg1:string,arg2:boolean):Promise<void>;
+
+export function SetFolder(arg1:string,arg2:string):Promise<void>;
+
+export function SetFontSize(arg1:number):Promise<main.AppearanceSettings>;
+
+export function SetHideOnBlur(arg1:string):Promise<void>;
+
+export function SetHotkey(arg1:string,arg2:string):Promise<void>;
+
+export function SetHotkeysEnabled(arg1:boolean):Promise<void>;
+
+export function SetKeymap(arg1:Record<string, string>):Promise<void>;
+
+export function SetLinkSettings(arg1:main.LinkSettings):Promise<void>;
+
+export function SetLocalAPI(arg1:main.LocalAPISettings):Promise<void>;
+
+export function SetLocalTranslator(arg1:Array<string>):Promise<void>;
+
+export function SetMouseTrigger(arg1:string):Promise<void>;
+
+export function SetPostProcessing(arg1:main.PostProcessSettings):Promise<void>;
+
+export function SetProviderCredentials(arg1:main.ProviderCredentials):Promise<void>;
+
+export function SetRetrieval(arg1:main.RetrievalSettings):Promise<void>;
+
+export function SetScreenshotPrompt(arg1:string):Promise<void>;
+
+export function SetScrollAnchor(arg1:string):Promise<void>;
+
+export function SetSelectionHint(arg1:main.SelectionHintSettings):Promise<void>;
+
+export function SetSessionPersona(arg1:string):Promise<void>;
+
+export function SetSpellCheck(arg1:main.SpellCheckSettings):Promise<void>;
+
+export function SetStorageLimits(arg1:main.StorageSettings):Promise<main.CacheReport>;
+
+export function SetStreamBatch(arg1:number):Promise<void>;
+
+export function SetSyncCredentials(arg1:main.SyncCredentials):Promise<void>;
+
+export function SetSystemControls(arg1:main.SystemControlSettings):Promise<void>;
+
+export function SetTranslation(arg1:main.TranslationSettings):Promise<void>;
+
+export function SetUpdateChannel(arg1:string):Promise<void>;
+
+export function SetWakeWord(arg1:main.WakeWordSettings):Promise<void>;
+
+export function SetWakeWordDetector(arg1:Array<string>):Promise<void>;
+
+export function SetWorkspace(arg1:string):Promise<main.WorkspaceMap>;
+
+export function SetZoom(arg1:number):Promise<main.AppearanceSettings>;
 
 export function ShowOverlay():Promise<void>;
+
+export function SkipOnboarding():Promise<void>;
+
+export function SkipRelease(arg1:string):Promise<void>;
+
+export function SnapOverlay(arg1:string):Promise<void>;
+
+export function StartHotkeyCapture(arg1:string,arg2:boolean):Promise<string>;
+
+export function StartRecording(arg1:main.RecordingOptions):Promise<string>;
+
+export function StopWakeWord():Promise<void>;
+
+export function SyncNow():Promise<main.SyncResult>;
+
+export function TagRecord(arg1:string,arg2:string):Promise<void>;
+
+export function TestAutomationHook(arg1:main.AutomationHook):Promise<main.HookRun>;
+
+export function ToggleAnnotation():Promise<void>;
+
+export function ToggleCompanion():Promise<boolean>;
+
+export function ToggleFullscreen():Promise<boolean>;
+
+export function Translate(arg1:string,arg2:string):Promise<main.Translation>;
+
+export function TranslateSelection():Promise<main.Translation>;
+
+export function UntagRecord(arg1:string,arg2:string):Promise<void>;
+
+export function UpdateTag(arg1:string,arg2:main.Tag):Promise<void>;
+
+export function ValidateHotkey(arg1:string):Promise<void>;
+
+export function ValidateProviderKey(arg1:string):Promise<string>;
+
+export function ZoomIn():Promise<main.AppearanceSettings>;
+
+export function ZoomOut():Promise<main.AppearanceSettings>;
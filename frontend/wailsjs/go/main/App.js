@@ -2,14 +2,766 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AddProviderKey(arg1, arg2) {
+  return window['go']['main']['App']['AddProviderKey'](arg1, arg2);
+}
+
+export function AllowLinkHost(arg1) {
+  return window['go']['main']['App']['AllowLinkHost'](arg1);
+}
+
+export function ArrangeWindow(arg1) {
+  return window['go']['main']['App']['ArrangeWindow'](arg1);
+}
+
+export function AttachRecording(arg1, arg2) {
+  return window['go']['main']['App']['AttachRecording'](arg1, arg2);
+}
+
+export function CancelHotkeyCapture() {
+  return window['go']['main']['App']['CancelHotkeyCapture']();
+}
+
+export function CancelNetTool(arg1) {
+  return window['go']['main']['App']['CancelNetTool'](arg1);
+}
+
+export function CancelQuery(arg1) {
+  return window['go']['main']['App']['CancelQuery'](arg1);
+}
+
+export function CancelRequest(arg1) {
+  return window['go']['main']['App']['CancelRequest'](arg1);
+}
+
+export function CheckForUpdate() {
+  return window['go']['main']['App']['CheckForUpdate']();
+}
+
+export function CheckPort(arg1, arg2) {
+  return window['go']['main']['App']['CheckPort'](arg1, arg2);
+}
+
+export function ClearCache() {
+  return window['go']['main']['App']['ClearCache']();
+}
+
+export function ClearResponseCache() {
+  return window['go']['main']['App']['ClearResponseCache']();
+}
+
+export function CloseAnnotation() {
+  return window['go']['main']['App']['CloseAnnotation']();
+}
+
+export function CloseDetachedWindow(arg1) {
+  return window['go']['main']['App']['CloseDetachedWindow'](arg1);
+}
+
+export function CompleteOnboardingStep(arg1) {
+  return window['go']['main']['App']['CompleteOnboardingStep'](arg1);
+}
+
+export function CopyResponseImage(arg1) {
+  return window['go']['main']['App']['CopyResponseImage'](arg1);
+}
+
+export function CopySecret(arg1, arg2) {
+  return window['go']['main']['App']['CopySecret'](arg1, arg2);
+}
+
+export function CreateTag(arg1, arg2) {
+  return window['go']['main']['App']['CreateTag'](arg1, arg2);
+}
+
+export function DNSLookup(arg1, arg2) {
+  return window['go']['main']['App']['DNSLookup'](arg1, arg2);
+}
+
+export function Define(arg1) {
+  return window['go']['main']['App']['Define'](arg1);
+}
+
+export function DeleteNote(arg1) {
+  return window['go']['main']['App']['DeleteNote'](arg1);
+}
+
+export function DeletePersona(arg1) {
+  return window['go']['main']['App']['DeletePersona'](arg1);
+}
+
+export function DeleteQuicklink(arg1) {
+  return window['go']['main']['App']['DeleteQuicklink'](arg1);
+}
+
+export function DeleteTag(arg1) {
+  return window['go']['main']['App']['DeleteTag'](arg1);
+}
+
+export function DeleteTemplate(arg1) {
+  return window['go']['main']['App']['DeleteTemplate'](arg1);
+}
+
+export function DetachWindow(arg1, arg2, arg3) {
+  return window['go']['main']['App']['DetachWindow'](arg1, arg2, arg3);
+}
+
+export function DownloadAttachment(arg1, arg2) {
+  return window['go']['main']['App']['DownloadAttachment'](arg1, arg2);
+}
+
+export function DownloadExport(arg1, arg2) {
+  return window['go']['main']['App']['DownloadExport'](arg1, arg2);
+}
+
+export function DownloadURL(arg1) {
+  return window['go']['main']['App']['DownloadURL'](arg1);
+}
+
+export function EscalateQuery(arg1) {
+  return window['go']['main']['App']['EscalateQuery'](arg1);
+}
+
+export function ExportResponse(arg1, arg2) {
+  return window['go']['main']['App']['ExportResponse'](arg1, arg2);
+}
+
+export function FavoriteSnippet(arg1, arg2) {
+  return window['go']['main']['App']['FavoriteSnippet'](arg1, arg2);
+}
+
+export function GeneratePassphrase(arg1) {
+  return window['go']['main']['App']['GeneratePassphrase'](arg1);
+}
+
+export function GeneratePassword(arg1) {
+  return window['go']['main']['App']['GeneratePassword'](arg1);
+}
+
+export function GetActiveApp() {
+  return window['go']['main']['App']['GetActiveApp']();
+}
+
+export function GetAppearance() {
+  return window['go']['main']['App']['GetAppearance']();
+}
+
+export function GetAutomationHooks() {
+  return window['go']['main']['App']['GetAutomationHooks']();
+}
+
+export function GetAutomationLog() {
+  return window['go']['main']['App']['GetAutomationLog']();
+}
+
+export function GetAverageLatency(arg1) {
+  return window['go']['main']['App']['GetAverageLatency'](arg1);
+}
+
+export function GetDailyUsage(arg1) {
+  return window['go']['main']['App']['GetDailyUsage'](arg1);
+}
+
+export function GetDisplays() {
+  return window['go']['main']['App']['GetDisplays']();
+}
+
+export function GetHotkeyCapabilities() {
+  return window['go']['main']['App']['GetHotkeyCapabilities']();
+}
+
+export function GetKeymap() {
+  return window['go']['main']['App']['GetKeymap']();
+}
+
+export function GetLastSession() {
+  return window['go']['main']['App']['GetLastSession']();
+}
+
+export function GetLocalAPIToken() {
+  return window['go']['main']['App']['GetLocalAPIToken']();
+}
+
+export function GetLocalTranslator() {
+  return window['go']['main']['App']['GetLocalTranslator']();
+}
+
+export function GetModels() {
+  return window['go']['main']['App']['GetModels']();
+}
+
+export function GetNextInput(arg1) {
+  return window['go']['main']['App']['GetNextInput'](arg1);
+}
+
+export function GetOnboarding() {
+  return window['go']['main']['App']['GetOnboarding']();
+}
+
+export function GetPermissions() {
+  return window['go']['main']['App']['GetPermissions']();
+}
+
+export function GetPreviousInput(arg1) {
+  return window['go']['main']['App']['GetPreviousInput'](arg1);
+}
+
+export function GetRecentItems() {
+  return window['go']['main']['App']['GetRecentItems']();
+}
+
+export function GetReleaseNotes() {
+  return window['go']['main']['App']['GetReleaseNotes']();
+}
+
+export function GetSelfCheck() {
+  return window['go']['main']['App']['GetSelfCheck']();
+}
+
+export function GetSessionPersona() {
+  return window['go']['main']['App']['GetSessionPersona']();
+}
+
+export function GetSettings() {
+  return window['go']['main']['App']['GetSettings']();
+}
+
+export function GetSpellCheck() {
+  return window['go']['main']['App']['GetSpellCheck']();
+}
+
+export function GetStatus() {
+  return window['go']['main']['App']['GetStatus']();
+}
+
+export function GetStorageUsage() {
+  return window['go']['main']['App']['GetStorageUsage']();
+}
+
+export function GetTheme() {
+  return window['go']['main']['App']['GetTheme']();
+}
+
+export function GetTopTemplates(arg1, arg2) {
+  return window['go']['main']['App']['GetTopTemplates'](arg1, arg2);
+}
+
+export function GetWakeWordDetector() {
+  return window['go']['main']['App']['GetWakeWordDetector']();
+}
+
+export function GetWakeWordStatus() {
+  return window['go']['main']['App']['GetWakeWordStatus']();
+}
+
+export function GetWindowState() {
+  return window['go']['main']['App']['GetWindowState']();
+}
+
+export function GetWorkspaceMap() {
+  return window['go']['main']['App']['GetWorkspaceMap']();
+}
+
+export function GlobalSearch(arg1) {
+  return window['go']['main']['App']['GlobalSearch'](arg1);
+}
+
 export function HideOverlay() {
   return window['go']['main']['App']['HideOverlay']();
 }
 
-export function OnWindowBlur() {
-  return window['go']['main']['App']['OnWindowBlur']();
+export function HotkeysEnabled() {
+  return window['go']['main']['App']['HotkeysEnabled']();
+}
+
+export function ImportBookmarks(arg1) {
+  return window['go']['main']['App']['ImportBookmarks'](arg1);
+}
+
+export function ImportDictionary(arg1) {
+  return window['go']['main']['App']['ImportDictionary'](arg1);
+}
+
+export function IsDefineQuery(arg1) {
+  return window['go']['main']['App']['IsDefineQuery'](arg1);
+}
+
+export function ListAudioDevices() {
+  return window['go']['main']['App']['ListAudioDevices']();
+}
+
+export function ListDetachedWindows() {
+  return window['go']['main']['App']['ListDetachedWindows']();
+}
+
+export function ListDownloads() {
+  return window['go']['main']['App']['ListDownloads']();
+}
+
+export function ListFavorites() {
+  return window['go']['main']['App']['ListFavorites']();
+}
+
+export function ListFolder(arg1, arg2) {
+  return window['go']['main']['App']['ListFolder'](arg1, arg2);
+}
+
+export function ListFolders() {
+  return window['go']['main']['App']['ListFolders']();
+}
+
+export function ListHistory(arg1, arg2) {
+  return window['go']['main']['App']['ListHistory'](arg1, arg2);
+}
+
+export function ListPersonas() {
+  return window['go']['main']['App']['ListPersonas']();
+}
+
+export function ListProcesses() {
+  return window['go']['main']['App']['ListProcesses']();
+}
+
+export function ListProviderKeys() {
+  return window['go']['main']['App']['ListProviderKeys']();
+}
+
+export function ListQuicklinks() {
+  return window['go']['main']['App']['ListQuicklinks']();
+}
+
+export function ListSystemCommands() {
+  return window['go']['main']['App']['ListSystemCommands']();
+}
+
+export function ListTags() {
+  return window['go']['main']['App']['ListTags']();
+}
+
+export function ListTemplates() {
+  return window['go']['main']['App']['ListTemplates']();
+}
+
+export function MarkReleaseNotesSeen() {
+  return window['go']['main']['App']['MarkReleaseNotesSeen']();
+}
+
+export function Match(arg1, arg2, arg3) {
+  return window['go']['main']['App']['Match'](arg1, arg2, arg3);
+}
+
+export function MinimizeToTray() {
+  return window['go']['main']['App']['MinimizeToTray']();
+}
+
+export function MoveCompanion(arg1) {
+  return window['go']['main']['App']['MoveCompanion'](arg1);
+}
+
+export function MyIP() {
+  return window['go']['main']['App']['MyIP']();
+}
+
+export function NewSession() {
+  return window['go']['main']['App']['NewSession']();
+}
+
+export function OnWindowBlur(arg1) {
+  return window['go']['main']['App']['OnWindowBlur'](arg1);
+}
+
+export function OnWindowFocus() {
+  return window['go']['main']['App']['OnWindowFocus']();
+}
+
+export function OpenQuicklink(arg1) {
+  return window['go']['main']['App']['OpenQuicklink'](arg1);
+}
+
+export function OpenRecentItem(arg1) {
+  return window['go']['main']['App']['OpenRecentItem'](arg1);
+}
+
+export function OpenSystemSettings(arg1) {
+  return window['go']['main']['App']['OpenSystemSettings'](arg1);
+}
+
+export function OpenURL(arg1) {
+  return window['go']['main']['App']['OpenURL'](arg1);
+}
+
+export function PasteLastResponse() {
+  return window['go']['main']['App']['PasteLastResponse']();
+}
+
+export function PinVersion(arg1) {
+  return window['go']['main']['App']['PinVersion'](arg1);
+}
+
+export function Ping(arg1, arg2) {
+  return window['go']['main']['App']['Ping'](arg1, arg2);
+}
+
+export function PreviewPrompt(arg1) {
+  return window['go']['main']['App']['PreviewPrompt'](arg1);
+}
+
+export function PrintRecords(arg1) {
+  return window['go']['main']['App']['PrintRecords'](arg1);
+}
+
+export function Query(arg1) {
+  return window['go']['main']['App']['Query'](arg1);
+}
+
+export function QuitApp(arg1, arg2) {
+  return window['go']['main']['App']['QuitApp'](arg1, arg2);
+}
+
+export function ReadWorkspaceFile(arg1) {
+  return window['go']['main']['App']['ReadWorkspaceFile'](arg1);
+}
+
+export function RemoveProviderKey(arg1) {
+  return window['go']['main']['App']['RemoveProviderKey'](arg1);
+}
+
+export function RequestPermission(arg1) {
+  return window['go']['main']['App']['RequestPermission'](arg1);
+}
+
+export function ResetOnboarding() {
+  return window['go']['main']['App']['ResetOnboarding']();
+}
+
+export function ResetOverlayPlacements() {
+  return window['go']['main']['App']['ResetOverlayPlacements']();
+}
+
+export function ResetZoom() {
+  return window['go']['main']['App']['ResetZoom']();
+}
+
+export function ResumeSession(arg1) {
+  return window['go']['main']['App']['ResumeSession'](arg1);
+}
+
+export function RevealDownload(arg1) {
+  return window['go']['main']['App']['RevealDownload'](arg1);
+}
+
+export function RevokePermission(arg1) {
+  return window['go']['main']['App']['RevokePermission'](arg1);
+}
+
+export function RunDiagnostics() {
+  return window['go']['main']['App']['RunDiagnostics']();
+}
+
+export function RunSelfCheck() {
+  return window['go']['main']['App']['RunSelfCheck']();
+}
+
+export function RunSystemCommand(arg1, arg2) {
+  return window['go']['main']['App']['RunSystemCommand'](arg1, arg2);
+}
+
+export function SaveNote(arg1, arg2) {
+  return window['go']['main']['App']['SaveNote'](arg1, arg2);
+}
+
+export function SavePersona(arg1) {
+  return window['go']['main']['App']['SavePersona'](arg1);
+}
+
+export function SaveQuicklink(arg1) {
+  return window['go']['main']['App']['SaveQuicklink'](arg1);
+}
+
+export function SaveSettings(arg1) {
+  return window['go']['main']['App']['SaveSettings'](arg1);
+}
+
+export function SaveTemplate(arg1) {
+  return window['go']['main']['App']['SaveTemplate'](arg1);
+}
+
+export function ScreenshotToPrompt() {
+  return window['go']['main']['App']['ScreenshotToPrompt']();
+}
+
+export function SearchHistory(arg1, arg2) {
+  return window['go']['main']['App']['SearchHistory'](arg1, arg2);
+}
+
+export function SearchQuicklinks(arg1, arg2) {
+  return window['go']['main']['App']['SearchQuicklinks'](arg1, arg2);
+}
+
+export function SearchSystemCommands(arg1, arg2) {
+  return window['go']['main']['App']['SearchSystemCommands'](arg1, arg2);
+}
+
+export function SelectSessionKey(arg1) {
+  return window['go']['main']['App']['SelectSessionKey'](arg1);
+}
+
+export function SemanticSearch(arg1, arg2) {
+  return window['go']['main']['App']['SemanticSearch'](arg1, arg2);
+}
+
+export function SendPrompt(arg1) {
+  return window['go']['main']['App']['SendPrompt'](arg1);
+}
+
+export function SendTemplate(arg1) {
+  return window['go']['main']['App']['SendTemplate'](arg1);
+}
+
+export function SetAlwaysOnTop(arg1) {
+  return window['go']['main']['App']['SetAlwaysOnTop'](arg1);
+}
+
+export function SetAudioDevices(arg1, arg2) {
+  return window['go']['main']['App']['SetAudioDevices'](arg1, arg2);
+}
+
+export function SetAutomationHooks(arg1) {
+  return window['go']['main']['App']['SetAutomationHooks'](arg1);
+}
+
+export function SetBlurGrace(arg1) {
+  return window['go']['main']['App']['SetBlurGrace'](arg1);
+}
+
+export function SetClipboardSuggestions(arg1) {
+  return window['go']['main']['App']['SetClipboardSuggestions'](arg1);
+}
+
+export function SetCompanionStatus(arg1) {
+  return window['go']['main']['App']['SetCompanionStatus'](arg1);
+}
+
+export function SetContextPrivacy(arg1) {
+  return window['go']['main']['App']['SetContextPrivacy'](arg1);
+}
+
+export function SetContextRules(arg1) {
+  return window['go']['main']['App']['SetContextRules'](arg1);
+}
+
+export function SetDefaultProviderKey(arg1) {
+  return window['go']['main']['App']['SetDefaultProviderKey'](arg1);
+}
+
+export function SetDictionary(arg1) {
+  return window['go']['main']['App']['SetDictionary'](arg1);
+}
+
+export function SetDownloadsDir(arg1) {
+  return window['go']['main']['App']['SetDownloadsDir'](arg1);
+}
+
+export function SetFailover(arg1) {
+  return window['go']['main']['App']['SetFailover'](arg1);
+}
+
+export function SetFavorite(arg1, arg2) {
+  return window['go']['main']['App']['SetFavorite'](arg1, arg2);
+}
+
+export function SetFolder(arg1, arg2) {
+  return window['go']['main']['App']['SetFolder'](arg1, arg2);
+}
+
+export function SetFontSize(arg1) {
+  return window['go']['main']['App']['SetFontSize'](arg1);
+}
+
+export function SetHideOnBlur(arg1) {
+  return window['go']['main']['App']['SetHideOnBlur'](arg1);
+}
+
+export function SetHotkey(arg1, arg2) {
+  return window['go']['main']['App']['SetHotkey'](arg1, arg2);
+}
+
+export function SetHotkeysEnabled(arg1) {
+  return window['go']['main']['App']['SetHotkeysEnabled'](arg1);
+}
+
+export function SetKeymap(arg1) {
+  return window['go']['main']['App']['SetKeymap'](arg1);
+}
+
+export function SetLinkSettings(arg1) {
+  return window['go']['main']['App']['SetLinkSettings'](arg1);
+}
+
+export function SetLocalAPI(arg1) {
+  return window['go']['main']['App']['SetLocalAPI'](arg1);
+}
+
+export function SetLocalTranslator(arg1) {
+  return window['go']['main']['App']['SetLocalTranslator'](arg1);
+}
+
+export function SetMouseTrigger(arg1) {
+  return window['go']['main']['App']['SetMouseTrigger'](arg1);
+}
+
+export function SetPostProcessing(arg1) {
+  return window['go']['main']['App']['SetPostProcessing'](arg1);
+}
+
+export function SetProviderCredentials(arg1) {
+  return window['go']['main']['App']['SetProviderCredentials'](arg1);
+}
+
+export function SetRetrieval(arg1) {
+  return window['go']['main']['App']['SetRetrieval'](arg1);
+}
+
+export function SetScreenshotPrompt(arg1) {
+  return window['go']['main']['App']['SetScreenshotPrompt'](arg1);
+}
+
+export function SetScrollAnchor(arg1) {
+  return window['go']['main']['App']['SetScrollAnchor'](arg1);
+}
+
+export function SetSelectionHint(arg1) {
+  return window['go']['main']['App']['SetSelectionHint'](arg1);
+}
+
+export function SetSessionPersona(arg1) {
+  return window['go']['main']['App']['SetSessionPersona'](arg1);
+}
+
+export function SetSpellCheck(arg1) {
+  return window['go']['main']['App']['SetSpellCheck'](arg1);
+}
+
+export function SetStorageLimits(arg1) {
+  return window['go']['main']['App']['SetStorageLimits'](arg1);
+}
+
+export function SetStreamBatch(arg1) {
+  return window['go']['main']['App']['SetStreamBatch'](arg1);
+}
+
+export function SetSyncCredentials(arg1) {
+  return window['go']['main']['App']['SetSyncCredentials'](arg1);
+}
+
+export function SetSystemControls(arg1) {
+  return window['go']['main']['App']['SetSystemControls'](arg1);
+}
+
+export function SetTranslation(arg1) {
+  return window['go']['main']['App']['SetTranslation'](arg1);
+}
+
+export function SetUpdateChannel(arg1) {
+  return window['go']['main']['App']['SetUpdateChannel'](arg1);
+}
+
+export function SetWakeWord(arg1) {
+  return window['go']['main']['App']['SetWakeWord'](arg1);
+}
+
+export function SetWakeWordDetector(arg1) {
+  return window['go']['main']['App']['SetWakeWordDetector'](arg1);
+}
+
+export function SetWorkspace(arg1) {
+  return window['go']['main']['App']['SetWorkspace'](arg1);
+}
+
+export function SetZoom(arg1) {
+  return window['go']['main']['App']['SetZoom'](arg1);
 }
 
 export function ShowOverlay() {
   return window['go']['main']['App']['ShowOverlay']();
 }
+
+export function SkipOnboarding() {
+  return window['go']['main']['App']['SkipOnboarding']();
+}
+
+export function SkipRelease(arg1) {
+  return window['go']['main']['App']['SkipRelease'](arg1);
+}
+
+export function SnapOverlay(arg1) {
+  return window['go']['main']['App']['SnapOverlay'](arg1);
+}
+
+export function StartHotkeyCapture(arg1, arg2) {
+  return window['go']['main']['App']['StartHotkeyCapture'](arg1, arg2);
+}
+
+export function StartRecording(arg1) {
+  return window['go']['main']['App']['StartRecording'](arg1);
+}
+
+export function StopWakeWord() {
+  return window['go']['main']['App']['StopWakeWord']();
+}
+
+export function SyncNow() {
+  return window['go']['main']['App']['SyncNow']();
+}
+
+export function TagRecord(arg1, arg2) {
+  return window['go']['main']['App']['TagRecord'](arg1, arg2);
+}
+
+export function TestAutomationHook(arg1) {
+  return window['go']['main']['App']['TestAutomationHook'](arg1);
+}
+
+export function ToggleAnnotation() {
+  return window['go']['main']['App']['ToggleAnnotation']();
+}
+
+export function ToggleCompanion() {
+  return window['go']['main']['App']['ToggleCompanion']();
+}
+
+export function ToggleFullscreen() {
+  return window['go']['main']['App']['ToggleFullscreen']();
+}
+
+export function Translate(arg1, arg2) {
+  return window['go']['main']['App']['Translate'](arg1, arg2);
+}
+
+export function TranslateSelection() {
+  return window['go']['main']['App']['TranslateSelection']();
+}
+
+export function UntagRecord(arg1, arg2) {
+  return window['go']['main']['App']['UntagRecord'](arg1, arg2);
+}
+
+export function UpdateTag(arg1, arg2) {
+  return window['go']['main']['App']['UpdateTag'](arg1, arg2);
+}
+
+export function ValidateHotkey(arg1) {
+  return window['go']['main']['App']['ValidateHotkey'](arg1);
+}
+
+export function ValidateProviderKey(arg1) {
+  return window['go']['main']['App']['ValidateProviderKey'](arg1);
+}
+
+export function ZoomIn() {
+  return window['go']['main']['App']['ZoomIn']();
+}
+
+export function ZoomOut() {
+  return window['go']['main']['App']['ZoomOut']();
+}
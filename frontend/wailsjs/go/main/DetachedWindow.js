@@ -0,0 +1,11 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function Close() {
+  return window['go']['main']['DetachedWindow']['Close']();
+}
+
+export function GetContent() {
+  return window['go']['main']['DetachedWindow']['GetContent']();
+}
@@ -0,0 +1,8 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function Choose(arg1:string):Promise<void>;
+
+export function Dismiss():Promise<void>;
+
+export function GetActions():Promise<Array<string>>;
@@ -0,0 +1,15 @@
+// @ts-check
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+export function Choose(arg1) {
+  return window['go']['main']['SelectionHintWindow']['Choose'](arg1);
+}
+
+export function Dismiss() {
+  return window['go']['main']['SelectionHintWindow']['Dismiss']();
+}
+
+export function GetActions() {
+  return window['go']['main']['SelectionHintWindow']['GetActions']();
+}
@@ -0,0 +1,2346 @@
+export namespace main {
+	
+	export class ActiveApp {
+	    name: string;
+	    id: string;
+	    windowTitle: string;
+	    pid: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ActiveApp(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.id = source["id"];
+	        this.windowTitle = source["windowTitle"];
+	        this.pid = source["pid"];
+	    }
+	}
+	export class SystemCheck {
+	    id: string;
+	    title: string;
+	    status: string;
+	    affects: string;
+	    repair?: string;
+	    settingsUrl?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SystemCheck(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.title = source["title"];
+	        this.status = source["status"];
+	        this.affects = source["affects"];
+	        this.repair = source["repair"];
+	        this.settingsUrl = source["settingsUrl"];
+	    }
+	}
+	export class SelfCheck {
+	    checks: SystemCheck[];
+	    missing: number;
+	    // Go type: time
+	    checkedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new SelfCheck(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.checks = this.convertValues(source["checks"], SystemCheck);
+	        this.missing = source["missing"];
+	        this.checkedAt = this.convertValues(source["checkedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DatabaseStatus {
+	    path: string;
+	    records: number;
+	    lines: number;
+	    corrupt: number;
+	    ok: boolean;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DatabaseStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.records = source["records"];
+	        this.lines = source["lines"];
+	        this.corrupt = source["corrupt"];
+	        this.ok = source["ok"];
+	        this.error = source["error"];
+	    }
+	}
+	export class ProviderStatus {
+	    kind: string;
+	    baseUrl: string;
+	    model: string;
+	    checked: boolean;
+	    reachable: boolean;
+	    latencyMs?: number;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProviderStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.baseUrl = source["baseUrl"];
+	        this.model = source["model"];
+	        this.checked = source["checked"];
+	        this.reachable = source["reachable"];
+	        this.latencyMs = source["latencyMs"];
+	        this.error = source["error"];
+	    }
+	}
+	export class HotkeyStatus {
+	    name: string;
+	    spec: string;
+	    active: boolean;
+	    registered: boolean;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new HotkeyStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.spec = source["spec"];
+	        this.active = source["active"];
+	        this.registered = source["registered"];
+	        this.error = source["error"];
+	    }
+	}
+	export class AppStatus {
+	    version: string;
+	    os: string;
+	    arch: string;
+	    goVersion: string;
+	    dataDir: string;
+	    portable: boolean;
+	    hotkeysEnabled: boolean;
+	    hotkeys: HotkeyStatus[];
+	    provider: ProviderStatus;
+	    database: DatabaseStatus;
+	    system: SelfCheck;
+	    // Go type: time
+	    checkedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new AppStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.version = source["version"];
+	        this.os = source["os"];
+	        this.arch = source["arch"];
+	        this.goVersion = source["goVersion"];
+	        this.dataDir = source["dataDir"];
+	        this.portable = source["portable"];
+	        this.hotkeysEnabled = source["hotkeysEnabled"];
+	        this.hotkeys = this.convertValues(source["hotkeys"], HotkeyStatus);
+	        this.provider = this.convertValues(source["provider"], ProviderStatus);
+	        this.database = this.convertValues(source["database"], DatabaseStatus);
+	        this.system = this.convertValues(source["system"], SelfCheck);
+	        this.checkedAt = this.convertValues(source["checkedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AppearanceSettings {
+	    zoom: number;
+	    fontSize: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new AppearanceSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.zoom = source["zoom"];
+	        this.fontSize = source["fontSize"];
+	    }
+	}
+	export class Attachment {
+	    kind: string;
+	    size: number;
+	    path?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Attachment(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.size = source["size"];
+	        this.path = source["path"];
+	    }
+	}
+	export class AudioDevice {
+	    id: string;
+	    name: string;
+	    kind: string;
+	    default: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new AudioDevice(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.kind = source["kind"];
+	        this.default = source["default"];
+	    }
+	}
+	export class AudioSettings {
+	    inputDevice: string;
+	    outputDevice: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AudioSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.inputDevice = source["inputDevice"];
+	        this.outputDevice = source["outputDevice"];
+	    }
+	}
+	export class AutomationHook {
+	    id: string;
+	    name: string;
+	    enabled: boolean;
+	    event: string;
+	    command?: string[];
+	    url?: string;
+	    timeoutSec?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new AutomationHook(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.enabled = source["enabled"];
+	        this.event = source["event"];
+	        this.command = source["command"];
+	        this.url = source["url"];
+	        this.timeoutSec = source["timeoutSec"];
+	    }
+	}
+	export class CacheReport {
+	    files: number;
+	    bytes: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CacheReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.files = source["files"];
+	        this.bytes = source["bytes"];
+	    }
+	}
+	export class CacheSettings {
+	    enabled: boolean;
+	    ttlMinutes: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CacheSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.ttlMinutes = source["ttlMinutes"];
+	    }
+	}
+	export class ClipboardRule {
+	    name: string;
+	    pattern: string;
+	    title: string;
+	    prompt: string;
+	    action: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ClipboardRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.pattern = source["pattern"];
+	        this.title = source["title"];
+	        this.prompt = source["prompt"];
+	        this.action = source["action"];
+	    }
+	}
+	export class ClipboardSuggestionSettings {
+	    enabled: boolean;
+	    rules: ClipboardRule[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ClipboardSuggestionSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.rules = this.convertValues(source["rules"], ClipboardRule);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CompanionStatus {
+	    title: string;
+	    text: string;
+	    busy: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new CompanionStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.title = source["title"];
+	        this.text = source["text"];
+	        this.busy = source["busy"];
+	    }
+	}
+	export class ContextItem {
+	    variable: string;
+	    value: string;
+	    enabled: boolean;
+	    used: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ContextItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.variable = source["variable"];
+	        this.value = source["value"];
+	        this.enabled = source["enabled"];
+	        this.used = source["used"];
+	    }
+	}
+	export class ContextPrivacy {
+	    app: boolean;
+	    windowTitle: boolean;
+	    selection: boolean;
+	    clipboard: boolean;
+	    page: boolean;
+	    workspace: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ContextPrivacy(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.app = source["app"];
+	        this.windowTitle = source["windowTitle"];
+	        this.selection = source["selection"];
+	        this.clipboard = source["clipboard"];
+	        this.page = source["page"];
+	        this.workspace = source["workspace"];
+	    }
+	}
+	export class ContextRule {
+	    app: string;
+	    titleContains: string;
+	    mode: string;
+	    attachSelection: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ContextRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.app = source["app"];
+	        this.titleContains = source["titleContains"];
+	        this.mode = source["mode"];
+	        this.attachSelection = source["attachSelection"];
+	    }
+	}
+	export class DailyUsage {
+	    date: string;
+	    requests: number;
+	    responses: number;
+	    inputChars: number;
+	    outputChars: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DailyUsage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.requests = source["requests"];
+	        this.responses = source["responses"];
+	        this.inputChars = source["inputChars"];
+	        this.outputChars = source["outputChars"];
+	    }
+	}
+	
+	export class DateRange {
+	    // Go type: time
+	    from: any;
+	    // Go type: time
+	    to: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new DateRange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = this.convertValues(source["from"], null);
+	        this.to = this.convertValues(source["to"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Definition {
+	    text: string;
+	    example?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Definition(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.text = source["text"];
+	        this.example = source["example"];
+	    }
+	}
+	export class DetachedContent {
+	    id: string;
+	    title: string;
+	    content: string;
+	    format: string;
+	    // Go type: time
+	    createdAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new DetachedContent(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.title = source["title"];
+	        this.content = source["content"];
+	        this.format = source["format"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Meaning {
+	    partOfSpeech: string;
+	    definitions: Definition[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Meaning(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.partOfSpeech = source["partOfSpeech"];
+	        this.definitions = this.convertValues(source["definitions"], Definition);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DictionaryEntry {
+	    word: string;
+	    phonetic?: string;
+	    audioUrl?: string;
+	    meanings: Meaning[];
+	    synonyms?: string[];
+	    antonyms?: string[];
+	    source: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DictionaryEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.word = source["word"];
+	        this.phonetic = source["phonetic"];
+	        this.audioUrl = source["audioUrl"];
+	        this.meanings = this.convertValues(source["meanings"], Meaning);
+	        this.synonyms = source["synonyms"];
+	        this.antonyms = source["antonyms"];
+	        this.source = source["source"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DictionarySettings {
+	    offline: boolean;
+	    language: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DictionarySettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.offline = source["offline"];
+	        this.language = source["language"];
+	    }
+	}
+	export class Rect {
+	    x: number;
+	    y: number;
+	    width: number;
+	    height: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Rect(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.x = source["x"];
+	        this.y = source["y"];
+	        this.width = source["width"];
+	        this.height = source["height"];
+	    }
+	}
+	export class Display {
+	    id: string;
+	    name: string;
+	    bounds: Rect;
+	    workArea: Rect;
+	    scale: number;
+	    primary: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Display(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.bounds = this.convertValues(source["bounds"], Rect);
+	        this.workArea = this.convertValues(source["workArea"], Rect);
+	        this.scale = source["scale"];
+	        this.primary = source["primary"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Download {
+	    id: string;
+	    name: string;
+	    path: string;
+	    size: number;
+	    written: number;
+	    status: string;
+	    error?: string;
+	    // Go type: time
+	    createdAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Download(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.size = source["size"];
+	        this.written = source["written"];
+	        this.status = source["status"];
+	        this.error = source["error"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ProviderSettings {
+	    kind: string;
+	    baseUrl: string;
+	    model: string;
+	    keyId?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProviderSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.baseUrl = source["baseUrl"];
+	        this.model = source["model"];
+	        this.keyId = source["keyId"];
+	    }
+	}
+	export class FailoverSettings {
+	    providers: ProviderSettings[];
+	    timeoutSec?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new FailoverSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.providers = this.convertValues(source["providers"], ProviderSettings);
+	        this.timeoutSec = source["timeoutSec"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GeneratedSecret {
+	    value: string;
+	    entropy: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new GeneratedSecret(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.value = source["value"];
+	        this.entropy = source["entropy"];
+	    }
+	}
+	export class HistoryRecord {
+	    id: string;
+	    kind: string;
+	    text: string;
+	    requestId?: string;
+	    model?: string;
+	    sourceId?: string;
+	    favorite?: boolean;
+	    tags?: string[];
+	    folder?: string;
+	    sessionId?: string;
+	    keyId?: string;
+	    personaId?: string;
+	    durationMs?: number;
+	    cached?: boolean;
+	    attachments?: Attachment[];
+	    // Go type: time
+	    createdAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new HistoryRecord(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.kind = source["kind"];
+	        this.text = source["text"];
+	        this.requestId = source["requestId"];
+	        this.model = source["model"];
+	        this.sourceId = source["sourceId"];
+	        this.favorite = source["favorite"];
+	        this.tags = source["tags"];
+	        this.folder = source["folder"];
+	        this.sessionId = source["sessionId"];
+	        this.keyId = source["keyId"];
+	        this.personaId = source["personaId"];
+	        this.durationMs = source["durationMs"];
+	        this.cached = source["cached"];
+	        this.attachments = this.convertValues(source["attachments"], Attachment);
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class HookRun {
+	    hookId: string;
+	    name: string;
+	    event: string;
+	    // Go type: time
+	    started: any;
+	    durationMs: number;
+	    ok: boolean;
+	    output?: string;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new HookRun(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hookId = source["hookId"];
+	        this.name = source["name"];
+	        this.event = source["event"];
+	        this.started = this.convertValues(source["started"], null);
+	        this.durationMs = source["durationMs"];
+	        this.ok = source["ok"];
+	        this.output = source["output"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class HotkeyCapabilities {
+	    platform: string;
+	    modifiers: string[];
+	    keys: string[];
+	    functionKeys: number;
+	    numpad: boolean;
+	    mediaKeys: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new HotkeyCapabilities(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.platform = source["platform"];
+	        this.modifiers = source["modifiers"];
+	        this.keys = source["keys"];
+	        this.functionKeys = source["functionKeys"];
+	        this.numpad = source["numpad"];
+	        this.mediaKeys = source["mediaKeys"];
+	    }
+	}
+	
+	export class KeyBinding {
+	    action: string;
+	    spec: string;
+	    key: string;
+	    ctrl: boolean;
+	    alt: boolean;
+	    shift: boolean;
+	    meta: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new KeyBinding(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.action = source["action"];
+	        this.spec = source["spec"];
+	        this.key = source["key"];
+	        this.ctrl = source["ctrl"];
+	        this.alt = source["alt"];
+	        this.shift = source["shift"];
+	        this.meta = source["meta"];
+	    }
+	}
+	export class KeyUsage {
+	    requests: number;
+	    characters: number;
+	    // Go type: time
+	    lastUsed: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new KeyUsage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.requests = source["requests"];
+	        this.characters = source["characters"];
+	        this.lastUsed = this.convertValues(source["lastUsed"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LanguageStat {
+	    language: string;
+	    files: number;
+	    bytes: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LanguageStat(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.language = source["language"];
+	        this.files = source["files"];
+	        this.bytes = source["bytes"];
+	    }
+	}
+	export class ModelLatency {
+	    model: string;
+	    averageMs: number;
+	    samples: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModelLatency(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.model = source["model"];
+	        this.averageMs = source["averageMs"];
+	        this.samples = source["samples"];
+	    }
+	}
+	export class LatencySummary {
+	    averageMs: number;
+	    samples: number;
+	    models: ModelLatency[];
+	
+	    static createFrom(source: any = {}) {
+	        return new LatencySummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.averageMs = source["averageMs"];
+	        this.samples = source["samples"];
+	        this.models = this.convertValues(source["models"], ModelLatency);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LinkSettings {
+	    confirm: string;
+	    allowlist: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new LinkSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.confirm = source["confirm"];
+	        this.allowlist = source["allowlist"];
+	    }
+	}
+	export class LocalAPISettings {
+	    enabled: boolean;
+	    port: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LocalAPISettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.port = source["port"];
+	    }
+	}
+	export class MatchCandidate {
+	    id: string;
+	    text: string;
+	    frequency?: number;
+	    // Go type: time
+	    lastUsed?: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new MatchCandidate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.text = source["text"];
+	        this.frequency = source["frequency"];
+	        this.lastUsed = this.convertValues(source["lastUsed"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MatchOptions {
+	    limit?: number;
+	    maxTypos?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MatchOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.limit = source["limit"];
+	        this.maxTypos = source["maxTypos"];
+	    }
+	}
+	export class MatchResult {
+	    id: string;
+	    text: string;
+	    score: number;
+	    typos?: number;
+	    positions?: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new MatchResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.text = source["text"];
+	        this.score = source["score"];
+	        this.typos = source["typos"];
+	        this.positions = source["positions"];
+	    }
+	}
+	
+	export class ModelCatalog {
+	    keyId: string;
+	    models: string[];
+	    // Go type: time
+	    fetchedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModelCatalog(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.keyId = source["keyId"];
+	        this.models = source["models"];
+	        this.fetchedAt = this.convertValues(source["fetchedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class ReleaseNote {
+	    version: string;
+	    date: string;
+	    highlights: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ReleaseNote(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.version = source["version"];
+	        this.date = source["date"];
+	        this.highlights = source["highlights"];
+	    }
+	}
+	export class OnboardingStep {
+	    id: string;
+	    title: string;
+	    done: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new OnboardingStep(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.title = source["title"];
+	        this.done = source["done"];
+	    }
+	}
+	export class Onboarding {
+	    steps: OnboardingStep[];
+	    tourPending: boolean;
+	    // Go type: time
+	    firstRunAt: any;
+	    whatsNew: ReleaseNote[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Onboarding(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.steps = this.convertValues(source["steps"], OnboardingStep);
+	        this.tourPending = source["tourPending"];
+	        this.firstRunAt = this.convertValues(source["firstRunAt"], null);
+	        this.whatsNew = this.convertValues(source["whatsNew"], ReleaseNote);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class PassphraseOptions {
+	    words: number;
+	    separator: string;
+	    capitalize: boolean;
+	    includeNumber: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new PassphraseOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.words = source["words"];
+	        this.separator = source["separator"];
+	        this.capitalize = source["capitalize"];
+	        this.includeNumber = source["includeNumber"];
+	    }
+	}
+	export class PasswordOptions {
+	    length: number;
+	    lowercase: boolean;
+	    uppercase: boolean;
+	    digits: boolean;
+	    symbols: boolean;
+	    excludeAmbiguous: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new PasswordOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.length = source["length"];
+	        this.lowercase = source["lowercase"];
+	        this.uppercase = source["uppercase"];
+	        this.digits = source["digits"];
+	        this.symbols = source["symbols"];
+	        this.excludeAmbiguous = source["excludeAmbiguous"];
+	    }
+	}
+	export class PermissionStatus {
+	    capability: string;
+	    description: string;
+	    granted: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new PermissionStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.capability = source["capability"];
+	        this.description = source["description"];
+	        this.granted = source["granted"];
+	    }
+	}
+	export class Persona {
+	    id: string;
+	    name: string;
+	    systemPrompt: string;
+	    model?: string;
+	    icon?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Persona(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.systemPrompt = source["systemPrompt"];
+	        this.model = source["model"];
+	        this.icon = source["icon"];
+	    }
+	}
+	export class PostProcessSettings {
+	    steps: string[];
+	    redactPatterns?: string[];
+	    maxLength?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PostProcessSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.steps = source["steps"];
+	        this.redactPatterns = source["redactPatterns"];
+	        this.maxLength = source["maxLength"];
+	    }
+	}
+	export class ProcessInfo {
+	    pid: number;
+	    name: string;
+	    path?: string;
+	    cpu: number;
+	    memory: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProcessInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.pid = source["pid"];
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.cpu = source["cpu"];
+	        this.memory = source["memory"];
+	    }
+	}
+	export class PromptPreview {
+	    prompt: string;
+	    items: ContextItem[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PromptPreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.prompt = source["prompt"];
+	        this.items = this.convertValues(source["items"], ContextItem);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PromptTemplate {
+	    id: string;
+	    name: string;
+	    prompt: string;
+	    systemPrompt?: string;
+	    baseUrl?: string;
+	    keyId?: string;
+	    model?: string;
+	    temperature?: number;
+	    maxTokens?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PromptTemplate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.prompt = source["prompt"];
+	        this.systemPrompt = source["systemPrompt"];
+	        this.baseUrl = source["baseUrl"];
+	        this.keyId = source["keyId"];
+	        this.model = source["model"];
+	        this.temperature = source["temperature"];
+	        this.maxTokens = source["maxTokens"];
+	    }
+	}
+	export class ProviderCredentials {
+	    apiKey: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProviderCredentials(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.apiKey = source["apiKey"];
+	    }
+	}
+	export class ProviderKey {
+	    id: string;
+	    label: string;
+	    provider: string;
+	    hint: string;
+	    // Go type: time
+	    createdAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProviderKey(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.label = source["label"];
+	        this.provider = source["provider"];
+	        this.hint = source["hint"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ProviderKeyInfo {
+	    id: string;
+	    label: string;
+	    provider: string;
+	    hint: string;
+	    // Go type: time
+	    createdAt: any;
+	    default: boolean;
+	    selected: boolean;
+	    usage: KeyUsage;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProviderKeyInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.label = source["label"];
+	        this.provider = source["provider"];
+	        this.hint = source["hint"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.default = source["default"];
+	        this.selected = source["selected"];
+	        this.usage = this.convertValues(source["usage"], KeyUsage);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class Quicklink {
+	    id: string;
+	    name: string;
+	    kind: string;
+	    target: string;
+	    keywords?: string[];
+	    uses?: number;
+	    // Go type: time
+	    lastUsed?: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Quicklink(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.kind = source["kind"];
+	        this.target = source["target"];
+	        this.keywords = source["keywords"];
+	        this.uses = source["uses"];
+	        this.lastUsed = this.convertValues(source["lastUsed"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RecentItem {
+	    kind: string;
+	    name: string;
+	    path?: string;
+	    id?: string;
+	    // Go type: time
+	    time: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new RecentItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.id = source["id"];
+	        this.time = this.convertValues(source["time"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RecordingOptions {
+	    seconds: number;
+	    region?: Rect;
+	    gif: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new RecordingOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.seconds = source["seconds"];
+	        this.region = this.convertValues(source["region"], Rect);
+	        this.gif = source["gif"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class Release {
+	    version: string;
+	    channel?: string;
+	    url: string;
+	    notes?: string;
+	    rollout?: number;
+	    // Go type: time
+	    publishedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Release(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.version = source["version"];
+	        this.channel = source["channel"];
+	        this.url = source["url"];
+	        this.notes = source["notes"];
+	        this.rollout = source["rollout"];
+	        this.publishedAt = this.convertValues(source["publishedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class RetrievalSettings {
+	    enabled: boolean;
+	    model: string;
+	    autoInclude: boolean;
+	    topK: number;
+	    minScore: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RetrievalSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.model = source["model"];
+	        this.autoInclude = source["autoInclude"];
+	        this.topK = source["topK"];
+	        this.minScore = source["minScore"];
+	    }
+	}
+	export class SelectionHintSettings {
+	    enabled: boolean;
+	    actions: string[];
+	    excludedApps: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SelectionHintSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.actions = source["actions"];
+	        this.excludedApps = source["excludedApps"];
+	    }
+	}
+	
+	export class SemanticMatch {
+	    record: HistoryRecord;
+	    score: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SemanticMatch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.record = this.convertValues(source["record"], HistoryRecord);
+	        this.score = source["score"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Session {
+	    id: string;
+	    records: HistoryRecord[];
+	    scrollAnchor?: string;
+	    // Go type: time
+	    updatedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Session(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.records = this.convertValues(source["records"], HistoryRecord);
+	        this.scrollAnchor = source["scrollAnchor"];
+	        this.updatedAt = this.convertValues(source["updatedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SyncSettings {
+	    backend: string;
+	    url: string;
+	    bucket: string;
+	    region: string;
+	    prefix: string;
+	    conflict: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SyncSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.backend = source["backend"];
+	        this.url = source["url"];
+	        this.bucket = source["bucket"];
+	        this.region = source["region"];
+	        this.prefix = source["prefix"];
+	        this.conflict = source["conflict"];
+	    }
+	}
+	export class SystemControlSettings {
+	    confirm: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SystemControlSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.confirm = source["confirm"];
+	    }
+	}
+	export class StorageSettings {
+	    tempMaxMb: number;
+	    tempMaxAgeHours: number;
+	    cacheMaxMb: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new StorageSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.tempMaxMb = source["tempMaxMb"];
+	        this.tempMaxAgeHours = source["tempMaxAgeHours"];
+	        this.cacheMaxMb = source["cacheMaxMb"];
+	    }
+	}
+	export class WorkspaceSettings {
+	    root: string;
+	    maxFileKb: number;
+	    maxContextKb: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new WorkspaceSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.root = source["root"];
+	        this.maxFileKb = source["maxFileKb"];
+	        this.maxContextKb = source["maxContextKb"];
+	    }
+	}
+	export class TranslationSettings {
+	    target: string;
+	    model?: string;
+	    replaceSelection: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new TranslationSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.target = source["target"];
+	        this.model = source["model"];
+	        this.replaceSelection = source["replaceSelection"];
+	    }
+	}
+	export class UpdateSettings {
+	    channel: string;
+	    feedUrl?: string;
+	    pinnedVersion?: string;
+	    skippedVersion?: string;
+	    rolloutBucket?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new UpdateSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.channel = source["channel"];
+	        this.feedUrl = source["feedUrl"];
+	        this.pinnedVersion = source["pinnedVersion"];
+	        this.skippedVersion = source["skippedVersion"];
+	        this.rolloutBucket = source["rolloutBucket"];
+	    }
+	}
+	export class WakeWordSettings {
+	    enabled: boolean;
+	    phrase: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new WakeWordSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.phrase = source["phrase"];
+	    }
+	}
+	export class SpellCheckSettings {
+	    enabled: boolean;
+	    language: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SpellCheckSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.language = source["language"];
+	    }
+	}
+	export class Settings {
+	    defaultMode: string;
+	    contextRules: ContextRule[];
+	    contextPrivacy: ContextPrivacy;
+	    companionCorner: string;
+	    hotkeys: Record<string, string>;
+	    keymap?: Record<string, string>;
+	    mouseTrigger: string;
+	    hideOnBlur: string;
+	    blurGraceMs: number;
+	    snapHotkeys: boolean;
+	    windowHotkeys: boolean;
+	    provider: ProviderSettings;
+	    failover: FailoverSettings;
+	    postProcess: PostProcessSettings;
+	    screenshotPrompt: string;
+	    maxConcurrentRequests: number;
+	    streamBatchMs: number;
+	    queryEscalateMs: number;
+	    responseCache: CacheSettings;
+	    appearance: AppearanceSettings;
+	    spellCheck: SpellCheckSettings;
+	    audio: AudioSettings;
+	    wakeWord: WakeWordSettings;
+	    clipboardSuggestions: ClipboardSuggestionSettings;
+	    selectionHint: SelectionHintSettings;
+	    updates: UpdateSettings;
+	    dictionary: DictionarySettings;
+	    translation: TranslationSettings;
+	    retrieval: RetrievalSettings;
+	    workspace: WorkspaceSettings;
+	    storage: StorageSettings;
+	    downloadsDir: string;
+	    links: LinkSettings;
+	    systemControls: SystemControlSettings;
+	    sync: SyncSettings;
+	    localApi: LocalAPISettings;
+	
+	    static createFrom(source: any = {}) {
+	        return new Settings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.defaultMode = source["defaultMode"];
+	        this.contextRules = this.convertValues(source["contextRules"], ContextRule);
+	        this.contextPrivacy = this.convertValues(source["contextPrivacy"], ContextPrivacy);
+	        this.companionCorner = source["companionCorner"];
+	        this.hotkeys = source["hotkeys"];
+	        this.keymap = source["keymap"];
+	        this.mouseTrigger = source["mouseTrigger"];
+	        this.hideOnBlur = source["hideOnBlur"];
+	        this.blurGraceMs = source["blurGraceMs"];
+	        this.snapHotkeys = source["snapHotkeys"];
+	        this.windowHotkeys = source["windowHotkeys"];
+	        this.provider = this.convertValues(source["provider"], ProviderSettings);
+	        this.failover = this.convertValues(source["failover"], FailoverSettings);
+	        this.postProcess = this.convertValues(source["postProcess"], PostProcessSettings);
+	        this.screenshotPrompt = source["screenshotPrompt"];
+	        this.maxConcurrentRequests = source["maxConcurrentRequests"];
+	        this.streamBatchMs = source["streamBatchMs"];
+	        this.queryEscalateMs = source["queryEscalateMs"];
+	        this.responseCache = this.convertValues(source["responseCache"], CacheSettings);
+	        this.appearance = this.convertValues(source["appearance"], AppearanceSettings);
+	        this.spellCheck = this.convertValues(source["spellCheck"], SpellCheckSettings);
+	        this.audio = this.convertValues(source["audio"], AudioSettings);
+	        this.wakeWord = this.convertValues(source["wakeWord"], WakeWordSettings);
+	        this.clipboardSuggestions = this.convertValues(source["clipboardSuggestions"], ClipboardSuggestionSettings);
+	        this.selectionHint = this.convertValues(source["selectionHint"], SelectionHintSettings);
+	        this.updates = this.convertValues(source["updates"], UpdateSettings);
+	        this.dictionary = this.convertValues(source["dictionary"], DictionarySettings);
+	        this.translation = this.convertValues(source["translation"], TranslationSettings);
+	        this.retrieval = this.convertValues(source["retrieval"], RetrievalSettings);
+	        this.workspace = this.convertValues(source["workspace"], WorkspaceSettings);
+	        this.storage = this.convertValues(source["storage"], StorageSettings);
+	        this.downloadsDir = source["downloadsDir"];
+	        this.links = this.convertValues(source["links"], LinkSettings);
+	        this.systemControls = this.convertValues(source["systemControls"], SystemControlSettings);
+	        this.sync = this.convertValues(source["sync"], SyncSettings);
+	        this.localApi = this.convertValues(source["localApi"], LocalAPISettings);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SpellCheckInfo {
+	    enabled: boolean;
+	    language: string;
+	    languages: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SpellCheckInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.language = source["language"];
+	        this.languages = source["languages"];
+	    }
+	}
+	
+	
+	export class StorageUsage {
+	    name: string;
+	    path: string;
+	    files: number;
+	    bytes: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new StorageUsage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.files = source["files"];
+	        this.bytes = source["bytes"];
+	    }
+	}
+	export class SyncConflict {
+	    file: string;
+	    resolution: string;
+	    backupPath: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SyncConflict(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.file = source["file"];
+	        this.resolution = source["resolution"];
+	        this.backupPath = source["backupPath"];
+	    }
+	}
+	export class SyncCredentials {
+	    username: string;
+	    password: string;
+	    accessKey: string;
+	    secretKey: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SyncCredentials(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.username = source["username"];
+	        this.password = source["password"];
+	        this.accessKey = source["accessKey"];
+	        this.secretKey = source["secretKey"];
+	    }
+	}
+	export class SyncResult {
+	    pushed: string[];
+	    pulled: string[];
+	    conflicts: SyncConflict[];
+	    // Go type: time
+	    at: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new SyncResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.pushed = source["pushed"];
+	        this.pulled = source["pulled"];
+	        this.conflicts = this.convertValues(source["conflicts"], SyncConflict);
+	        this.at = this.convertValues(source["at"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class SystemCommand {
+	    id: string;
+	    title: string;
+	    keywords?: string[];
+	    takesValue?: boolean;
+	    destructive?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new SystemCommand(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.title = source["title"];
+	        this.keywords = source["keywords"];
+	        this.takesValue = source["takesValue"];
+	        this.destructive = source["destructive"];
+	    }
+	}
+	
+	export class Tag {
+	    name: string;
+	    color?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Tag(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.color = source["color"];
+	    }
+	}
+	export class TagInfo {
+	    name: string;
+	    color?: string;
+	    count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TagInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.color = source["color"];
+	        this.count = source["count"];
+	    }
+	}
+	export class TemplateUsage {
+	    template: string;
+	    count: number;
+	    // Go type: time
+	    lastUsed: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new TemplateUsage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.template = source["template"];
+	        this.count = source["count"];
+	        this.lastUsed = this.convertValues(source["lastUsed"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Theme {
+	    css: string;
+	    variables: Record<string, string>;
+	    errors?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Theme(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.css = source["css"];
+	        this.variables = source["variables"];
+	        this.errors = source["errors"];
+	    }
+	}
+	export class Translation {
+	    text: string;
+	    source: string;
+	    target: string;
+	    engine: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Translation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.text = source["text"];
+	        this.source = source["source"];
+	        this.target = source["target"];
+	        this.engine = source["engine"];
+	    }
+	}
+	
+	
+	
+	export class WakeWordStatus {
+	    listening: boolean;
+	    phrase?: string;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new WakeWordStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.listening = source["listening"];
+	        this.phrase = source["phrase"];
+	        this.error = source["error"];
+	    }
+	}
+	export class WindowState {
+	    visible: boolean;
+	    fullscreen: boolean;
+	    alwaysOnTop: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new WindowState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.visible = source["visible"];
+	        this.fullscreen = source["fullscreen"];
+	        this.alwaysOnTop = source["alwaysOnTop"];
+	    }
+	}
+	export class WorkspaceFile {
+	    path: string;
+	    size: number;
+	    language?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new WorkspaceFile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.size = source["size"];
+	        this.language = source["language"];
+	    }
+	}
+	export class WorkspaceMap {
+	    root: string;
+	    files: WorkspaceFile[];
+	    languages: LanguageStat[];
+	    truncated: boolean;
+	    // Go type: time
+	    builtAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new WorkspaceMap(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.root = source["root"];
+	        this.files = this.convertValues(source["files"], WorkspaceFile);
+	        this.languages = this.convertValues(source["languages"], LanguageStat);
+	        this.truncated = source["truncated"];
+	        this.builtAt = this.convertValues(source["builtAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
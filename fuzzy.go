@@ -0,0 +1,299 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// The scoring follows fzy: matched characters earn a bonus depending on
+// what precedes them, runs of consecutive matches earn more, and gaps cost
+// a little, so "gco" ranks "git checkout" above "gecko".
+const (
+	scoreGapLeading  = -0.005
+	scoreGapTrailing = -0.005
+	scoreGapInner    = -0.01
+	scoreConsecutive = 1.0
+	scoreAfterSlash  = 0.9
+	scoreAfterWord   = 0.8
+	scoreCapital     = 0.7
+	scoreAfterDot    = 0.6
+	// scoreExact lifts a candidate equal to the query above everything
+	scoreExact = 100.0
+
+	// maxMatchRunes bounds the text scored per candidate
+	maxMatchRunes = 1024
+	// recencyHalfLife is how quickly the recency boost fades
+	recencyHalfLife = 7 * 24 * time.Hour
+	// Boost weights are added to the match score
+	frequencyWeight = 0.5
+	recencyWeight   = 1.0
+)
+
+// MatchCandidate is one item to rank. Frequency and LastUsed are optional
+// boosts for things used often or lately.
+type MatchCandidate struct {
+	ID        string    `json:"id"`
+	Text      string    `json:"text"`
+	Frequency int       `json:"frequency,omitempty"`
+	LastUsed  time.Time `json:"lastUsed,omitempty"`
+}
+
+// MatchOptions tunes Match
+type MatchOptions struct {
+	// Limit caps the results; zero returns every match
+	Limit int `json:"limit,omitempty"`
+	// MaxTypos lets queries of three or more characters match a word with
+	// up to this many typos when they are not a subsequence
+	MaxTypos int `json:"maxTypos,omitempty"`
+}
+
+// MatchResult is a ranked candidate; Positions are the rune offsets of
+// the matched characters, for highlighting
+type MatchResult struct {
+	ID        string  `json:"id"`
+	Text      string  `json:"text"`
+	Score     float64 `json:"score"`
+	Typos     int     `json:"typos,omitempty"`
+	Positions []int   `json:"positions,omitempty"`
+}
+
+// Match ranks candidates against query with the same fuzzy matcher every
+// search in the app uses. Results are best first: exact subsequence
+// matches before ones needing typos, then by score.
+func (a *App) Match(query string, candidates []MatchCandidate, opts MatchOptions) []MatchResult {
+	return fuzzyMatch(query, candidates, opts)
+}
+
+func fuzzyMatch(query string, candidates []MatchCandidate, opts MatchOptions) []MatchResult {
+	q := []rune(strings.ToLower(strings.TrimSpace(query)))
+	m := &matcher{query: q}
+	now := time.Now()
+	results := []MatchResult{}
+	for _, c := range candidates {
+		r, ok := m.match(c.Text, opts.MaxTypos)
+		if !ok {
+			continue
+		}
+		r.ID, r.Text = c.ID, c.Text
+		if c.Frequency > 0 {
+			r.Score += frequencyWeight * math.Log1p(float64(c.Frequency))
+		}
+		if !c.LastUsed.IsZero() {
+			r.Score += recencyWeight * math.Exp2(-float64(now.Sub(c.LastUsed))/float64(recencyHalfLife))
+		}
+		results = append(results, r)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Typos != results[j].Typos {
+			return results[i].Typos < results[j].Typos
+		}
+		return results[i].Score > results[j].Score
+	})
+	if opts.Limit > 0 && len(results) > opts.Limit {
+		results = results[:opts.Limit]
+	}
+	return results
+}
+
+// matcher scores one query against many texts, reusing its buffers
+type matcher struct {
+	query []rune
+	text  []rune
+	lower []rune
+	bonus []float64
+	d, m  [][]float64
+	// rows are the edit distance buffers
+	rows [3][]int
+}
+
+func (m *matcher) match(text string, maxTypos int) (MatchResult, bool) {
+	if len(m.query) == 0 {
+		return MatchResult{}, true
+	}
+	m.text, m.lower = m.text[:0], m.lower[:0]
+	for _, r := range text {
+		if len(m.text) == maxMatchRunes {
+			break
+		}
+		m.text = append(m.text, r)
+		m.lower = append(m.lower, unicode.ToLower(r))
+	}
+	if !isSubsequence(m.query, m.lower) {
+		if maxTypos <= 0 || len(m.query) < 3 {
+			return MatchResult{}, false
+		}
+		typos, ok := m.typoMatch(maxTypos)
+		// A typo match is ranked only by how few typos it needs
+		return MatchResult{Typos: typos, Score: -float64(typos)}, ok
+	}
+	score, positions := m.score()
+	if len(m.query) == len(m.lower) {
+		score += scoreExact
+	}
+	return MatchResult{Score: score, Positions: positions}, true
+}
+
+func isSubsequence(q, t []rune) bool {
+	i := 0
+	for _, r := range t {
+		if i < len(q) && r == q[i] {
+			i++
+		}
+	}
+	return i == len(q)
+}
+
+// score runs the fzy dynamic program: d[i][j] is the best score with
+// query[i] matched at text[j], m[i][j] the best with query[:i+1] matched
+// anywhere in text[:j+1]
+func (m *matcher) score() (float64, []int) {
+	n, k := len(m.query), len(m.lower)
+	m.computeBonus()
+	m.d, m.m = grow(m.d, n, k), grow(m.m, n, k)
+	inf := math.Inf(-1)
+	for i := 0; i < n; i++ {
+		prev := inf
+		gap := scoreGapInner
+		if i == n-1 {
+			gap = scoreGapTrailing
+		}
+		for j := 0; j < k; j++ {
+			if m.query[i] != m.lower[j] {
+				m.d[i][j] = inf
+				prev += gap
+				m.m[i][j] = prev
+				continue
+			}
+			score := inf
+			switch {
+			case i == 0:
+				score = float64(j)*scoreGapLeading + m.bonus[j]
+			case j > 0:
+				score = math.Max(m.m[i-1][j-1]+m.bonus[j], m.d[i-1][j-1]+scoreConsecutive)
+			}
+			m.d[i][j] = score
+			prev = math.Max(score, prev+gap)
+			m.m[i][j] = prev
+		}
+	}
+
+	// Walk back through the tables to recover where each character matched
+	positions := make([]int, n)
+	required := false
+	j := k - 1
+	for i := n - 1; i >= 0; i-- {
+		for ; j >= 0; j-- {
+			if m.d[i][j] != inf && (required || m.d[i][j] == m.m[i][j]) {
+				required = i > 0 && j > 0 && m.m[i][j] == m.d[i-1][j-1]+scoreConsecutive
+				positions[i] = j
+				j--
+				break
+			}
+		}
+	}
+	return m.m[n-1][k-1], positions
+}
+
+// computeBonus rewards matches at the start of a word, path segment or
+// camelCase hump
+func (m *matcher) computeBonus() {
+	m.bonus = m.bonus[:0]
+	prev := '/'
+	for _, r := range m.text {
+		b := 0.0
+		switch {
+		case prev == '/' || prev == '\\':
+			b = scoreAfterSlash
+		case prev == '-' || prev == '_' || unicode.IsSpace(prev):
+			b = scoreAfterWord
+		case prev == '.':
+			b = scoreAfterDot
+		case unicode.IsLower(prev) && unicode.IsUpper(r):
+			b = scoreCapital
+		}
+		m.bonus = append(m.bonus, b)
+		prev = r
+	}
+}
+
+func grow(t [][]float64, n, k int) [][]float64 {
+	for len(t) < n {
+		t = append(t, nil)
+	}
+	for i := 0; i < n; i++ {
+		if cap(t[i]) < k {
+			t[i] = make([]float64, k)
+		}
+		t[i] = t[i][:k]
+	}
+	return t
+}
+
+// typoMatch looks for a word starting like the query with at most
+// maxTypos edits, counting a swap of neighbours as one
+func (m *matcher) typoMatch(maxTypos int) (int, bool) {
+	best := maxTypos + 1
+	for start := 0; start < len(m.lower) && best > 0; start++ {
+		if !isWordRune(m.lower[start]) || (start > 0 && isWordRune(m.lower[start-1])) {
+			continue
+		}
+		end := start
+		for end < len(m.lower) && isWordRune(m.lower[end]) {
+			end++
+		}
+		word := m.lower[start:end]
+		// Compare against prefixes one shorter and longer than the query
+		for l := len(m.query) - 1; l <= len(m.query)+1; l++ {
+			if l < 1 || l > len(word) {
+				continue
+			}
+			if d := m.editDistance(word[:l], best); d < best {
+				best = d
+			}
+		}
+	}
+	return best, best <= maxTypos
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// editDistance is the optimal string alignment distance between the query
+// and b, giving up once it reaches limit
+func (m *matcher) editDistance(b []rune, limit int) int {
+	a := m.query
+	for i := range m.rows {
+		if cap(m.rows[i]) < len(b)+1 {
+			m.rows[i] = make([]int, len(b)+1)
+		}
+		m.rows[i] = m.rows[i][:len(b)+1]
+	}
+	prev2, prev, cur := m.rows[0], m.rows[1], m.rows[2]
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+			rowMin = min(rowMin, cur[j])
+		}
+		if rowMin >= limit {
+			return limit
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(b)]
+}
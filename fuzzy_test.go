@@ -0,0 +1,131 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func matchIDs(results []MatchResult) []string {
+	ids := make([]string, len(results))
+	for i, r := range results {
+		ids[i] = r.ID
+	}
+	return ids
+}
+
+func TestFuzzyMatchRanking(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		candidates []MatchCandidate
+		opts       MatchOptions
+		want       []string
+	}{
+		{
+			name:       "word starts beat scattered letters",
+			query:      "gco",
+			candidates: []MatchCandidate{{ID: "gecko", Text: "gecko"}, {ID: "checkout", Text: "git checkout"}},
+			want:       []string{"checkout", "gecko"},
+		},
+		{
+			name:       "exact match first",
+			query:      "note",
+			candidates: []MatchCandidate{{ID: "notes", Text: "notes"}, {ID: "note", Text: "Note"}, {ID: "denote", Text: "denote"}},
+			want:       []string{"note", "notes", "denote"},
+		},
+		{
+			name:       "non-matches dropped",
+			query:      "xyz",
+			candidates: []MatchCandidate{{ID: "a", Text: "abc"}, {ID: "b", Text: "x y z"}},
+			want:       []string{"b"},
+		},
+		{
+			name:       "empty query keeps order",
+			query:      "  ",
+			candidates: []MatchCandidate{{ID: "a", Text: "abc"}, {ID: "b", Text: "def"}},
+			want:       []string{"a", "b"},
+		},
+		{
+			name:       "typos rank after subsequence matches",
+			query:      "chekcout",
+			candidates: []MatchCandidate{{ID: "typo", Text: "git checkout"}, {ID: "exact", Text: "chekcout notes"}},
+			opts:       MatchOptions{MaxTypos: 1},
+			want:       []string{"exact", "typo"},
+		},
+		{
+			name:       "typos off by default",
+			query:      "chekcout",
+			candidates: []MatchCandidate{{ID: "typo", Text: "git checkout"}},
+			want:       []string{},
+		},
+		{
+			name:       "short queries need no typos",
+			query:      "xa",
+			candidates: []MatchCandidate{{ID: "a", Text: "ab"}},
+			opts:       MatchOptions{MaxTypos: 1},
+			want:       []string{},
+		},
+		{
+			name:       "too many typos",
+			query:      "chkcuot",
+			candidates: []MatchCandidate{{ID: "a", Text: "checkout"}},
+			opts:       MatchOptions{MaxTypos: 1},
+			want:       []string{},
+		},
+		{
+			name:       "frequency breaks ties",
+			query:      "re",
+			candidates: []MatchCandidate{{ID: "rare", Text: "report"}, {ID: "often", Text: "report", Frequency: 10}},
+			want:       []string{"often", "rare"},
+		},
+		{
+			name:  "recent breaks ties",
+			query: "re",
+			candidates: []MatchCandidate{
+				{ID: "old", Text: "report", LastUsed: time.Now().Add(-60 * 24 * time.Hour)},
+				{ID: "new", Text: "report", LastUsed: time.Now()},
+			},
+			want: []string{"new", "old"},
+		},
+		{
+			name:       "limit",
+			query:      "a",
+			candidates: []MatchCandidate{{ID: "a", Text: "a"}, {ID: "b", Text: "ab"}, {ID: "c", Text: "abc"}},
+			opts:       MatchOptions{Limit: 2},
+			want:       []string{"a", "b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := matchIDs(fuzzyMatch(tt.query, tt.candidates, tt.opts))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("fuzzyMatch(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFuzzyMatchPositions(t *testing.T) {
+	tests := []struct {
+		query, text string
+		want        []int
+	}{
+		{"gc", "git checkout", []int{0, 4}},
+		{"GC", "git checkout", []int{0, 4}},
+		{"out", "git checkout", []int{9, 10, 11}},
+		{"fb", "fooBar", []int{0, 3}},
+		{"ab", "a/b", []int{0, 2}},
+		{"é", "café", []int{3}},
+	}
+	for _, tt := range tests {
+		results := fuzzyMatch(tt.query, []MatchCandidate{{ID: "x", Text: tt.text}}, MatchOptions{})
+		if len(results) != 1 {
+			t.Errorf("fuzzyMatch(%q, %q) matched %d, want 1", tt.query, tt.text, len(results))
+			continue
+		}
+		if got := results[0].Positions; !slices.Equal(got, tt.want) {
+			t.Errorf("fuzzyMatch(%q, %q) positions = %v, want %v", tt.query, tt.text, got, tt.want)
+		}
+	}
+}
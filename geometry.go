@@ -0,0 +1,87 @@
+package main
+
+// Rect is a rectangle in desktop coordinates with a top-left origin
+type Rect struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+func (r Rect) right() int  { return r.X + r.Width }
+func (r Rect) bottom() int { return r.Y + r.Height }
+
+// overlap returns the area shared by r and o
+func (r Rect) overlap(o Rect) int {
+	w := min(r.right(), o.right()) - max(r.X, o.X)
+	h := min(r.bottom(), o.bottom()) - max(r.Y, o.Y)
+	if w <= 0 || h <= 0 {
+		return 0
+	}
+	return w * h
+}
+
+// contains reports whether the point lies inside r
+func (r Rect) contains(x, y int) bool {
+	return x >= r.X && x < r.right() && y >= r.Y && y < r.bottom()
+}
+
+// displayFor returns the display showing most of r, falling back to the
+// display nearest its centre and then to the primary display
+func displayFor(displays []Display, r Rect) (Display, bool) {
+	if len(displays) == 0 {
+		return Display{}, false
+	}
+	best, bestArea := -1, 0
+	for i, d := range displays {
+		if area := d.Bounds.overlap(r); area > bestArea {
+			best, bestArea = i, area
+		}
+	}
+	if best >= 0 {
+		return displays[best], true
+	}
+
+	cx, cy := r.X+r.Width/2, r.Y+r.Height/2
+	nearest, nearestDist := 0, -1
+	for i, d := range displays {
+		dx := max(d.Bounds.X-cx, 0, cx-d.Bounds.right())
+		dy := max(d.Bounds.Y-cy, 0, cy-d.Bounds.bottom())
+		if dist := dx*dx + dy*dy; nearestDist < 0 || dist < nearestDist {
+			nearest, nearestDist = i, dist
+		}
+	}
+	return displays[nearest], true
+}
+
+// primaryDisplay returns the primary display, or the first one
+func primaryDisplay(displays []Display) (Display, bool) {
+	for _, d := range displays {
+		if d.Primary {
+			return d, true
+		}
+	}
+	if len(displays) > 0 {
+		return displays[0], true
+	}
+	return Display{}, false
+}
+
+// centerIn returns a rect of the given size centred in area
+func centerIn(area Rect, width, height int) Rect {
+	width, height = min(width, area.Width), min(height, area.Height)
+	return Rect{
+		X:      area.X + (area.Width-width)/2,
+		Y:      area.Y + (area.Height-height)/2,
+		Width:  width,
+		Height: height,
+	}
+}
+
+// clampInto moves r so it lies fully inside area, shrinking it if needed
+func clampInto(r, area Rect) Rect {
+	r.Width, r.Height = min(r.Width, area.Width), min(r.Height, area.Height)
+	r.X = max(area.X, min(r.X, area.right()-r.Width))
+	r.Y = max(area.Y, min(r.Y, area.bottom()-r.Height))
+	return r
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// globalSearchPerSource caps each group; a source's best matches are
+	// enough to decide where to look further
+	globalSearchPerSource = 8
+	// clipboardHistoryMax is how many recent copies are kept in memory
+	clipboardHistoryMax = 50
+)
+
+// Global search sources, one group of results each. Records are in
+// exactly one: favorites are starred responses, snippets are saved
+// favorites whether starred or not.
+const (
+	searchHistory   = "history"
+	searchNotes     = "notes"
+	searchSnippets  = "snippets"
+	searchClipboard = "clipboard"
+	searchFavorites = "favorites"
+)
+
+// SearchHit is one global search result. Positions are the rune offsets
+// in Text the query matched, for highlighting.
+type SearchHit struct {
+	Source    string    `json:"source"`
+	ID        string    `json:"id"`
+	Kind      string    `json:"kind,omitempty"`
+	Title     string    `json:"title"`
+	Text      string    `json:"text"`
+	Score     float64   `json:"score"`
+	Positions []int     `json:"positions,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// GlobalSearchResults is one source's results, sent in a
+// global-search-results event as soon as that source is searched
+type GlobalSearchResults struct {
+	Query  string      `json:"query"`
+	Source string      `json:"source"`
+	Hits   []SearchHit `json:"hits"`
+	// Notice says why a source was not searched
+	Notice string `json:"notice,omitempty"`
+	// Remaining counts the sources still being searched; the last group
+	// has zero
+	Remaining int `json:"remaining"`
+}
+
+// clipboardCopy is a copy seen by the clipboard watcher
+type clipboardCopy struct {
+	id   string
+	text string
+	at   time.Time
+}
+
+// rememberCopy adds text to the in-memory clipboard history. Only copies
+// the watcher reads are kept, so it fills while clipboard suggestions are
+// on; it is cleared when they are turned off and never written to disk.
+func (a *App) rememberCopy(text string) {
+	a.clipboardMu.Lock()
+	defer a.clipboardMu.Unlock()
+	a.clipboardHistory = slices.DeleteFunc(a.clipboardHistory, func(c clipboardCopy) bool { return c.text == text })
+	a.clipboardHistory = append(a.clipboardHistory, clipboardCopy{id: newID(), text: text, at: time.Now()})
+	if len(a.clipboardHistory) > clipboardHistoryMax {
+		a.clipboardHistory = a.clipboardHistory[1:]
+	}
+}
+
+func (a *App) clearClipboardHistory() {
+	a.clipboardMu.Lock()
+	a.clipboardHistory = nil
+	a.clipboardMu.Unlock()
+}
+
+// GlobalSearch searches history, notes, snippets, clipboard history and
+// favorites at once. Each source's ranked results arrive in a
+// global-search-results event tagged with the returned ID; a newer search
+// stops the groups of an older one.
+func (a *App) GlobalSearch(query string) (string, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return "", errors.New("query is empty")
+	}
+	id := newRequestID()
+	a.searchMu.Lock()
+	a.searchID = id
+	a.searchMu.Unlock()
+
+	sources := map[string]func() []SearchHit{
+		searchHistory: func() []SearchHit {
+			return a.searchRecords(query, searchHistory, func(r HistoryRecord) bool {
+				return (r.Kind == "input" || r.Kind == "response") && !r.Favorite
+			})
+		},
+		searchNotes: func() []SearchHit {
+			return a.searchRecords(query, searchNotes, func(r HistoryRecord) bool { return r.Kind == "note" })
+		},
+		searchSnippets: func() []SearchHit {
+			return a.searchRecords(query, searchSnippets, func(r HistoryRecord) bool { return r.Kind == "snippet" })
+		},
+		searchFavorites: func() []SearchHit {
+			return a.searchRecords(query, searchFavorites, func(r HistoryRecord) bool { return r.Favorite && r.Kind != "snippet" })
+		},
+	}
+	// Copies are only read while clipboard suggestions are on, so without
+	// them the group says there is no clipboard history to search
+	clipboardOn := a.GetSettings().ClipboardSuggestions.Enabled
+	sources[searchClipboard] = func() []SearchHit {
+		if !clipboardOn {
+			return []SearchHit{}
+		}
+		return a.searchClipboard(query)
+	}
+
+	var mu sync.Mutex
+	remaining := len(sources)
+	for source, search := range sources {
+		go func() {
+			group := GlobalSearchResults{Query: query, Source: source, Hits: search()}
+			if source == searchClipboard && !clipboardOn {
+				group.Notice = "Clipboard history is only kept while clipboard suggestions are on"
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			remaining--
+			group.Remaining = remaining
+			a.searchMu.Lock()
+			current := a.searchID == id
+			a.searchMu.Unlock()
+			if current {
+				eventGlobalSearch.respond(a.ctx, id, group, nil)
+			}
+		}()
+	}
+	return id, nil
+}
+
+// searchRecords ranks the history records keep accepts against query
+func (a *App) searchRecords(query, source string, keep func(HistoryRecord) bool) []SearchHit {
+	records := a.queryHistory(keep)
+	candidates := make([]MatchCandidate, len(records))
+	byID := map[string]HistoryRecord{}
+	for i, r := range records {
+		byID[r.ID] = r
+		candidates[i] = MatchCandidate{ID: r.ID, Text: r.Text, LastUsed: r.CreatedAt}
+	}
+	hits := []SearchHit{}
+	for _, m := range fuzzyMatch(query, candidates, MatchOptions{Limit: globalSearchPerSource, MaxTypos: 1}) {
+		r := byID[m.ID]
+		hits = append(hits, SearchHit{Source: source, ID: r.ID, Kind: r.Kind, Title: firstLine(r.Text), Text: r.Text,
+			Score: m.Score, Positions: m.Positions, CreatedAt: r.CreatedAt})
+	}
+	return hits
+}
+
+// clipboardCopies returns the clipboard history, oldest first
+func (a *App) clipboardCopies() []clipboardCopy {
+	a.clipboardMu.Lock()
+	defer a.clipboardMu.Unlock()
+	return slices.Clone(a.clipboardHistory)
+}
+
+func (a *App) searchClipboard(query string) []SearchHit {
+	copies := a.clipboardCopies()
+	candidates := make([]MatchCandidate, len(copies))
+	byID := map[string]clipboardCopy{}
+	for i, c := range copies {
+		byID[c.id] = c
+		candidates[i] = MatchCandidate{ID: c.id, Text: c.text, LastUsed: c.at}
+	}
+	hits := []SearchHit{}
+	for _, m := range fuzzyMatch(query, candidates, MatchOptions{Limit: globalSearchPerSource, MaxTypos: 1}) {
+		c := byID[m.ID]
+		hits = append(hits, SearchHit{Source: searchClipboard, ID: c.id, Title: firstLine(c.text), Text: c.text,
+			Score: m.Score, Positions: m.Positions, CreatedAt: c.at})
+	}
+	return hits
+}
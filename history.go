@@ -0,0 +1,287 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+const historyFile = "history.jsonl"
+
+// HistoryRecord is one submitted input, received response or saved
+// snippet
+type HistoryRecord struct {
+	ID string `json:"id"`
+	// Kind is "input", "response", "snippet" or "note"
+	Kind      string `json:"kind"`
+	Text      string `json:"text"`
+	RequestID string `json:"requestId,omitempty"`
+	Model     string `json:"model,omitempty"`
+	// SourceID is the record a snippet was cut from
+	SourceID string   `json:"sourceId,omitempty"`
+	Favorite bool     `json:"favorite,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	// Folder is a slash-separated path such as "work/reports"
+	Folder    string `json:"folder,omitempty"`
+	SessionID string `json:"sessionId,omitempty"`
+	// KeyID is the provider key a response was paid with
+	KeyID string `json:"keyId,omitempty"`
+	// PersonaID is the persona active when an input was sent
+	PersonaID string `json:"personaId,omitempty"`
+	// DurationMs is how long a response took to complete; Cached marks
+	// one replayed from the response cache
+	DurationMs int64 `json:"durationMs,omitempty"`
+	Cached     bool  `json:"cached,omitempty"`
+	// Attachments describe the context sent with an input
+	Attachments []Attachment `json:"attachments,omitempty"`
+	CreatedAt   time.Time    `json:"createdAt"`
+}
+
+// historyLine is one entry in the log; Deleted marks a tombstone
+type historyLine struct {
+	HistoryRecord
+	Deleted bool `json:"deleted,omitempty"`
+}
+
+// historyStore is an append-only JSON-lines log with an in-memory index.
+// Updating a record appends a new version and deleting one appends a
+// tombstone; the last line for an ID wins and superseded lines are dropped
+// when the log is compacted on open.
+type historyStore struct {
+	mu      sync.Mutex
+	path    string
+	file    *os.File
+	records []HistoryRecord
+	index   map[string]int
+}
+
+func openHistoryStore(path string) (*historyStore, error) {
+	h := &historyStore{path: path, index: map[string]int{}}
+	lines, err := h.load()
+	if err != nil {
+		return nil, err
+	}
+	if lines > 2*len(h.records) && lines > 100 {
+		if err := h.compact(); err != nil {
+			return nil, err
+		}
+	}
+	h.file, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// load replays the log and returns how many lines it had
+func (h *historyStore) load() (int, error) {
+	f, err := os.Open(h.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	lines := 0
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		lines++
+		var l historyLine
+		// A torn last line from a crash is skipped rather than fatal
+		if json.Unmarshal(scanner.Bytes(), &l) != nil || l.ID == "" {
+			continue
+		}
+		if l.Deleted {
+			h.removeLocked(l.ID)
+		} else {
+			h.setLocked(l.HistoryRecord)
+		}
+	}
+	sort.SliceStable(h.records, func(i, j int) bool { return h.records[i].CreatedAt.Before(h.records[j].CreatedAt) })
+	for i, r := range h.records {
+		h.index[r.ID] = i
+	}
+	return lines, scanner.Err()
+}
+
+func (h *historyStore) compact() error {
+	var buf []byte
+	for _, r := range h.records {
+		line, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		buf = append(append(buf, line...), '\n')
+	}
+	return writeFileAtomic(h.path, buf, 0o600)
+}
+
+func (h *historyStore) setLocked(r HistoryRecord) {
+	if i, ok := h.index[r.ID]; ok {
+		h.records[i] = r
+		return
+	}
+	h.index[r.ID] = len(h.records)
+	h.records = append(h.records, r)
+}
+
+func (h *historyStore) removeLocked(id string) {
+	i, ok := h.index[id]
+	if !ok {
+		return
+	}
+	h.records = append(h.records[:i], h.records[i+1:]...)
+	delete(h.index, id)
+	for j := i; j < len(h.records); j++ {
+		h.index[h.records[j].ID] = j
+	}
+}
+
+func (h *historyStore) appendLocked(l historyLine) error {
+	if h.file == nil {
+		return errors.New("history store is closed")
+	}
+	line, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+	_, err = h.file.Write(append(line, '\n'))
+	return err
+}
+
+// put inserts or replaces r and appends it to the log
+func (h *historyStore) put(r HistoryRecord) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err := h.appendLocked(historyLine{HistoryRecord: r}); err != nil {
+		return err
+	}
+	h.setLocked(r)
+	return nil
+}
+
+// update applies fn to the record with id and stores the result. Returning
+// an error from fn leaves the record unchanged.
+func (h *historyStore) update(id string, fn func(r *HistoryRecord) error) (HistoryRecord, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	i, ok := h.index[id]
+	if !ok {
+		return HistoryRecord{}, fmt.Errorf("no history record %q", id)
+	}
+	r := h.records[i]
+	r.Tags = slices.Clone(r.Tags)
+	if err := fn(&r); err != nil {
+		return h.records[i], err
+	}
+	if err := h.appendLocked(historyLine{HistoryRecord: r}); err != nil {
+		return h.records[i], err
+	}
+	h.records[i] = r
+	return r, nil
+}
+
+// remove deletes the record with id
+func (h *historyStore) remove(id string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.index[id]; !ok {
+		return fmt.Errorf("no history record %q", id)
+	}
+	if err := h.appendLocked(historyLine{HistoryRecord: HistoryRecord{ID: id}, Deleted: true}); err != nil {
+		return err
+	}
+	h.removeLocked(id)
+	return nil
+}
+
+func (h *historyStore) get(id string) (HistoryRecord, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	i, ok := h.index[id]
+	if !ok {
+		return HistoryRecord{}, false
+	}
+	return h.records[i], true
+}
+
+// list returns the records keep accepts, oldest first
+func (h *historyStore) list(keep func(HistoryRecord) bool) []HistoryRecord {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var out []HistoryRecord
+	for _, r := range h.records {
+		if keep == nil || keep(r) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// verify rereads the log and counts its lines and the ones that no longer
+// parse, which load would silently skip
+func (h *historyStore) verify() (lines, corrupt int, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.file == nil {
+		return 0, 0, errors.New("history store is closed")
+	}
+	f, err := os.Open(h.path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		lines++
+		var l historyLine
+		if json.Unmarshal(scanner.Bytes(), &l) != nil || l.ID == "" {
+			corrupt++
+		}
+	}
+	return lines, corrupt, scanner.Err()
+}
+
+// close syncs the log to disk; later writes fail
+func (h *historyStore) close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.file == nil {
+		return nil
+	}
+	err := h.file.Sync()
+	if cerr := h.file.Close(); err == nil {
+		err = cerr
+	}
+	h.file = nil
+	return err
+}
+
+// recordHistory appends a new record, logging rather than failing the
+// caller when the store is unavailable
+func (a *App) recordHistory(r HistoryRecord) HistoryRecord {
+	if r.ID == "" {
+		r.ID = newID()
+	}
+	if r.CreatedAt.IsZero() {
+		r.CreatedAt = time.Now()
+	}
+	if a.history == nil {
+		return r
+	}
+	if err := a.history.put(r); err != nil {
+		println("Error writing history:", err.Error())
+	}
+	return r
+}
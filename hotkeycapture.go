@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.design/x/hotkey"
+)
+
+const hotkeyCaptureTimeout = 15 * time.Second
+
+// HotkeyCapture is the payload of the hotkey-captured response. A
+// combination that can't be registered still carries its spec and codes.
+type HotkeyCapture struct {
+	Name string `json:"name"`
+	// Spec is the human-readable combination, e.g. "Ctrl+Shift+K"
+	Spec string `json:"spec"`
+	// RawModifiers and RawKey are the platform codes as the OS reports them
+	RawModifiers int  `json:"rawModifiers"`
+	RawKey       int  `json:"rawKey"`
+	Registered   bool `json:"registered"`
+}
+
+// StartHotkeyCapture listens for the next key combination pressed anywhere
+// and returns a request ID; the combination arrives as the hotkey-captured
+// response, or hotkey-capture-cancelled for a bare Escape or
+// CancelHotkeyCapture. Registered hotkeys are suspended meanwhile so
+// existing shortcuts can be captured too. With register set, a valid
+// combination becomes the shortcut of binding name.
+func (a *App) StartHotkeyCapture(name string, register bool) (string, error) {
+	a.captureMu.Lock()
+	if a.captureStop != nil {
+		a.captureMu.Unlock()
+		return "", fmt.Errorf("a hotkey capture is already running")
+	}
+	stop := make(chan struct{})
+	var once sync.Once
+	a.captureStop = func() { once.Do(func() { close(stop) }) }
+	cancel := a.captureStop
+	a.captureMu.Unlock()
+
+	a.unregisterHotkeys()
+	var timedOut atomic.Bool
+	timer := time.AfterFunc(hotkeyCaptureTimeout, func() {
+		timedOut.Store(true)
+		cancel()
+	})
+
+	id := newRequestID()
+	go func() {
+		chord, ok, err := captureKeyChord(stop)
+		timer.Stop()
+		a.captureMu.Lock()
+		a.captureStop = nil
+		a.captureMu.Unlock()
+		a.resumeHotkeys()
+
+		result := HotkeyCapture{Name: name}
+		switch {
+		case err != nil:
+		case timedOut.Load():
+			err = fmt.Errorf("no key combination was pressed")
+		case !ok || (chord.key == hotkey.KeyEscape && len(chord.mods) == 0):
+			eventHotkeyCaptureCancelled.respond(a.ctx, id, name, nil)
+			return
+		default:
+			result.Spec = formatHotkey(chord)
+			result.RawKey = int(chord.key)
+			for _, m := range chord.mods {
+				result.RawModifiers |= int(m)
+			}
+			if _, err = parseHotkey(result.Spec); err == nil && register && name != "" {
+				if err = a.SetHotkey(name, result.Spec); err == nil {
+					result.Registered = true
+				}
+			}
+		}
+		eventHotkeyCaptured.respond(a.ctx, id, result, err)
+	}()
+	return id, nil
+}
+
+// CancelHotkeyCapture stops a running StartHotkeyCapture
+func (a *App) CancelHotkeyCapture() {
+	a.captureMu.Lock()
+	defer a.captureMu.Unlock()
+	if a.captureStop != nil {
+		a.captureStop()
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+/*
+#cgo LDFLAGS: -framework ApplicationServices -framework CoreFoundation
+#include <ApplicationServices/ApplicationServices.h>
+
+static CFMachPortRef ovCaptureTap;
+static volatile int ovCapturedKey = -1;
+static volatile int ovCaptureStopped;
+static CGEventFlags ovCapturedFlags;
+
+// Modifier-only presses arrive as kCGEventFlagsChanged, so the first key
+// down is always a complete combination
+static CGEventRef ovCaptureCallback(CGEventTapProxy proxy, CGEventType type, CGEventRef event, void *info) {
+	if (type == kCGEventTapDisabledByTimeout || type == kCGEventTapDisabledByUserInput) {
+		CGEventTapEnable(ovCaptureTap, true);
+		return event;
+	}
+	if (ovCapturedKey >= 0) return NULL;
+	ovCapturedKey = (int)CGEventGetIntegerValueField(event, kCGKeyboardEventKeycode);
+	ovCapturedFlags = CGEventGetFlags(event);
+	return NULL;
+}
+
+static void ovResetCapture(void) {
+	ovCapturedKey = -1;
+	ovCaptureStopped = 0;
+}
+
+// ovCaptureKey blocks until a key is pressed or ovStopCapture is called,
+// returning the key code or -1; -2 means the tap could not be created
+static int ovCaptureKey(unsigned long long *flags) {
+	ovCaptureTap = CGEventTapCreate(kCGSessionEventTap, kCGHeadInsertEventTap, kCGEventTapOptionDefault,
+		CGEventMaskBit(kCGEventKeyDown), ovCaptureCallback, NULL);
+	if (!ovCaptureTap) return -2;
+	CFRunLoopSourceRef src = CFMachPortCreateRunLoopSource(kCFAllocatorDefault, ovCaptureTap, 0);
+	CFRunLoopRef loop = CFRunLoopGetCurrent();
+	CFRunLoopAddSource(loop, src, kCFRunLoopCommonModes);
+	CGEventTapEnable(ovCaptureTap, true);
+	while (ovCapturedKey < 0 && !ovCaptureStopped) {
+		CFRunLoopRunInMode(kCFRunLoopDefaultMode, 0.2, false);
+	}
+	CFRunLoopRemoveSource(loop, src, kCFRunLoopCommonModes);
+	CFRelease(src);
+	CFMachPortInvalidate(ovCaptureTap);
+	CFRelease(ovCaptureTap);
+	ovCaptureTap = NULL;
+	*flags = ovCapturedFlags;
+	return ovCapturedKey;
+}
+
+static void ovStopCapture(void) {
+	ovCaptureStopped = 1;
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"runtime"
+
+	"golang.design/x/hotkey"
+)
+
+// captureKeyChord swallows the next key press through a CGEventTap
+func captureKeyChord(stop <-chan struct{}) (hotkeyChord, bool, error) {
+	type result struct {
+		code  int
+		flags uint64
+	}
+	done := make(chan result, 1)
+	C.ovResetCapture()
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		var flags C.ulonglong
+		code := int(C.ovCaptureKey(&flags))
+		done <- result{code, uint64(flags)}
+	}()
+
+	var res result
+	select {
+	case res = <-done:
+	case <-stop:
+		C.ovStopCapture()
+		res = <-done
+	}
+	switch {
+	case res.code == -2:
+		return hotkeyChord{}, false, fmt.Errorf("hotkey capture needs the Accessibility permission")
+	case res.code < 0:
+		return hotkeyChord{}, false, nil
+	}
+
+	chord := hotkeyChord{key: hotkey.Key(res.code)}
+	for _, m := range []struct {
+		flag uint64
+		mod  hotkey.Modifier
+	}{
+		{C.kCGEventFlagMaskControl, hotkey.ModCtrl},
+		{C.kCGEventFlagMaskAlternate, hotkey.ModOption},
+		{C.kCGEventFlagMaskShift, hotkey.ModShift},
+		{C.kCGEventFlagMaskCommand, hotkey.ModCmd},
+	} {
+		if res.flags&m.flag != 0 {
+			chord.mods = append(chord.mods, m.mod)
+		}
+	}
+	return chord, true, nil
+}
@@ -0,0 +1,78 @@
+package main
+
+/*
+#cgo LDFLAGS: -lX11
+#include <X11/Xlib.h>
+#include <X11/Xutil.h>
+#include <poll.h>
+
+// ovNextKeyPress waits up to timeoutMs for a non-modifier key press and
+// returns its unshifted keysym, or 0
+static KeySym ovNextKeyPress(Display *d, unsigned int *state, int timeoutMs) {
+	if (!XPending(d)) {
+		struct pollfd p = {ConnectionNumber(d), POLLIN, 0};
+		if (poll(&p, 1, timeoutMs) <= 0) return 0;
+	}
+	while (XPending(d)) {
+		XEvent ev;
+		XNextEvent(d, &ev);
+		if (ev.type != KeyPress) continue;
+		KeySym sym = XLookupKeysym(&ev.xkey, 0);
+		if (sym == NoSymbol || IsModifierKey(sym)) continue;
+		*state = ev.xkey.state;
+		return sym;
+	}
+	return 0;
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"runtime"
+
+	"golang.design/x/hotkey"
+)
+
+// captureKeyChord grabs the keyboard over its own X connection until the
+// next non-modifier key press. The grab fails if another client holds it.
+func captureKeyChord(stop <-chan struct{}) (hotkeyChord, bool, error) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	d := C.XOpenDisplay(nil)
+	if d == nil {
+		return hotkeyChord{}, false, fmt.Errorf("cannot open X display")
+	}
+	defer C.XCloseDisplay(d)
+
+	root := C.XDefaultRootWindow(d)
+	if C.XGrabKeyboard(d, root, C.False, C.GrabModeAsync, C.GrabModeAsync, C.CurrentTime) != C.GrabSuccess {
+		return hotkeyChord{}, false, fmt.Errorf("keyboard is grabbed by another application")
+	}
+	defer C.XUngrabKeyboard(d, C.CurrentTime)
+
+	for {
+		select {
+		case <-stop:
+			return hotkeyChord{}, false, nil
+		default:
+		}
+		var state C.uint
+		sym := C.ovNextKeyPress(d, &state, 200)
+		if sym == 0 {
+			continue
+		}
+		if sym > 0xffff {
+			return hotkeyChord{}, false, fmt.Errorf("keysym 0x%x can't be bound as a hotkey", uint64(sym))
+		}
+		chord := hotkeyChord{key: hotkey.Key(sym)}
+		// X modifier masks are the hotkey package's modifier values
+		for _, m := range []hotkey.Modifier{hotkey.ModCtrl, hotkey.Mod1, hotkey.ModShift, hotkey.Mod4} {
+			if hotkey.Modifier(state)&m != 0 {
+				chord.mods = append(chord.mods, m)
+			}
+		}
+		return chord, true, nil
+	}
+}
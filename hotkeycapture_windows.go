@@ -0,0 +1,106 @@
+package main
+
+import (
+	"runtime"
+	"syscall"
+	"unsafe"
+
+	"golang.design/x/hotkey"
+)
+
+var procPostQuitMessage = user32.NewProc("PostQuitMessage")
+
+const (
+	whKeyboardLL = 13
+	wmKeyDown    = 0x0100
+	wmSysKeyDown = 0x0104
+)
+
+type kbdllHookStruct struct {
+	VkCode, ScanCode, Flags, Time uint32
+	ExtraInfo                     uintptr
+}
+
+// modifierVK are the virtual keys that only modify; pressing one alone
+// keeps the capture waiting
+var modifierVK = map[uint32]bool{
+	0x10: true, 0x11: true, 0x12: true, 0x14: true, 0x5b: true, 0x5c: true,
+	0xa0: true, 0xa1: true, 0xa2: true, 0xa3: true, 0xa4: true, 0xa5: true,
+}
+
+// captureKeyChord swallows the next non-modifier key press through a
+// WH_KEYBOARD_LL hook and returns it with the modifiers held at the time
+func captureKeyChord(stop <-chan struct{}) (hotkeyChord, bool, error) {
+	type result struct {
+		chord hotkeyChord
+		ok    bool
+		err   error
+	}
+	done := make(chan result, 1)
+
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+
+		var res result
+		cb := syscall.NewCallback(func(code, wparam, lparam uintptr) uintptr {
+			if int32(code) >= 0 && !res.ok && (wparam == wmKeyDown || wparam == wmSysKeyDown) {
+				info := *(**kbdllHookStruct)(unsafe.Pointer(&lparam))
+				if !modifierVK[info.VkCode] {
+					res.chord = hotkeyChord{mods: heldModifiers(), key: hotkey.Key(info.VkCode)}
+					res.ok = true
+					procPostQuitMessage.Call(0)
+					return 1
+				}
+			}
+			r, _, _ := procCallNextHookEx.Call(0, code, wparam, lparam)
+			return r
+		})
+
+		hook, _, err := procSetWindowsHookExW.Call(whKeyboardLL, cb, 0, 0)
+		if hook == 0 {
+			done <- result{err: err}
+			return
+		}
+		tid, _, _ := procGetCurrentThreadId.Call()
+		quit := make(chan struct{})
+		go func() {
+			select {
+			case <-stop:
+				procPostThreadMessageW.Call(tid, wmQuit, 0, 0)
+			case <-quit:
+			}
+		}()
+
+		var msg [48]byte // MSG
+		for {
+			r, _, _ := procGetMessageW.Call(uintptr(unsafe.Pointer(&msg[0])), 0, 0, 0)
+			if int32(r) <= 0 {
+				break
+			}
+		}
+		procUnhookWindowsHookEx.Call(hook)
+		close(quit)
+		done <- res
+	}()
+
+	res := <-done
+	return res.chord, res.ok, res.err
+}
+
+func heldModifiers() []hotkey.Modifier {
+	var mods []hotkey.Modifier
+	if keyDown(vkControl) {
+		mods = append(mods, hotkey.ModCtrl)
+	}
+	if keyDown(vkMenu) {
+		mods = append(mods, hotkey.ModAlt)
+	}
+	if keyDown(vkShift) {
+		mods = append(mods, hotkey.ModShift)
+	}
+	if keyDown(vkLWin) || keyDown(vkRWin) {
+		mods = append(mods, hotkey.ModWin)
+	}
+	return mods
+}
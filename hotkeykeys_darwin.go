@@ -0,0 +1,19 @@
+package main
+
+import "golang.design/x/hotkey"
+
+// platformKeys are the extra named keys RegisterEventHotKey accepts; macOS
+// keyboards stop at F20
+var platformKeys = map[string]hotkey.Key{
+	"f13": 0x69, "f14": 0x6b, "f15": 0x71, "f16": 0x6a,
+	"f17": 0x40, "f18": 0x4f, "f19": 0x50, "f20": 0x5a,
+
+	"num0": 0x52, "num1": 0x53, "num2": 0x54, "num3": 0x55, "num4": 0x56,
+	"num5": 0x57, "num6": 0x58, "num7": 0x59, "num8": 0x5b, "num9": 0x5c,
+	"numdec": 0x41, "nummult": 0x43, "numadd": 0x45, "numdiv": 0x4b,
+	"numsub": 0x4e, "numenter": 0x4c, "numequal": 0x51, "numclear": 0x47,
+}
+
+// mediaKeys is empty: media keys arrive as system-defined events that
+// Carbon hotkeys never see
+var mediaKeys = map[string]hotkey.Key{}
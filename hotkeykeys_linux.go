@@ -0,0 +1,18 @@
+package main
+
+import "golang.design/x/hotkey"
+
+// platformKeys are the extra named keys as X11 keysyms
+var platformKeys = map[string]hotkey.Key{
+	"f13": 0xffca, "f14": 0xffcb, "f15": 0xffcc, "f16": 0xffcd, "f17": 0xffce, "f18": 0xffcf,
+	"f19": 0xffd0, "f20": 0xffd1, "f21": 0xffd2, "f22": 0xffd3, "f23": 0xffd4, "f24": 0xffd5,
+
+	"num0": 0xffb0, "num1": 0xffb1, "num2": 0xffb2, "num3": 0xffb3, "num4": 0xffb4,
+	"num5": 0xffb5, "num6": 0xffb6, "num7": 0xffb7, "num8": 0xffb8, "num9": 0xffb9,
+	"nummult": 0xffaa, "numadd": 0xffab, "numsub": 0xffad, "numdec": 0xffae,
+	"numdiv": 0xffaf, "numenter": 0xff8d, "numequal": 0xffbd,
+}
+
+// mediaKeys is empty: the XF86 media keysyms (0x1008ffxx) don't fit the
+// hotkey package's 16-bit keys
+var mediaKeys = map[string]hotkey.Key{}
@@ -0,0 +1,29 @@
+package main
+
+import "golang.design/x/hotkey"
+
+// platformKeys are the extra named keys RegisterHotKey accepts. Numpad
+// Enter shares VK_RETURN with the main Enter key, so it is not listed.
+var platformKeys = map[string]hotkey.Key{
+	"f13": 0x7c, "f14": 0x7d, "f15": 0x7e, "f16": 0x7f, "f17": 0x80, "f18": 0x81,
+	"f19": 0x82, "f20": 0x83, "f21": 0x84, "f22": 0x85, "f23": 0x86, "f24": 0x87,
+
+	"num0": 0x60, "num1": 0x61, "num2": 0x62, "num3": 0x63, "num4": 0x64,
+	"num5": 0x65, "num6": 0x66, "num7": 0x67, "num8": 0x68, "num9": 0x69,
+	"nummult": 0x6a, "numadd": 0x6b, "numsub": 0x6d, "numdec": 0x6e, "numdiv": 0x6f,
+}
+
+// mediaKeys are the VK_MEDIA_* / VK_VOLUME_* / VK_LAUNCH_* virtual keys
+var mediaKeys = map[string]hotkey.Key{
+	"VolumeMute":         0xad,
+	"VolumeDown":         0xae,
+	"VolumeUp":           0xaf,
+	"MediaNextTrack":     0xb0,
+	"MediaPreviousTrack": 0xb1,
+	"MediaStop":          0xb2,
+	"MediaPlayPause":     0xb3,
+	"LaunchMail":         0xb4,
+	"LaunchMediaSelect":  0xb5,
+	"LaunchApp1":         0xb6,
+	"LaunchApp2":         0xb7,
+}
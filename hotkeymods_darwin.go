@@ -0,0 +1,29 @@
+package main
+
+import "golang.design/x/hotkey"
+
+// modPrimary is the CmdOrCtrl modifier: Cmd on macOS
+const modPrimary = hotkey.ModCmd
+
+// modifierNames maps the lower-cased modifier names accepted in a hotkey
+// spec to this platform's modifiers
+var modifierNames = map[string]hotkey.Modifier{
+	"cmd":     hotkey.ModCmd,
+	"command": hotkey.ModCmd,
+	"super":   hotkey.ModCmd,
+	"meta":    hotkey.ModCmd,
+	"ctrl":    hotkey.ModCtrl,
+	"control": hotkey.ModCtrl,
+	"alt":     hotkey.ModOption,
+	"option":  hotkey.ModOption,
+	"opt":     hotkey.ModOption,
+	"shift":   hotkey.ModShift,
+}
+
+// modifierOrder is how formatHotkey names and orders modifiers
+var modifierOrder = []namedModifier{
+	{"Ctrl", hotkey.ModCtrl},
+	{"Option", hotkey.ModOption},
+	{"Shift", hotkey.ModShift},
+	{"Cmd", hotkey.ModCmd},
+}
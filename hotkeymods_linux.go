@@ -0,0 +1,28 @@
+package main
+
+import "golang.design/x/hotkey"
+
+// modPrimary is the CmdOrCtrl modifier: Ctrl under X11
+const modPrimary = hotkey.ModCtrl
+
+// modifierNames maps the lower-cased modifier names accepted in a hotkey
+// spec to this platform's modifiers; Super is Mod4 in the default keymap
+var modifierNames = map[string]hotkey.Modifier{
+	"ctrl":    hotkey.ModCtrl,
+	"control": hotkey.ModCtrl,
+	"alt":     hotkey.Mod1,
+	"option":  hotkey.Mod1,
+	"shift":   hotkey.ModShift,
+	"super":   hotkey.Mod4,
+	"win":     hotkey.Mod4,
+	"meta":    hotkey.Mod4,
+	"cmd":     hotkey.Mod4,
+}
+
+// modifierOrder is how formatHotkey names and orders modifiers
+var modifierOrder = []namedModifier{
+	{"Ctrl", hotkey.ModCtrl},
+	{"Alt", hotkey.Mod1},
+	{"Shift", hotkey.ModShift},
+	{"Super", hotkey.Mod4},
+}
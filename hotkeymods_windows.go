@@ -0,0 +1,28 @@
+package main
+
+import "golang.design/x/hotkey"
+
+// modPrimary is the CmdOrCtrl modifier: Ctrl on Windows
+const modPrimary = hotkey.ModCtrl
+
+// modifierNames maps the lower-cased modifier names accepted in a hotkey
+// spec to this platform's modifiers
+var modifierNames = map[string]hotkey.Modifier{
+	"ctrl":    hotkey.ModCtrl,
+	"control": hotkey.ModCtrl,
+	"alt":     hotkey.ModAlt,
+	"option":  hotkey.ModAlt,
+	"shift":   hotkey.ModShift,
+	"win":     hotkey.ModWin,
+	"super":   hotkey.ModWin,
+	"meta":    hotkey.ModWin,
+	"cmd":     hotkey.ModWin,
+}
+
+// modifierOrder is how formatHotkey names and orders modifiers
+var modifierOrder = []namedModifier{
+	{"Ctrl", hotkey.ModCtrl},
+	{"Alt", hotkey.ModAlt},
+	{"Shift", hotkey.ModShift},
+	{"Win", hotkey.ModWin},
+}
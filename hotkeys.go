@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.design/x/hotkey"
+)
+
+// hotkeyBinding is a global shortcut and the action it triggers. The
+// underlying hotkey is nil while the binding is not registered.
+type hotkeyBinding struct {
+	name   string
+	spec   string
+	action func()
+	hk     *hotkey.Hotkey
+	// overlayOnly bindings are only registered while the overlay is
+	// visible, for combos such as Cmd+= that other apps need the rest of
+	// the time
+	overlayOnly bool
+	// err is why the last registration attempt failed
+	err error
+}
+
+// register resolves the spec against the current keyboard layout, so
+// calling it again after a layout change picks up the new key codes
+func (b *hotkeyBinding) register() error {
+	chord, err := parseHotkey(b.spec)
+	if err != nil {
+		b.err = err
+		return err
+	}
+	hk := hotkey.New(chord.mods, chord.key)
+	if b.err = hk.Register(); b.err != nil {
+		return b.err
+	}
+	b.hk = hk
+	// Unregister closes the keydown channel, which ends this loop
+	go func() {
+		for range hk.Keydown() {
+			b.action()
+		}
+	}()
+	return nil
+}
+
+func (b *hotkeyBinding) unregister() {
+	if b.hk != nil {
+		_ = b.hk.Unregister()
+		b.hk = nil
+	}
+}
+
+// bindHotkey adds a global shortcut and registers it unless hotkeys are
+// currently paused. A spec in settings overrides the given default.
+func (a *App) bindHotkey(name, spec string, action func()) error {
+	return a.addHotkey(&hotkeyBinding{name: name, spec: a.hotkeySpec(name, spec), action: action})
+}
+
+// bindOverlayHotkey is bindHotkey for a shortcut that only applies while
+// the overlay is showing
+func (a *App) bindOverlayHotkey(name, spec string, action func()) error {
+	return a.addHotkey(&hotkeyBinding{name: name, spec: a.hotkeySpec(name, spec), action: action, overlayOnly: true})
+}
+
+func (a *App) addHotkey(b *hotkeyBinding) error {
+	a.hotkeysMu.Lock()
+	defer a.hotkeysMu.Unlock()
+	a.hotkeys = append(a.hotkeys, b)
+	if !a.activeLocked(b) {
+		return nil
+	}
+	return b.register()
+}
+
+// activeLocked reports whether b should currently be registered
+func (a *App) activeLocked(b *hotkeyBinding) bool {
+	return !a.hotkeysPaused && (!b.overlayOnly || a.overlayHotkeys)
+}
+
+// setOverlayHotkeys registers or releases the overlay-only bindings as the
+// overlay is shown and hidden
+func (a *App) setOverlayHotkeys(active bool) {
+	a.hotkeysMu.Lock()
+	defer a.hotkeysMu.Unlock()
+	a.overlayHotkeys = active
+	for _, b := range a.hotkeys {
+		if !b.overlayOnly {
+			continue
+		}
+		if !a.activeLocked(b) {
+			b.unregister()
+		} else if b.hk == nil {
+			_ = b.register()
+		}
+	}
+}
+
+// SetHotkeysEnabled unregisters every global hotkey, or registers them
+// again, e.g. while gaming or when another app needs the same combo
+func (a *App) SetHotkeysEnabled(enabled bool) error {
+	a.hotkeysMu.Lock()
+	var failed []string
+	a.hotkeysPaused = !enabled
+	for _, b := range a.hotkeys {
+		if !a.activeLocked(b) {
+			b.unregister()
+			continue
+		}
+		if b.hk == nil {
+			if err := b.register(); err != nil {
+				failed = append(failed, b.name)
+			}
+		}
+	}
+	a.hotkeysMu.Unlock()
+
+	a.syncHotkeysMenu(enabled)
+	eventHotkeysEnabled.emit(a.ctx, enabled)
+	if len(failed) > 0 {
+		return fmt.Errorf("could not register hotkeys: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// HotkeysEnabled reports whether global hotkeys are currently active
+func (a *App) HotkeysEnabled() bool {
+	a.hotkeysMu.Lock()
+	defer a.hotkeysMu.Unlock()
+	return !a.hotkeysPaused
+}
+
+// unregisterHotkeys releases every global hotkey on shutdown or while a
+// new shortcut is being captured
+func (a *App) unregisterHotkeys() {
+	a.hotkeysMu.Lock()
+	defer a.hotkeysMu.Unlock()
+	for _, b := range a.hotkeys {
+		b.unregister()
+	}
+}
+
+// SetHotkey changes the shortcut of a bound hotkey, e.g. "Ctrl+Alt+Space".
+// The new spec is validated against the current layout before it is saved.
+func (a *App) SetHotkey(name, spec string) error {
+	if _, err := parseHotkey(spec); err != nil {
+		return err
+	}
+
+	a.hotkeysMu.Lock()
+	var binding *hotkeyBinding
+	for _, b := range a.hotkeys {
+		if b.name == name {
+			binding = b
+		}
+	}
+	if binding == nil {
+		a.hotkeysMu.Unlock()
+		return fmt.Errorf("unknown hotkey %q", name)
+	}
+	old := binding.spec
+	binding.spec = spec
+	if a.activeLocked(binding) {
+		binding.unregister()
+		if err := binding.register(); err != nil {
+			// Keep the previous shortcut working
+			binding.spec = old
+			_ = binding.register()
+			a.hotkeysMu.Unlock()
+			return err
+		}
+	}
+	a.hotkeysMu.Unlock()
+
+	if err := a.updateSettings(func(s *Settings) {
+		if s.Hotkeys == nil {
+			s.Hotkeys = map[string]string{}
+		}
+		s.Hotkeys[name] = spec
+	}); err != nil {
+		return err
+	}
+	if name == "show-overlay" {
+		a.followOverlayVariants(spec)
+	}
+	return nil
+}
+
+// resumeHotkeys registers the bindings again after unregisterHotkeys,
+// unless hotkeys are paused
+func (a *App) resumeHotkeys() {
+	a.hotkeysMu.Lock()
+	defer a.hotkeysMu.Unlock()
+	for _, b := range a.hotkeys {
+		if b.hk == nil && a.activeLocked(b) {
+			_ = b.register()
+		}
+	}
+}
+
+// reregisterHotkeys registers every active binding again so character
+// specs resolve against the current keyboard layout
+func (a *App) reregisterHotkeys() {
+	a.hotkeysMu.Lock()
+	defer a.hotkeysMu.Unlock()
+	for _, b := range a.hotkeys {
+		b.unregister()
+		if a.activeLocked(b) {
+			_ = b.register()
+		}
+	}
+}
+
+// watchKeyboardLayout polls the active keyboard layout. None of the
+// platforms tell the hotkey package when the layout switches, and
+// registered key codes would keep pointing at the old layout's keys.
+func (a *App) watchKeyboardLayout(ctx context.Context) {
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	last, _ := keyboardLayout()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		layout, err := keyboardLayout()
+		if err != nil || layout == last {
+			continue
+		}
+		last = layout
+		a.reregisterHotkeys()
+		eventKeyboardLayout.emit(ctx, layout)
+	}
+}
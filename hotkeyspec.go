@@ -0,0 +1,241 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.design/x/hotkey"
+)
+
+// defaultHotkeys are the shortcuts used when settings.json has no override.
+// "CmdOrCtrl" is Cmd on macOS and Ctrl elsewhere; "Alt" is Option on macOS.
+// The show-overlay variants derive from show-overlay; see overlayVariants.
+var defaultHotkeys = map[string]string{
+	"show-overlay":      "CmdOrCtrl+G",
+	"annotation":        "CmdOrCtrl+Shift+A",
+	"paste-last":        "CmdOrCtrl+Alt+V",
+	"screenshot-prompt": "CmdOrCtrl+Shift+E",
+	"translate":         "CmdOrCtrl+Alt+T",
+	"zoom-in":           "CmdOrCtrl+=",
+	"zoom-out":          "CmdOrCtrl+-",
+	"zoom-reset":        "CmdOrCtrl+0",
+}
+
+// namedKeys are the non-character keys every platform accepts in a hotkey
+// spec. Their codes are fixed regardless of keyboard layout; platformKeys
+// and mediaKeys add F13 and up, the numpad and media keys where available.
+var namedKeys = map[string]hotkey.Key{
+	"space":  hotkey.KeySpace,
+	"return": hotkey.KeyReturn,
+	"enter":  hotkey.KeyReturn,
+	"escape": hotkey.KeyEscape,
+	"esc":    hotkey.KeyEscape,
+	"delete": hotkey.KeyDelete,
+	"tab":    hotkey.KeyTab,
+	"left":   hotkey.KeyLeft,
+	"right":  hotkey.KeyRight,
+	"up":     hotkey.KeyUp,
+	"down":   hotkey.KeyDown,
+	"f1":     hotkey.KeyF1,
+	"f2":     hotkey.KeyF2,
+	"f3":     hotkey.KeyF3,
+	"f4":     hotkey.KeyF4,
+	"f5":     hotkey.KeyF5,
+	"f6":     hotkey.KeyF6,
+	"f7":     hotkey.KeyF7,
+	"f8":     hotkey.KeyF8,
+	"f9":     hotkey.KeyF9,
+	"f10":    hotkey.KeyF10,
+	"f11":    hotkey.KeyF11,
+	"f12":    hotkey.KeyF12,
+}
+
+// hotkeyChord is a spec resolved to the codes the OS registers
+type hotkeyChord struct {
+	mods []hotkey.Modifier
+	key  hotkey.Key
+}
+
+// parseHotkey resolves a spec such as "CmdOrCtrl+Shift+A" against the
+// active keyboard layout. Characters are mapped to whichever key produces
+// them, so "Cmd+Z" follows the printed Z on AZERTY or QWERTZ keyboards.
+func parseHotkey(spec string) (hotkeyChord, error) {
+	parts := strings.Split(spec, "+")
+	// A trailing empty part means the key itself is "+"
+	if len(parts) > 1 && parts[len(parts)-1] == "" {
+		parts = append(parts[:len(parts)-2], "+")
+	}
+	keyName := strings.TrimSpace(parts[len(parts)-1])
+	if keyName == "" {
+		return hotkeyChord{}, fmt.Errorf("hotkey %q has no key", spec)
+	}
+
+	var chord hotkeyChord
+	seen := map[hotkey.Modifier]bool{}
+	addMod := func(m hotkey.Modifier) {
+		if !seen[m] {
+			seen[m] = true
+			chord.mods = append(chord.mods, m)
+		}
+	}
+	for _, p := range parts[:len(parts)-1] {
+		name := strings.ToLower(strings.TrimSpace(p))
+		switch name {
+		case "cmdorctrl", "commandorcontrol", "mod":
+			addMod(modPrimary)
+			continue
+		}
+		m, ok := modifierNames[name]
+		if !ok {
+			return hotkeyChord{}, fmt.Errorf("unknown modifier %q in hotkey %q", p, spec)
+		}
+		addMod(m)
+	}
+
+	if key, dedicated, ok := lookupNamedKey(strings.ToLower(keyName)); ok {
+		// Keys that type text or navigate would be swallowed system-wide
+		if len(chord.mods) == 0 && !dedicated {
+			return hotkeyChord{}, fmt.Errorf("hotkey %q needs a modifier", spec)
+		}
+		chord.key = key
+		return chord, nil
+	}
+	if len(chord.mods) == 0 {
+		return hotkeyChord{}, fmt.Errorf("hotkey %q needs a modifier", spec)
+	}
+	r, size := utf8.DecodeRuneInString(keyName)
+	if size != len(keyName) {
+		return hotkeyChord{}, fmt.Errorf("unknown key %q in hotkey %q", keyName, spec)
+	}
+	// Letters are written upper case in specs; Shift is explicit
+	key, shift, ok := keyForChar(unicode.ToLower(r))
+	if !ok {
+		return hotkeyChord{}, fmt.Errorf("no key produces %q on the current keyboard layout", keyName)
+	}
+	if shift {
+		addMod(hotkey.ModShift)
+	}
+	chord.key = key
+	return chord, nil
+}
+
+type namedModifier struct {
+	name string
+	mod  hotkey.Modifier
+}
+
+// formatHotkey is the inverse of parseHotkey, naming the key as the
+// current layout prints it
+func formatHotkey(chord hotkeyChord) string {
+	var parts []string
+	for _, m := range modifierOrder {
+		for _, have := range chord.mods {
+			if have == m.mod {
+				parts = append(parts, m.name)
+				break
+			}
+		}
+	}
+	return strings.Join(append(parts, keyName(chord.key)), "+")
+}
+
+func keyName(key hotkey.Key) string {
+	var names []string
+	for _, keys := range []map[string]hotkey.Key{namedKeys, platformKeys, mediaKeys} {
+		for name, k := range keys {
+			if k == key {
+				names = append(names, name)
+			}
+		}
+	}
+	if len(names) > 0 {
+		// Aliases share a code; pick one deterministically
+		sort.Strings(names)
+		return strings.ToUpper(names[0][:1]) + names[0][1:]
+	}
+	if r, ok := charForKey(key); ok {
+		return string(unicode.ToUpper(r))
+	}
+	return fmt.Sprintf("0x%x", int(key))
+}
+
+// lookupNamedKey resolves a lower-cased key name. Dedicated keys, F13 and
+// up and media keys, may be bound without a modifier since nothing types
+// with them; they are what launcher setups usually dedicate to shortcuts.
+func lookupNamedKey(name string) (hotkey.Key, bool, bool) {
+	if key, ok := namedKeys[name]; ok {
+		return key, false, true
+	}
+	for media, key := range mediaKeys {
+		if strings.EqualFold(media, name) {
+			return key, true, true
+		}
+	}
+	if key, ok := platformKeys[name]; ok {
+		return key, !strings.HasPrefix(name, "num"), true
+	}
+	return 0, false, false
+}
+
+// HotkeyCapabilities describes what hotkey specs this platform can
+// register, for the settings UI
+type HotkeyCapabilities struct {
+	Platform  string   `json:"platform"`
+	Modifiers []string `json:"modifiers"`
+	// Keys are the named keys; any character on the layout is accepted too
+	Keys []string `json:"keys"`
+	// FunctionKeys is the highest Fn key that can be bound
+	FunctionKeys int  `json:"functionKeys"`
+	Numpad       bool `json:"numpad"`
+	MediaKeys    bool `json:"mediaKeys"`
+}
+
+// GetHotkeyCapabilities reports the modifiers and keys accepted on this
+// platform
+func (a *App) GetHotkeyCapabilities() HotkeyCapabilities {
+	caps := HotkeyCapabilities{
+		Platform:  runtime.GOOS,
+		Modifiers: []string{"CmdOrCtrl"},
+		MediaKeys: len(mediaKeys) > 0,
+	}
+	for name := range modifierNames {
+		caps.Modifiers = append(caps.Modifiers, name)
+	}
+	for _, keys := range []map[string]hotkey.Key{namedKeys, platformKeys, mediaKeys} {
+		for name := range keys {
+			caps.Keys = append(caps.Keys, name)
+			if strings.HasPrefix(name, "num") {
+				caps.Numpad = true
+			}
+			if rest, ok := strings.CutPrefix(name, "f"); ok {
+				if n, err := strconv.Atoi(rest); err == nil && n > caps.FunctionKeys {
+					caps.FunctionKeys = n
+				}
+			}
+		}
+	}
+	sort.Strings(caps.Modifiers[1:])
+	sort.Strings(caps.Keys)
+	return caps
+}
+
+// ValidateHotkey reports why spec can't be registered on this platform and
+// layout, or nil if it can
+func (a *App) ValidateHotkey(spec string) error {
+	_, err := parseHotkey(spec)
+	return err
+}
+
+// hotkeySpec returns the configured spec for a binding, falling back to
+// fallback when settings has no override
+func (a *App) hotkeySpec(name, fallback string) string {
+	if spec := a.GetSettings().Hotkeys[name]; spec != "" {
+		return spec
+	}
+	return fallback
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"testing"
+
+	"golang.design/x/hotkey"
+)
+
+// charChord is the chord a character key resolves to on this layout,
+// including the Shift it may need
+func charChord(t *testing.T, r rune, mods ...hotkey.Modifier) hotkeyChord {
+	t.Helper()
+	key, shift, ok := keyForChar(r)
+	if !ok {
+		t.Skipf("no key produces %q on this layout", r)
+	}
+	if shift {
+		mods = append(mods, hotkey.ModShift)
+	}
+	return hotkeyChord{mods: mods, key: key}
+}
+
+func sameChord(a, b hotkeyChord) bool {
+	if a.key != b.key || len(a.mods) != len(b.mods) {
+		return false
+	}
+	for _, m := range a.mods {
+		found := false
+		for _, n := range b.mods {
+			found = found || m == n
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func TestParseHotkey(t *testing.T) {
+	ctrl, alt, shift := modifierNames["ctrl"], modifierNames["alt"], hotkey.ModShift
+	tests := []struct {
+		spec string
+		mods []hotkey.Modifier
+		// char is the character key; key is used when it is zero
+		char rune
+		key  hotkey.Key
+	}{
+		{spec: "CmdOrCtrl+Shift+A", mods: []hotkey.Modifier{modPrimary, shift}, char: 'a'},
+		{spec: "CommandOrControl+A", mods: []hotkey.Modifier{modPrimary}, char: 'a'},
+		{spec: "Mod+A", mods: []hotkey.Modifier{modPrimary}, char: 'a'},
+		{spec: "cmdorctrl+a", mods: []hotkey.Modifier{modPrimary}, char: 'a'},
+		{spec: " Ctrl + A ", mods: []hotkey.Modifier{ctrl}, char: 'a'},
+		{spec: "Control+A", mods: []hotkey.Modifier{ctrl}, char: 'a'},
+		{spec: "Option+A", mods: []hotkey.Modifier{alt}, char: 'a'},
+		{spec: "Alt+Shift+shift+A", mods: []hotkey.Modifier{alt, shift}, char: 'a'},
+		{spec: "Alt++", mods: []hotkey.Modifier{alt}, char: '+'},
+		{spec: "Alt+Esc", mods: []hotkey.Modifier{alt}, key: hotkey.KeyEscape},
+		{spec: "Alt+Enter", mods: []hotkey.Modifier{alt}, key: hotkey.KeyReturn},
+		{spec: "Alt+F5", mods: []hotkey.Modifier{alt}, key: hotkey.KeyF5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			want := hotkeyChord{mods: tt.mods, key: tt.key}
+			if tt.char != 0 {
+				want = charChord(t, tt.char, tt.mods...)
+			}
+			got, err := parseHotkey(tt.spec)
+			if err != nil {
+				t.Fatalf("parseHotkey(%q): %v", tt.spec, err)
+			}
+			if !sameChord(got, want) {
+				t.Errorf("parseHotkey(%q) = %+v, want %+v", tt.spec, got, want)
+			}
+		})
+	}
+}
+
+func TestParseHotkeyErrors(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"Alt+",
+		"A",
+		"+",
+		"F5",
+		"Space",
+		"Hyper+A",
+		"Alt+AB",
+		"Alt+Shift",
+	} {
+		if chord, err := parseHotkey(spec); err == nil {
+			t.Errorf("parseHotkey(%q) = %+v, want an error", spec, chord)
+		}
+	}
+}
+
+func TestFormatHotkeyRoundTrip(t *testing.T) {
+	for _, spec := range []string{"CmdOrCtrl+Shift+A", "Alt+Space", "CmdOrCtrl+Alt+V", "Alt+F12", "Shift+Alt+Z"} {
+		chord, err := parseHotkey(spec)
+		if err != nil {
+			t.Fatalf("parseHotkey(%q): %v", spec, err)
+		}
+		formatted := formatHotkey(chord)
+		again, err := parseHotkey(formatted)
+		if err != nil {
+			t.Fatalf("parseHotkey(formatHotkey(%q) = %q): %v", spec, formatted, err)
+		}
+		if !sameChord(again, chord) {
+			t.Errorf("%q formats as %q, which parses to %+v, want %+v", spec, formatted, again, chord)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// newID returns a random 16 character hex identifier
+func newID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
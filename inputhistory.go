@@ -0,0 +1,62 @@
+package main
+
+// pastInputs returns submitted inputs oldest first, with immediate repeats
+// collapsed like a shell history
+func (a *App) pastInputs() []string {
+	if a.history == nil {
+		return nil
+	}
+	var inputs []string
+	for _, r := range a.history.list(func(r HistoryRecord) bool { return r.Kind == "input" }) {
+		if n := len(inputs); n == 0 || inputs[n-1] != r.Text {
+			inputs = append(inputs, r.Text)
+		}
+	}
+	return inputs
+}
+
+// GetPreviousInput steps back through past inputs for the Up arrow. The
+// first step remembers draft so GetNextInput can bring it back.
+func (a *App) GetPreviousInput(draft string) string {
+	inputs := a.pastInputs()
+	a.inputMu.Lock()
+	defer a.inputMu.Unlock()
+
+	if a.inputCursor < 0 || a.inputCursor > len(inputs) {
+		a.inputCursor = len(inputs)
+		a.inputDraft = draft
+	}
+	if len(inputs) == 0 {
+		return draft
+	}
+	if a.inputCursor > 0 {
+		a.inputCursor--
+	}
+	return inputs[a.inputCursor]
+}
+
+// GetNextInput steps forward for the Down arrow, returning the saved
+// draft after the newest input
+func (a *App) GetNextInput(draft string) string {
+	inputs := a.pastInputs()
+	a.inputMu.Lock()
+	defer a.inputMu.Unlock()
+
+	if a.inputCursor < 0 {
+		return draft
+	}
+	a.inputCursor++
+	if a.inputCursor >= len(inputs) {
+		a.inputCursor = -1
+		return a.inputDraft
+	}
+	return inputs[a.inputCursor]
+}
+
+// resetInputCursor ends history navigation once an input is submitted
+func (a *App) resetInputCursor() {
+	a.inputMu.Lock()
+	a.inputCursor = -1
+	a.inputDraft = ""
+	a.inputMu.Unlock()
+}
@@ -0,0 +1,111 @@
+package main
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Carbon -framework Cocoa
+#import <Carbon/Carbon.h>
+#import <Cocoa/Cocoa.h>
+
+// Text Input Source calls must run on the main thread on recent macOS
+static void ovOnMain(void (^block)(void)) {
+	if ([NSThread isMainThread]) block();
+	else dispatch_sync(dispatch_get_main_queue(), block);
+}
+
+static void ovKeyboardLayoutID(char *buf, int n) {
+	buf[0] = 0;
+	ovOnMain(^{
+		TISInputSourceRef src = TISCopyCurrentKeyboardLayoutInputSource();
+		if (!src) return;
+		CFStringRef id = TISGetInputSourceProperty(src, kTISPropertyInputSourceID);
+		if (id) CFStringGetCString(id, buf, n, kCFStringEncodingUTF8);
+		CFRelease(src);
+	});
+}
+
+// ovKeyCodeForChar scans the virtual key codes of the current layout for
+// the one typing ch, first unshifted and then with Shift. Returns -1 when
+// no key produces it.
+static int ovKeyCodeForChar(UniChar ch, int *shift) {
+	__block int found = -1;
+	ovOnMain(^{
+		TISInputSourceRef src = TISCopyCurrentKeyboardLayoutInputSource();
+		if (!src) return;
+		CFDataRef data = TISGetInputSourceProperty(src, kTISPropertyUnicodeKeyLayoutData);
+		if (!data) { CFRelease(src); return; }
+		const UCKeyboardLayout *layout = (const UCKeyboardLayout *)CFDataGetBytePtr(data);
+		UInt32 states[2] = {0, (shiftKey >> 8) & 0xff};
+		for (int s = 0; s < 2 && found < 0; s++) {
+			for (UInt16 code = 0; code < 128; code++) {
+				UInt32 dead = 0;
+				UniChar out[4];
+				UniCharCount len = 0;
+				OSStatus err = UCKeyTranslate(layout, code, kUCKeyActionDisplay, states[s], LMGetKbdType(),
+					kUCKeyTranslateNoDeadKeysBit, &dead, 4, &len, out);
+				if (err == noErr && len == 1 && out[0] == ch) {
+					found = code;
+					*shift = s;
+					break;
+				}
+			}
+		}
+		CFRelease(src);
+	});
+	return found;
+}
+
+// ovCharForKeyCode types code unshifted on the current layout
+static UniChar ovCharForKeyCode(UInt16 code) {
+	__block UniChar ch = 0;
+	ovOnMain(^{
+		TISInputSourceRef src = TISCopyCurrentKeyboardLayoutInputSource();
+		if (!src) return;
+		CFDataRef data = TISGetInputSourceProperty(src, kTISPropertyUnicodeKeyLayoutData);
+		if (data) {
+			const UCKeyboardLayout *layout = (const UCKeyboardLayout *)CFDataGetBytePtr(data);
+			UInt32 dead = 0;
+			UniChar out[4];
+			UniCharCount len = 0;
+			if (UCKeyTranslate(layout, code, kUCKeyActionDisplay, 0, LMGetKbdType(),
+					kUCKeyTranslateNoDeadKeysBit, &dead, 4, &len, out) == noErr && len == 1) {
+				ch = out[0];
+			}
+		}
+		CFRelease(src);
+	});
+	return ch;
+}
+*/
+import "C"
+
+import "golang.design/x/hotkey"
+
+// keyboardLayout returns the input source ID, e.g.
+// "com.apple.keylayout.German"
+func keyboardLayout() (string, error) {
+	var buf [256]C.char
+	C.ovKeyboardLayoutID(&buf[0], C.int(len(buf)))
+	return C.GoString(&buf[0]), nil
+}
+
+// keyForChar maps r to the virtual key code that types it on the current
+// layout. Hotkey key codes are physical ANSI positions, so on AZERTY "A"
+// lives where QWERTY has Q.
+func keyForChar(r rune) (hotkey.Key, bool, bool) {
+	if r > 0xffff {
+		return 0, false, false
+	}
+	var shift C.int
+	code := int(C.ovKeyCodeForChar(C.UniChar(r), &shift))
+	if code < 0 {
+		return 0, false, false
+	}
+	return hotkey.Key(code), shift != 0, true
+}
+
+// charForKey returns the character code types unshifted on the current
+// layout
+func charForKey(key hotkey.Key) (rune, bool) {
+	r := rune(C.ovCharForKeyCode(C.UInt16(key)))
+	return r, r > 0x20
+}
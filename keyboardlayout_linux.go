@@ -0,0 +1,43 @@
+package main
+
+import (
+	"os/exec"
+	"strings"
+
+	"golang.design/x/hotkey"
+)
+
+// keyboardLayout reads the XKB layout and variant. X11 grabs take keysyms
+// and the server maps them to keycodes with the current keymap, so
+// re-registering after a change is all that layout awareness needs here.
+func keyboardLayout() (string, error) {
+	out, err := exec.Command("setxkbmap", "-query").Output()
+	if err != nil {
+		return "", err
+	}
+	var layout []string
+	for _, line := range strings.Split(string(out), "\n") {
+		k, v, ok := strings.Cut(line, ":")
+		if ok && (k == "layout" || k == "variant") {
+			layout = append(layout, strings.TrimSpace(v))
+		}
+	}
+	return strings.Join(layout, "/"), nil
+}
+
+// keyForChar maps r to its keysym. Latin-1 keysyms equal the code point;
+// the Unicode keysym range doesn't fit the hotkey package's 16-bit keys.
+func keyForChar(r rune) (hotkey.Key, bool, bool) {
+	if r < 0x20 || (r >= 0x7f && r < 0xa0) || r > 0xff {
+		return 0, false, false
+	}
+	return hotkey.Key(r), false, true
+}
+
+// charForKey reverses keyForChar for Latin-1 keysyms
+func charForKey(key hotkey.Key) (rune, bool) {
+	if key < 0x20 || (key >= 0x7f && key < 0xa0) || key > 0xff {
+		return 0, false
+	}
+	return rune(key), true
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+
+	"golang.design/x/hotkey"
+)
+
+var (
+	procGetKeyboardLayout = user32.NewProc("GetKeyboardLayout")
+	procVkKeyScanExW      = user32.NewProc("VkKeyScanExW")
+)
+
+// activeKeyboardLayout returns the layout of the foreground thread; Windows
+// tracks layouts per thread, and that is the one the user is typing with
+func activeKeyboardLayout() uintptr {
+	fg, _, _ := procGetForegroundWindow.Call()
+	tid, _, _ := procGetWindowThreadProcessId.Call(fg, 0)
+	hkl, _, _ := procGetKeyboardLayout.Call(tid)
+	return hkl
+}
+
+func keyboardLayout() (string, error) {
+	return fmt.Sprintf("%x", activeKeyboardLayout()), nil
+}
+
+// keyForChar asks the layout which virtual key types r. The high byte of
+// VkKeyScanEx is the shift state; Ctrl or Alt combinations (AltGr) are
+// rejected since they would clash with the hotkey's own modifiers.
+func keyForChar(r rune) (hotkey.Key, bool, bool) {
+	if r > 0xffff {
+		return 0, false, false
+	}
+	res, _, _ := procVkKeyScanExW.Call(uintptr(r), activeKeyboardLayout())
+	scan := int16(res)
+	if scan == -1 {
+		return 0, false, false
+	}
+	state := byte(scan >> 8)
+	if state&^1 != 0 {
+		return 0, false, false
+	}
+	return hotkey.Key(scan & 0xff), state&1 != 0, true
+}
+
+var procMapVirtualKeyExW = user32.NewProc("MapVirtualKeyExW")
+
+const mapvkVKToChar = 2
+
+// charForKey returns the unshifted character key types on the current
+// layout. The high bit marks dead keys, which are reported as is.
+func charForKey(key hotkey.Key) (rune, bool) {
+	ch, _, _ := procMapVirtualKeyExW.Call(uintptr(key), mapvkVKToChar, activeKeyboardLayout())
+	r := rune(ch & 0x7fffffff)
+	return r, r != 0
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"runtime"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// defaultKeymap holds the overlay's own shortcuts by action. Unlike
+// hotkeys they only work while the overlay has focus, so plain keys are
+// fine. Settings.Keymap overrides them; an empty spec turns one off.
+var defaultKeymap = map[string]string{
+	"submit":         "Enter",
+	"close":          "Escape",
+	"new-session":    "CmdOrCtrl+N",
+	"toggle-pin":     "CmdOrCtrl+Shift+P",
+	"switch-mode":    "CmdOrCtrl+M",
+	"print":          "CmdOrCtrl+P",
+	"previous-input": "Up",
+	"next-input":     "Down",
+	"cancel-request": "CmdOrCtrl+.",
+}
+
+// keymapKeys maps the key names a spec may use to the KeyboardEvent.key
+// value the webview reports
+var keymapKeys = map[string]string{
+	"enter": "Enter", "return": "Enter", "escape": "Escape", "esc": "Escape",
+	"tab": "Tab", "space": " ", "backspace": "Backspace", "delete": "Delete",
+	"up": "ArrowUp", "down": "ArrowDown", "left": "ArrowLeft", "right": "ArrowRight",
+	"home": "Home", "end": "End", "pageup": "PageUp", "pagedown": "PageDown",
+}
+
+// KeyBinding is an overlay shortcut resolved for the frontend, which
+// compares Key with KeyboardEvent.key, ignoring case, and the modifier
+// flags with the event's
+type KeyBinding struct {
+	Action string `json:"action"`
+	// Spec is the shortcut as shown to the user, e.g. "Cmd+Shift+P"
+	Spec  string `json:"spec"`
+	Key   string `json:"key"`
+	Ctrl  bool   `json:"ctrl"`
+	Alt   bool   `json:"alt"`
+	Shift bool   `json:"shift"`
+	Meta  bool   `json:"meta"`
+}
+
+// parseKeyBinding resolves spec, such as "CmdOrCtrl+Shift+P", for action
+func parseKeyBinding(action, spec string) (KeyBinding, error) {
+	b := KeyBinding{Action: action}
+	parts := strings.Split(spec, "+")
+	// "CmdOrCtrl++" binds the plus key
+	if strings.HasSuffix(spec, "++") {
+		parts = append(parts[:len(parts)-2], "+")
+	}
+	key := strings.TrimSpace(parts[len(parts)-1])
+	for _, p := range parts[:len(parts)-1] {
+		switch strings.ToLower(strings.TrimSpace(p)) {
+		case "ctrl", "control":
+			b.Ctrl = true
+		case "alt", "option", "opt":
+			b.Alt = true
+		case "shift":
+			b.Shift = true
+		case "cmd", "command", "super", "win", "meta":
+			b.Meta = true
+		case "cmdorctrl", "commandorcontrol", "mod":
+			if runtime.GOOS == "darwin" {
+				b.Meta = true
+			} else {
+				b.Ctrl = true
+			}
+		default:
+			return KeyBinding{}, fmt.Errorf("unknown modifier %q in %q", p, spec)
+		}
+	}
+	switch {
+	case keymapKeys[strings.ToLower(key)] != "":
+		b.Key = keymapKeys[strings.ToLower(key)]
+	case len(key) >= 2 && (key[0] == 'f' || key[0] == 'F') && strings.Trim(key[1:], "0123456789") == "":
+		b.Key = strings.ToUpper(key)
+	case utf8.RuneCountInString(key) == 1:
+		b.Key = strings.ToLower(key)
+	default:
+		return KeyBinding{}, fmt.Errorf("unknown key %q in %q", key, spec)
+	}
+
+	var names []string
+	for _, m := range []struct {
+		on        bool
+		mac, name string
+	}{{b.Ctrl, "Ctrl", "Ctrl"}, {b.Alt, "Option", "Alt"}, {b.Shift, "Shift", "Shift"}, {b.Meta, "Cmd", "Win"}} {
+		if !m.on {
+			continue
+		}
+		if runtime.GOOS == "darwin" {
+			names = append(names, m.mac)
+		} else {
+			names = append(names, m.name)
+		}
+	}
+	label := strings.TrimPrefix(b.Key, "Arrow")
+	if b.Key == " " {
+		label = "Space"
+	}
+	b.Spec = strings.Join(append(names, strings.ToUpper(label[:1])+label[1:]), "+")
+	return b, nil
+}
+
+// keymap resolves defaultKeymap with overrides applied, in action order.
+// Invalid overrides fall back to the default.
+func keymap(overrides map[string]string) []KeyBinding {
+	bindings := []KeyBinding{}
+	for _, action := range slices.Sorted(maps.Keys(defaultKeymap)) {
+		spec, ok := overrides[action]
+		if !ok {
+			spec = defaultKeymap[action]
+		}
+		if spec == "" {
+			continue
+		}
+		b, err := parseKeyBinding(action, spec)
+		if err != nil {
+			println("Error in keymap:", err.Error())
+			if b, err = parseKeyBinding(action, defaultKeymap[action]); err != nil {
+				continue
+			}
+		}
+		bindings = append(bindings, b)
+	}
+	return bindings
+}
+
+// GetKeymap returns the overlay's shortcuts with the user's overrides
+func (a *App) GetKeymap() []KeyBinding {
+	return keymap(a.GetSettings().Keymap)
+}
+
+// SetKeymap saves overrides by action, e.g. {"toggle-pin": "Ctrl+T"},
+// rejecting unknown actions, invalid specs and two actions on one
+// shortcut. The resolved keymap is sent in a keymap-updated event.
+func (a *App) SetKeymap(overrides map[string]string) error {
+	for action, spec := range overrides {
+		if _, ok := defaultKeymap[action]; !ok {
+			return fmt.Errorf("unknown action %q", action)
+		}
+		if spec == "" {
+			continue
+		}
+		if _, err := parseKeyBinding(action, spec); err != nil {
+			return err
+		}
+	}
+	bindings := keymap(overrides)
+	seen := map[KeyBinding]string{}
+	for _, b := range bindings {
+		chord := KeyBinding{Key: strings.ToLower(b.Key), Ctrl: b.Ctrl, Alt: b.Alt, Shift: b.Shift, Meta: b.Meta}
+		if other, ok := seen[chord]; ok {
+			return fmt.Errorf("%s is used by both %s and %s", b.Spec, other, b.Action)
+		}
+		seen[chord] = b.Action
+	}
+	if err := a.updateSettings(func(s *Settings) { s.Keymap = overrides }); err != nil {
+		return err
+	}
+	eventKeymapUpdated.emit(a.ctx, bindings)
+	return nil
+}
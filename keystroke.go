@@ -0,0 +1,11 @@
+package main
+
+// sendCopy injects the platform copy shortcut into the frontmost app
+func sendCopy() error {
+	return injectShortcut('c')
+}
+
+// sendPaste injects the platform paste shortcut into the frontmost app
+func sendPaste() error {
+	return injectShortcut('v')
+}
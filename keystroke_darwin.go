@@ -0,0 +1,13 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// injectShortcut sends Cmd+key through System Events, which requires the
+// accessibility permission
+func injectShortcut(key byte) error {
+	script := fmt.Sprintf(`tell application "System Events" to keystroke "%c" using command down`, key)
+	return exec.Command("osascript", "-e", script).Run()
+}
@@ -0,0 +1,8 @@
+package main
+
+import "os/exec"
+
+// injectShortcut sends Ctrl+key through xdotool
+func injectShortcut(key byte) error {
+	return exec.Command("xdotool", "key", "--clearmodifiers", "ctrl+"+string(key)).Run()
+}
@@ -0,0 +1,18 @@
+package main
+
+var procKeybdEvent = user32.NewProc("keybd_event")
+
+const (
+	vkControl      = 0x11
+	keyEventFKeyUp = 0x0002
+)
+
+// injectShortcut sends Ctrl+key to the foreground window
+func injectShortcut(key byte) error {
+	vk := uintptr(key &^ 0x20) // virtual-key codes for letters are upper case ASCII
+	procKeybdEvent.Call(vkControl, 0, 0, 0)
+	procKeybdEvent.Call(vk, 0, 0, 0)
+	procKeybdEvent.Call(vk, 0, keyEventFKeyUp, 0)
+	procKeybdEvent.Call(vkControl, 0, keyEventFKeyUp, 0)
+	return nil
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const linkLogFile = "link-opens.jsonl"
+
+// Values of LinkSettings.Confirm
+const (
+	confirmLinksAlways  = "always"
+	confirmLinksUnknown = "unknown"
+	confirmLinksNever   = "never"
+)
+
+// LinkSettings controls OpenURL
+type LinkSettings struct {
+	// Confirm is "always", "unknown" for hosts outside Allowlist, or
+	// "never"
+	Confirm string `json:"confirm"`
+	// Allowlist holds host patterns such as "github.com" or
+	// "*.example.com"
+	Allowlist []string `json:"allowlist"`
+}
+
+// linkOpen is one line of the link log
+type linkOpen struct {
+	URL    string    `json:"url"`
+	Opened bool      `json:"opened"`
+	Reason string    `json:"reason,omitempty"`
+	Time   time.Time `json:"time"`
+}
+
+// openableSchemes are the only ones OpenURL hands to the OS; file:,
+// javascript:, data: and custom app schemes could run or read anything
+var openableSchemes = []string{"http", "https", "mailto"}
+
+func hostAllowed(allowlist []string, host string) bool {
+	host = strings.ToLower(host)
+	for _, pattern := range allowlist {
+		if ok, _ := path.Match(strings.ToLower(pattern), host); ok {
+			return true
+		}
+		// "*.example.com" covers example.com itself too
+		if base, ok := strings.CutPrefix(strings.ToLower(pattern), "*."); ok && host == base {
+			return true
+		}
+	}
+	return false
+}
+
+// OpenURL opens a link from response content in the default browser,
+// after checking its scheme and asking for confirmation as LinkSettings
+// say. It reports whether the link was opened; every attempt is logged.
+func (a *App) OpenURL(rawURL string) (bool, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		a.logLinkOpen(rawURL, false, "invalid")
+		return false, fmt.Errorf("invalid link: %w", err)
+	}
+	scheme := strings.ToLower(u.Scheme)
+	allowedScheme := false
+	for _, s := range openableSchemes {
+		allowedScheme = allowedScheme || s == scheme
+	}
+	if !allowedScheme {
+		a.logLinkOpen(rawURL, false, "blocked scheme")
+		return false, fmt.Errorf("links with scheme %q are not opened", u.Scheme)
+	}
+	if scheme != "mailto" && u.Host == "" {
+		a.logLinkOpen(rawURL, false, "invalid")
+		return false, fmt.Errorf("link %q has no host", rawURL)
+	}
+
+	cfg := a.GetSettings().Links
+	ask := cfg.Confirm == confirmLinksAlways ||
+		(cfg.Confirm != confirmLinksNever && !hostAllowed(cfg.Allowlist, u.Hostname()))
+	if ask {
+		choice, err := wailsruntime.MessageDialog(a.ctx, wailsruntime.MessageDialogOptions{
+			Type:          wailsruntime.QuestionDialog,
+			Title:         "Open Link?",
+			Message:       u.String(),
+			Buttons:       []string{"Open", "Cancel"},
+			DefaultButton: "Cancel",
+			CancelButton:  "Cancel",
+		})
+		// Windows reports Yes/No for two-button question dialogs
+		if err != nil || (choice != "Open" && choice != "Yes") {
+			a.logLinkOpen(u.String(), false, "declined")
+			return false, err
+		}
+	}
+
+	wailsruntime.BrowserOpenURL(a.ctx, u.String())
+	a.logLinkOpen(u.String(), true, "")
+	return true, nil
+}
+
+// AllowLinkHost adds a host pattern to the allowlist
+func (a *App) AllowLinkHost(pattern string) error {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	if _, err := path.Match(pattern, ""); err != nil || pattern == "" {
+		return fmt.Errorf("invalid host pattern %q", pattern)
+	}
+	return a.updateSettings(func(s *Settings) {
+		for _, p := range s.Links.Allowlist {
+			if p == pattern {
+				return
+			}
+		}
+		s.Links.Allowlist = append(s.Links.Allowlist, pattern)
+	})
+}
+
+// SetLinkSettings saves the confirmation mode and allowlist
+func (a *App) SetLinkSettings(cfg LinkSettings) error {
+	switch cfg.Confirm {
+	case confirmLinksAlways, confirmLinksUnknown, confirmLinksNever:
+	default:
+		return fmt.Errorf("unknown confirmation mode %q", cfg.Confirm)
+	}
+	return a.updateSettings(func(s *Settings) { s.Links = cfg })
+}
+
+func (a *App) logLinkOpen(rawURL string, opened bool, reason string) {
+	p, err := configPath(linkLogFile)
+	if err != nil {
+		return
+	}
+	line, err := json.Marshal(linkOpen{URL: rawURL, Opened: opened, Reason: reason, Time: time.Now()})
+	if err != nil {
+		return
+	}
+	f, err := os.OpenFile(p, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		println("Error logging link:", err.Error())
+		return
+	}
+	defer f.Close()
+	_, _ = f.Write(append(line, '\n'))
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// LocalAPISettings controls the HTTP server scripts and integrations talk
+// to. It only ever listens on the loopback interface. /health is open and
+// only reports that the app is up; everything else, including the full
+// /status, needs the token from GetLocalAPIToken.
+type LocalAPISettings struct {
+	Enabled bool `json:"enabled"`
+	Port    int  `json:"port"`
+}
+
+const defaultLocalAPIPort = 47810
+
+// localAPIHandler serves the local API routes
+func (a *App) localAPIHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
+		writeAPIJSON(w, map[string]any{"ok": true, "version": appVersion})
+	})
+	// ?provider=1 calls the provider with the user's key, so it is never
+	// open to unauthenticated callers
+	mux.Handle("GET /status", a.requireAPIToken(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeAPIJSON(w, a.status(r.URL.Query().Get("provider") == "1"))
+	})))
+	mux.Handle("GET /events", a.requireAPIToken(a.eventStreamHandler()))
+	a.browserRoutes(mux)
+	return loopbackOnly(mux)
+}
+
+// loopbackOnly rejects requests whose Host is not a loopback name, so a web
+// page cannot reach the API through DNS rebinding
+func loopbackOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.Host)
+		if err != nil {
+			host = r.Host
+		}
+		if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeAPIJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		println("Error writing API response:", err.Error())
+	}
+}
+
+// startLocalAPI starts the server if it is enabled in settings, replacing
+// one already running
+func (a *App) startLocalAPI() error {
+	a.stopLocalAPI()
+	cfg := a.GetSettings().LocalAPI
+	if !cfg.Enabled {
+		return nil
+	}
+	port := cfg.Port
+	if port == 0 {
+		port = defaultLocalAPIPort
+	}
+	ln, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return fmt.Errorf("local API: %w", err)
+	}
+	srv := &http.Server{Handler: a.localAPIHandler(), ReadHeaderTimeout: 5 * time.Second}
+	a.apiMu.Lock()
+	a.apiServer = srv
+	a.apiMu.Unlock()
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			println("Error serving local API:", err.Error())
+		}
+	}()
+	return nil
+}
+
+func (a *App) stopLocalAPI() {
+	a.apiMu.Lock()
+	srv := a.apiServer
+	a.apiServer = nil
+	a.apiMu.Unlock()
+	// Shutdown leaves hijacked WebSocket connections open
+	bridge.closeAll()
+	if srv != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = srv.Shutdown(ctx)
+	}
+}
+
+// SetLocalAPI saves the local API settings and restarts the server
+func (a *App) SetLocalAPI(cfg LocalAPISettings) error {
+	if cfg.Port < 0 || cfg.Port > 65535 {
+		return fmt.Errorf("invalid port %d", cfg.Port)
+	}
+	if err := a.updateSettings(func(s *Settings) { s.LocalAPI = cfg }); err != nil {
+		return err
+	}
+	return a.startLocalAPI()
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"embed"
+	"fmt"
+	"os"
 
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/options"
@@ -12,6 +14,29 @@ import (
 var assets embed.FS
 
 func main() {
+	os.Args = append(os.Args[:1], enablePortableFromArgs(os.Args[1:])...)
+
+	// Secondary windows run as child processes of the same binary
+	if kind, flags, ok := parseChildWindowArgs(os.Args[1:]); ok {
+		var err error
+		switch kind {
+		case "annotation":
+			err = runAnnotationWindow(flags)
+		case "companion":
+			err = runCompanionWindow(flags)
+		case "detached":
+			err = runDetachedWindow(flags)
+		case "selection-hint":
+			err = runSelectionHintWindow(flags)
+		default:
+			err = fmt.Errorf("unknown window kind %q", kind)
+		}
+		if err != nil {
+			println("Error:", err.Error())
+		}
+		return
+	}
+
 	// Create an instance of the app structure
 	app := NewApp()
 
@@ -28,10 +53,12 @@ func main() {
 		AlwaysOnTop:       true,
 		HideWindowOnClose: true,
 		BackgroundColour:  &options.RGBA{R: 27, G: 38, B: 54, A: 1},
+		Menu:              app.applicationMenu(),
 		OnStartup:         app.startup,
 		OnBeforeClose:     app.Cleanup,
 		Bind: []interface{}{
 			app,
+			&WindowInfo{kind: "main"},
 		},
 	})
 
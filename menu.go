@@ -0,0 +1,89 @@
+package main
+
+import (
+	"runtime"
+
+	"github.com/wailsapp/wails/v2/pkg/menu"
+	"github.com/wailsapp/wails/v2/pkg/menu/keys"
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// Wails v2 has no system tray, so quick toggles live in the application
+// menu, which is what macOS shows in the menu bar while overlae is active
+func (a *App) applicationMenu() *menu.Menu {
+	if runtime.GOOS != "darwin" {
+		return nil
+	}
+	appMenu := menu.NewMenu()
+	appMenu.Append(menu.AppMenu())
+
+	overlay := appMenu.AddSubmenu("Overlay")
+	a.hotkeysMenuItem = overlay.AddCheckbox("Pause Global Hotkeys", false, nil, func(cd *menu.CallbackData) {
+		_ = a.SetHotkeysEnabled(!cd.MenuItem.Checked)
+	})
+	// Unchecking closes the microphone; checking needs a detector set up
+	// on this install, so the item stays unchecked when none is
+	a.wakeMenuItem = overlay.AddCheckbox("Listen for Wake Word", false, nil, func(cd *menu.CallbackData) {
+		if cd.MenuItem.Checked {
+			cfg := a.GetSettings().WakeWord
+			cfg.Enabled = true
+			if err := a.withPermission(capShell, func() error { return a.SetWakeWord(cfg) }); err != nil {
+				println("Error starting wake word detector:", err.Error())
+				a.syncWakeWordMenu(false)
+			}
+		} else {
+			_ = a.StopWakeWord()
+		}
+	})
+	overlay.AddSeparator()
+	a.alwaysOnTopMenuItem = overlay.AddCheckbox("Always on Top", true, nil, func(cd *menu.CallbackData) {
+		a.SetAlwaysOnTop(cd.MenuItem.Checked)
+	})
+	a.fullscreenMenuItem = overlay.AddCheckbox("Full Screen", false, keys.Combo("f", keys.CmdOrCtrlKey, keys.ControlKey), func(*menu.CallbackData) {
+		a.ToggleFullscreen()
+	})
+	overlay.AddText("Minimize to Menu Bar", keys.CmdOrCtrl("m"), func(*menu.CallbackData) {
+		a.MinimizeToTray()
+	})
+	appMenu.Append(menu.EditMenu())
+
+	help := appMenu.AddSubmenu("Help")
+	help.AddText("Run Diagnostics", nil, func(*menu.CallbackData) {
+		opts := wailsruntime.MessageDialogOptions{Type: wailsruntime.InfoDialog, Title: "Diagnostics"}
+		if path, err := a.RunDiagnostics(); err != nil {
+			opts.Type, opts.Message = wailsruntime.ErrorDialog, err.Error()
+		} else {
+			opts.Message = "The report was copied to the clipboard and saved to " + path
+		}
+		_, _ = wailsruntime.MessageDialog(a.ctx, opts)
+	})
+	return appMenu
+}
+
+// syncHotkeysMenu keeps the pause checkbox in step with the real state
+func (a *App) syncHotkeysMenu(enabled bool) {
+	if a.hotkeysMenuItem == nil || a.ctx == nil {
+		return
+	}
+	a.hotkeysMenuItem.SetChecked(!enabled)
+	wailsruntime.MenuUpdateApplicationMenu(a.ctx)
+}
+
+// syncWakeWordMenu keeps the wake word checkbox in step with the mic
+func (a *App) syncWakeWordMenu(listening bool) {
+	if a.wakeMenuItem == nil || a.ctx == nil {
+		return
+	}
+	a.wakeMenuItem.SetChecked(listening)
+	wailsruntime.MenuUpdateApplicationMenu(a.ctx)
+}
+
+// syncWindowMenu keeps the window checkboxes in step with the real state
+func (a *App) syncWindowMenu(s WindowState) {
+	if a.alwaysOnTopMenuItem == nil || a.ctx == nil {
+		return
+	}
+	a.alwaysOnTopMenuItem.SetChecked(s.AlwaysOnTop)
+	a.fullscreenMenuItem.SetChecked(s.Fullscreen)
+	wailsruntime.MenuUpdateApplicationMenu(a.ctx)
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	modelCatalogFile = "models.json"
+	// modelCatalogTTL is how long a fetched model list is trusted
+	modelCatalogTTL = 24 * time.Hour
+	keyCheckTimeout = 15 * time.Second
+)
+
+// ModelCatalog is the model list a provider key has access to
+type ModelCatalog struct {
+	KeyID     string    `json:"keyId"`
+	Models    []string  `json:"models"`
+	FetchedAt time.Time `json:"fetchedAt"`
+}
+
+// errKeyRejected is returned when the provider refuses the API key itself
+var errKeyRejected = errors.New("the provider rejected the API key")
+
+func (p *openAIProvider) listModels(ctx context.Context) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/models", nil)
+	if err != nil {
+		return nil, err
+	}
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return nil, errKeyRejected
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("provider returned %s", resp.Status)
+	}
+
+	var body struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("invalid model list: %w", err)
+	}
+	models := make([]string, 0, len(body.Data))
+	for _, m := range body.Data {
+		models = append(models, m.ID)
+	}
+	sort.Strings(models)
+	return models, nil
+}
+
+func loadModelCatalogs() map[string]ModelCatalog {
+	catalogs := map[string]ModelCatalog{}
+	if dir, err := cacheDir(); err == nil {
+		if data, err := os.ReadFile(filepath.Join(dir, modelCatalogFile)); err == nil {
+			_ = json.Unmarshal(data, &catalogs)
+		}
+	}
+	return catalogs
+}
+
+func (a *App) storeModelCatalog(c ModelCatalog) error {
+	a.modelsMu.Lock()
+	defer a.modelsMu.Unlock()
+	catalogs := loadModelCatalogs()
+	catalogs[c.KeyID] = c
+	dir, err := cacheDir()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(catalogs)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(dir, modelCatalogFile), data, 0o600)
+}
+
+// fetchModelCatalog lists the models of the current provider using key
+// keyID. Listing models is free, so it doubles as the key check.
+func (a *App) fetchModelCatalog(ctx context.Context, keyID string) (ModelCatalog, error) {
+	cfg := a.GetSettings().Provider
+	apiKey := ""
+	if keyID != "" {
+		key, err := a.providerKey(keyID)
+		if err != nil {
+			return ModelCatalog{}, err
+		}
+		if key.Provider != providerKind(cfg.Kind) {
+			return ModelCatalog{}, fmt.Errorf("key %q is for %s, not the current provider", key.Label, key.Provider)
+		}
+		if apiKey, err = getSecret(providerKeyAccount(keyID)); err != nil {
+			return ModelCatalog{}, err
+		}
+	}
+	p, err := newProvider(cfg, ProviderCredentials{APIKey: apiKey})
+	if err != nil {
+		return ModelCatalog{}, err
+	}
+	models, err := p.listModels(ctx)
+	if err != nil {
+		return ModelCatalog{}, err
+	}
+	c := ModelCatalog{KeyID: keyID, Models: models, FetchedAt: time.Now()}
+	if err := a.storeModelCatalog(c); err != nil {
+		println("Error caching model list:", err.Error())
+	}
+	return c, nil
+}
+
+// ValidateProviderKey checks a key in the background and reports the
+// outcome as a provider-key-validated event carrying the key ID as its
+// request ID, along with the models the key can use
+func (a *App) ValidateProviderKey(keyID string) string {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), keyCheckTimeout)
+		defer cancel()
+		c, err := a.fetchModelCatalog(ctx, keyID)
+		if c.KeyID == "" {
+			c.KeyID = keyID
+		}
+		eventKeyValidated.respond(a.ctx, keyID, c, err)
+	}()
+	return keyID
+}
+
+// GetModels returns the models available with the key requests currently
+// use, from the cache while it is fresh
+func (a *App) GetModels() (ModelCatalog, error) {
+	keyID, _, err := a.resolveProviderKey(a.GetSettings().Provider)
+	if err != nil {
+		return ModelCatalog{}, err
+	}
+	a.modelsMu.Lock()
+	c, ok := loadModelCatalogs()[keyID]
+	a.modelsMu.Unlock()
+	if ok && time.Since(c.FetchedAt) < modelCatalogTTL {
+		return c, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), keyCheckTimeout)
+	defer cancel()
+	return a.fetchModelCatalog(ctx, keyID)
+}
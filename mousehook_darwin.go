@@ -0,0 +1,141 @@
+package main
+
+/*
+#cgo LDFLAGS: -framework ApplicationServices -framework CoreFoundation
+#include <ApplicationServices/ApplicationServices.h>
+
+extern int ovMouseEvent(int button, int down, unsigned long long flags);
+
+static CFMachPortRef ovTap;
+static CFRunLoopRef ovTapLoop;
+
+static CGEventRef ovTapCallback(CGEventTapProxy proxy, CGEventType type, CGEventRef event, void *info) {
+	if (type == kCGEventTapDisabledByTimeout || type == kCGEventTapDisabledByUserInput) {
+		CGEventTapEnable(ovTap, true);
+		return event;
+	}
+	int button = (int)CGEventGetIntegerValueField(event, kCGMouseEventButtonNumber);
+	int down = type == kCGEventOtherMouseDown;
+	if (ovMouseEvent(button, down, CGEventGetFlags(event))) return NULL;
+	return event;
+}
+
+// ovRunMouseTap blocks running the tap's run loop until ovStopMouseTap.
+// Creating the tap fails without the Accessibility permission.
+static int ovRunMouseTap(void) {
+	CGEventMask mask = CGEventMaskBit(kCGEventOtherMouseDown) | CGEventMaskBit(kCGEventOtherMouseUp);
+	ovTap = CGEventTapCreate(kCGSessionEventTap, kCGHeadInsertEventTap, kCGEventTapOptionDefault, mask, ovTapCallback, NULL);
+	if (!ovTap) return 0;
+	CFRunLoopSourceRef src = CFMachPortCreateRunLoopSource(kCFAllocatorDefault, ovTap, 0);
+	ovTapLoop = CFRunLoopGetCurrent();
+	CFRunLoopAddSource(ovTapLoop, src, kCFRunLoopCommonModes);
+	CGEventTapEnable(ovTap, true);
+	return 1;
+}
+
+static void ovLoopMouseTap(void) {
+	CFRunLoopRun();
+	CFMachPortInvalidate(ovTap);
+	CFRelease(ovTap);
+	ovTap = NULL;
+}
+
+static void ovStopMouseTap(void) {
+	if (ovTapLoop) CFRunLoopStop(ovTapLoop);
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+var (
+	mouseHandlerMu sync.Mutex
+	mouseTarget    mouseTrigger
+	mouseHandler   func() bool
+	mouseSwallowed = map[mouseButton]bool{}
+)
+
+//export ovMouseEvent
+func ovMouseEvent(button, down C.int, flags C.ulonglong) C.int {
+	// CGEvent button numbers: 2 is middle, 3 and 4 are the side buttons
+	var b mouseButton
+	switch button {
+	case 2:
+		b = mouseMiddle
+	case 3:
+		b = mouse4
+	case 4:
+		b = mouse5
+	default:
+		return 0
+	}
+
+	mouseHandlerMu.Lock()
+	defer mouseHandlerMu.Unlock()
+	if down == 0 {
+		if mouseSwallowed[b] {
+			delete(mouseSwallowed, b)
+			return 1
+		}
+		return 0
+	}
+	if mouseHandler == nil || b != mouseTarget.button || mouseModsFromFlags(uint64(flags)) != mouseTarget.mods || !mouseHandler() {
+		return 0
+	}
+	mouseSwallowed[b] = true
+	return 1
+}
+
+func mouseModsFromFlags(flags uint64) mouseMods {
+	var mods mouseMods
+	if flags&C.kCGEventFlagMaskControl != 0 {
+		mods |= mouseCtrl
+	}
+	if flags&C.kCGEventFlagMaskAlternate != 0 {
+		mods |= mouseAlt
+	}
+	if flags&C.kCGEventFlagMaskShift != 0 {
+		mods |= mouseShift
+	}
+	if flags&C.kCGEventFlagMaskCommand != 0 {
+		mods |= mouseSuper
+	}
+	return mods
+}
+
+// startMouseHook runs a CGEventTap for the extra mouse buttons on its own
+// thread. Only one tap exists at a time; startMouseTrigger stops the
+// previous one first.
+func startMouseHook(trigger mouseTrigger, fire func() bool) (func(), error) {
+	mouseHandlerMu.Lock()
+	mouseTarget, mouseHandler = trigger, fire
+	mouseHandlerMu.Unlock()
+
+	ready := make(chan bool)
+	done := make(chan struct{})
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		defer close(done)
+		if C.ovRunMouseTap() == 0 {
+			ready <- false
+			return
+		}
+		ready <- true
+		C.ovLoopMouseTap()
+	}()
+	if !<-ready {
+		return nil, fmt.Errorf("mouse triggers need the Accessibility permission")
+	}
+	return func() {
+		C.ovStopMouseTap()
+		<-done
+		mouseHandlerMu.Lock()
+		mouseHandler = nil
+		mouseHandlerMu.Unlock()
+	}, nil
+}
@@ -0,0 +1,131 @@
+package main
+
+/*
+#cgo LDFLAGS: -lX11
+#include <X11/Xlib.h>
+#include <poll.h>
+
+// Lock and NumLock must not stop the trigger, so every combination of
+// them is grabbed alongside the requested modifiers
+static const unsigned int ovIgnoredMods[] = {0, LockMask, Mod2Mask, LockMask | Mod2Mask};
+
+static int ovGrabFailed;
+
+static int ovOnGrabError(Display *d, XErrorEvent *e) {
+	ovGrabFailed = 1;
+	return 0;
+}
+
+// ovGrabButton returns 0 if another client already grabs the button. The
+// default X error handler would exit the process, so a temporary one is
+// installed around the synced grab.
+static int ovGrabButton(Display *d, unsigned int button, unsigned int mods, int grab) {
+	Window root = DefaultRootWindow(d);
+	ovGrabFailed = 0;
+	XErrorHandler prev = XSetErrorHandler(ovOnGrabError);
+	for (int i = 0; i < 4; i++) {
+		if (grab) {
+			XGrabButton(d, button, mods | ovIgnoredMods[i], root, False, ButtonPressMask | ButtonReleaseMask,
+				GrabModeSync, GrabModeAsync, None, None);
+		} else {
+			XUngrabButton(d, button, mods | ovIgnoredMods[i], root);
+		}
+	}
+	XSync(d, False);
+	XSetErrorHandler(prev);
+	return !ovGrabFailed;
+}
+
+// ovNextButtonPress waits up to timeoutMs for a press of the grabbed
+// button; releases are consumed here
+static int ovNextButtonPress(Display *d, int timeoutMs) {
+	if (!XPending(d)) {
+		struct pollfd p = {ConnectionNumber(d), POLLIN, 0};
+		if (poll(&p, 1, timeoutMs) <= 0) return 0;
+	}
+	while (XPending(d)) {
+		XEvent ev;
+		XNextEvent(d, &ev);
+		if (ev.type == ButtonPress) return 1;
+	}
+	return 0;
+}
+
+// ovAllowButton releases the synchronous grab, either keeping the click or
+// replaying it to the window under the pointer
+static void ovAllowButton(Display *d, int keep) {
+	XAllowEvents(d, keep ? AsyncPointer : ReplayPointer, CurrentTime);
+	XFlush(d);
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"runtime"
+	"sync/atomic"
+)
+
+// X11 pointer buttons; 8 and 9 are back and forward
+var x11Buttons = map[mouseButton]C.uint{mouseMiddle: 2, mouse4: 8, mouse5: 9}
+
+func x11Mods(mods mouseMods) C.uint {
+	var m C.uint
+	if mods&mouseCtrl != 0 {
+		m |= C.ControlMask
+	}
+	if mods&mouseAlt != 0 {
+		m |= C.Mod1Mask
+	}
+	if mods&mouseShift != 0 {
+		m |= C.ShiftMask
+	}
+	if mods&mouseSuper != 0 {
+		m |= C.Mod4Mask
+	}
+	return m
+}
+
+// startMouseHook grabs the trigger button on the root window over its own
+// X connection. The grab is synchronous so a press can still be replayed
+// to the app under the pointer when fire declines it.
+func startMouseHook(trigger mouseTrigger, fire func() bool) (func(), error) {
+	d := C.XOpenDisplay(nil)
+	if d == nil {
+		return nil, fmt.Errorf("cannot open X display")
+	}
+	button, mods := x11Buttons[trigger.button], x11Mods(trigger.mods)
+
+	var stopped atomic.Bool
+	ready := make(chan bool)
+	done := make(chan struct{})
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		defer close(done)
+		defer C.XCloseDisplay(d)
+
+		if C.ovGrabButton(d, button, mods, 1) == 0 {
+			ready <- false
+			return
+		}
+		ready <- true
+		for !stopped.Load() {
+			if C.ovNextButtonPress(d, 200) == 1 {
+				keep := C.int(0)
+				if fire() {
+					keep = 1
+				}
+				C.ovAllowButton(d, keep)
+			}
+		}
+		C.ovGrabButton(d, button, mods, 0)
+	}()
+	if !<-ready {
+		return nil, fmt.Errorf("mouse button is already grabbed by another application")
+	}
+	return func() {
+		stopped.Store(true)
+		<-done
+	}, nil
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"runtime"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	procSetWindowsHookExW   = user32.NewProc("SetWindowsHookExW")
+	procCallNextHookEx      = user32.NewProc("CallNextHookEx")
+	procUnhookWindowsHookEx = user32.NewProc("UnhookWindowsHookEx")
+	procGetMessageW         = user32.NewProc("GetMessageW")
+	procPostThreadMessageW  = user32.NewProc("PostThreadMessageW")
+	procGetAsyncKeyState    = user32.NewProc("GetAsyncKeyState")
+	procGetCurrentThreadId  = kernel32.NewProc("GetCurrentThreadId")
+)
+
+const (
+	whMouseLL     = 14
+	wmQuit        = 0x0012
+	wmMButtonDown = 0x0207
+	wmMButtonUp   = 0x0208
+	wmXButtonDown = 0x020b
+	wmXButtonUp   = 0x020c
+	xButton1      = 1
+
+	vkShift = 0x10
+	vkMenu  = 0x12
+	vkLWin  = 0x5b
+	vkRWin  = 0x5c
+)
+
+type msllHookStruct struct {
+	X, Y      int32
+	MouseData uint32
+	Flags     uint32
+	Time      uint32
+	ExtraInfo uintptr
+}
+
+func keyDown(vk uintptr) bool {
+	state, _, _ := procGetAsyncKeyState.Call(vk)
+	return state&0x8000 != 0
+}
+
+func currentMouseMods() mouseMods {
+	var mods mouseMods
+	if keyDown(vkControl) {
+		mods |= mouseCtrl
+	}
+	if keyDown(vkMenu) {
+		mods |= mouseAlt
+	}
+	if keyDown(vkShift) {
+		mods |= mouseShift
+	}
+	if keyDown(vkLWin) || keyDown(vkRWin) {
+		mods |= mouseSuper
+	}
+	return mods
+}
+
+// startMouseHook installs a WH_MOUSE_LL hook on its own thread, which has
+// to pump messages for the hook to be called. A handled press swallows the
+// matching release too, since apps act on either.
+func startMouseHook(trigger mouseTrigger, fire func() bool) (func(), error) {
+	type started struct {
+		tid uintptr
+		err error
+	}
+	ready := make(chan started)
+
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+
+		tid, _, _ := procGetCurrentThreadId.Call()
+		swallowed := map[mouseButton]bool{}
+		cb := syscall.NewCallback(func(code, wparam, lparam uintptr) uintptr {
+			if int32(code) >= 0 {
+				info := *(**msllHookStruct)(unsafe.Pointer(&lparam))
+				button, down, ok := mouseEvent(wparam, info.MouseData)
+				switch {
+				case ok && down && button == trigger.button && currentMouseMods() == trigger.mods && fire():
+					swallowed[button] = true
+					return 1
+				case ok && !down && swallowed[button]:
+					delete(swallowed, button)
+					return 1
+				}
+			}
+			r, _, _ := procCallNextHookEx.Call(0, code, wparam, lparam)
+			return r
+		})
+
+		hook, _, err := procSetWindowsHookExW.Call(whMouseLL, cb, 0, 0)
+		if hook == 0 {
+			ready <- started{err: err}
+			return
+		}
+		ready <- started{tid: tid}
+
+		var msg [48]byte // MSG
+		for {
+			r, _, _ := procGetMessageW.Call(uintptr(unsafe.Pointer(&msg[0])), 0, 0, 0)
+			if int32(r) <= 0 {
+				break
+			}
+		}
+		procUnhookWindowsHookEx.Call(hook)
+	}()
+
+	s := <-ready
+	if s.err != nil {
+		return nil, s.err
+	}
+	return func() { procPostThreadMessageW.Call(s.tid, wmQuit, 0, 0) }, nil
+}
+
+func mouseEvent(msg uintptr, data uint32) (mouseButton, bool, bool) {
+	switch msg {
+	case wmMButtonDown, wmMButtonUp:
+		return mouseMiddle, msg == wmMButtonDown, true
+	case wmXButtonDown, wmXButtonUp:
+		button := mouse5
+		if data>>16 == xButton1 {
+			button = mouse4
+		}
+		return button, msg == wmXButtonDown, true
+	}
+	return 0, false, false
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+type mouseButton int
+
+const (
+	mouseMiddle mouseButton = iota
+	mouse4
+	mouse5
+)
+
+var mouseButtonNames = map[string]mouseButton{
+	"middle":  mouseMiddle,
+	"mouse3":  mouseMiddle,
+	"mouse4":  mouse4,
+	"back":    mouse4,
+	"mouse5":  mouse5,
+	"forward": mouse5,
+}
+
+// mouseMods are the modifier keys held during a click, independent of
+// platform
+type mouseMods uint8
+
+const (
+	mouseCtrl mouseMods = 1 << iota
+	mouseAlt
+	mouseShift
+	mouseSuper
+)
+
+// mouseTrigger is a parsed spec such as "Mouse4" or "Ctrl+Middle"
+type mouseTrigger struct {
+	button mouseButton
+	mods   mouseMods
+}
+
+// parseMouseTrigger accepts modifiers joined with "+" before a button
+// name. A bare middle click pastes or opens links, so it needs a modifier.
+func parseMouseTrigger(spec string) (mouseTrigger, error) {
+	parts := strings.Split(spec, "+")
+	button, ok := mouseButtonNames[strings.ToLower(strings.TrimSpace(parts[len(parts)-1]))]
+	if !ok {
+		return mouseTrigger{}, fmt.Errorf("unknown mouse button in trigger %q", spec)
+	}
+
+	t := mouseTrigger{button: button}
+	for _, p := range parts[:len(parts)-1] {
+		switch strings.ToLower(strings.TrimSpace(p)) {
+		case "ctrl", "control":
+			t.mods |= mouseCtrl
+		case "alt", "option", "opt":
+			t.mods |= mouseAlt
+		case "shift":
+			t.mods |= mouseShift
+		case "cmd", "command", "super", "win", "meta":
+			t.mods |= mouseSuper
+		case "cmdorctrl", "commandorcontrol", "mod":
+			if runtime.GOOS == "darwin" {
+				t.mods |= mouseSuper
+			} else {
+				t.mods |= mouseCtrl
+			}
+		default:
+			return mouseTrigger{}, fmt.Errorf("unknown modifier %q in trigger %q", p, spec)
+		}
+	}
+	if t.button == mouseMiddle && t.mods == 0 {
+		return mouseTrigger{}, fmt.Errorf("middle-click trigger %q needs a modifier", spec)
+	}
+	return t, nil
+}
+
+// startMouseTrigger installs the platform mouse hook for the configured
+// trigger, replacing any previous one. An empty spec just removes it.
+func (a *App) startMouseTrigger(spec string) error {
+	a.mouseMu.Lock()
+	defer a.mouseMu.Unlock()
+
+	if a.mouseStop != nil {
+		a.mouseStop()
+		a.mouseStop = nil
+	}
+	if spec == "" {
+		return nil
+	}
+	trigger, err := parseMouseTrigger(spec)
+	if err != nil {
+		return err
+	}
+	stop, err := startMouseHook(trigger, func() bool {
+		// Pausing hotkeys pauses mouse triggers too; the click then passes
+		// through to the app under the cursor
+		if !a.HotkeysEnabled() {
+			return false
+		}
+		go a.emitShowOverlay("mouse")
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	a.mouseStop = stop
+	return nil
+}
+
+// SetMouseTrigger binds a mouse button, e.g. "Mouse4" or "Ctrl+Middle", to
+// show the overlay as an alternative to the keyboard shortcut. An empty
+// spec disables it.
+func (a *App) SetMouseTrigger(spec string) error {
+	if err := a.startMouseTrigger(spec); err != nil {
+		return err
+	}
+	return a.updateSettings(func(s *Settings) { s.MouseTrigger = spec })
+}
+
+// stopMouseTrigger removes the mouse hook on shutdown
+func (a *App) stopMouseTrigger() {
+	_ = a.startMouseTrigger("")
+}
@@ -0,0 +1,349 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+const (
+	// netToolTimeout bounds every network tool run
+	netToolTimeout = 30 * time.Second
+	netDialTimeout = 3 * time.Second
+	pingInterval   = time.Second
+	maxPingCount   = 20
+	// publicIPURL echoes the caller's address as {"ip": "…"}
+	publicIPURL = "https://api64.ipify.org?format=json"
+)
+
+// NetToolLine is one line of output from a network tool, sent as it is
+// produced in a net-tool-output event
+type NetToolLine struct {
+	Tool string `json:"tool"`
+	Text string `json:"text"`
+}
+
+// NetToolResult ends a run in a net-tool-done event; the envelope carries
+// the error of a failed run
+type NetToolResult struct {
+	Tool       string `json:"tool"`
+	Lines      int    `json:"lines"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// startNetTool runs a tool in the background and returns its request ID;
+// output and the result arrive as events carrying that ID
+func (a *App) startNetTool(tool string, run func(ctx context.Context, print func(string)) error) string {
+	id := newRequestID()
+	ctx, cancel := context.WithTimeout(a.ctx, netToolTimeout)
+	a.netToolsMu.Lock()
+	if a.netTools == nil {
+		a.netTools = map[string]context.CancelFunc{}
+	}
+	a.netTools[id] = cancel
+	a.netToolsMu.Unlock()
+
+	go func() {
+		defer func() {
+			cancel()
+			a.netToolsMu.Lock()
+			delete(a.netTools, id)
+			a.netToolsMu.Unlock()
+		}()
+		start := time.Now()
+		lines := 0
+		err := run(ctx, func(text string) {
+			lines++
+			eventNetToolOutput.respond(a.ctx, id, NetToolLine{Tool: tool, Text: text}, nil)
+		})
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && err != nil {
+			err = fmt.Errorf("%s timed out", tool)
+		}
+		eventNetToolDone.respond(a.ctx, id, NetToolResult{Tool: tool, Lines: lines, DurationMs: time.Since(start).Milliseconds()}, err)
+	}()
+	return id
+}
+
+// CancelNetTool stops a network tool run
+func (a *App) CancelNetTool(id string) {
+	a.netToolsMu.Lock()
+	defer a.netToolsMu.Unlock()
+	if cancel, ok := a.netTools[id]; ok {
+		cancel()
+	}
+}
+
+// MyIP lists the addresses of the network interfaces that are up and then
+// the public address the internet sees
+func (a *App) MyIP() string {
+	return a.startNetTool("ip", func(ctx context.Context, print func(string)) error {
+		ifaces, err := net.Interfaces()
+		if err != nil {
+			return err
+		}
+		for _, iface := range ifaces {
+			if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+				continue
+			}
+			addrs, _ := iface.Addrs()
+			for _, addr := range addrs {
+				if ipnet, ok := addr.(*net.IPNet); ok && !ipnet.IP.IsLinkLocalUnicast() {
+					print(fmt.Sprintf("Local: %s (%s)", ipnet.IP, iface.Name))
+				}
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, publicIPURL, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("could not reach the internet: %w", err)
+		}
+		defer resp.Body.Close()
+		var body struct {
+			IP string `json:"ip"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil || body.IP == "" {
+			return errors.New("invalid public address response")
+		}
+		print("Public: " + body.IP)
+		return nil
+	})
+}
+
+// Ping sends count echo requests to host. Where unprivileged ICMP sockets
+// are unavailable, as on Windows and most Linux setups by default, it
+// times TCP connections to port 443 instead and says so.
+func (a *App) Ping(host string, count int) (string, error) {
+	host = strings.TrimSpace(host)
+	if host == "" {
+		return "", errors.New("enter a host to ping")
+	}
+	count = max(1, min(count, maxPingCount))
+	return a.startNetTool("ping", func(ctx context.Context, print func(string)) error {
+		addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			return err
+		}
+		ip := addrs[0].IP
+		print(fmt.Sprintf("PING %s (%s)", host, ip))
+
+		probe, closeProbe, err := icmpProbe(ip)
+		if err == nil {
+			defer closeProbe()
+		} else {
+			print("ICMP is not available; timing TCP connections to port 443 instead")
+			probe = func(ctx context.Context, _ int) (time.Duration, error) {
+				start := time.Now()
+				var d net.Dialer
+				conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(ip.String(), "443"))
+				if err != nil {
+					return 0, err
+				}
+				conn.Close()
+				return time.Since(start), nil
+			}
+		}
+
+		received := 0
+		var total, best, worst time.Duration
+		for seq := 1; seq <= count; seq++ {
+			probeCtx, cancel := context.WithTimeout(ctx, netDialTimeout)
+			rtt, err := probe(probeCtx, seq)
+			cancel()
+			switch {
+			case ctx.Err() != nil:
+				return ctx.Err()
+			case err != nil:
+				print(fmt.Sprintf("seq=%d: %v", seq, err))
+			default:
+				received++
+				total += rtt
+				if best == 0 || rtt < best {
+					best = rtt
+				}
+				worst = max(worst, rtt)
+				print(fmt.Sprintf("seq=%d time=%s", seq, rtt.Round(10*time.Microsecond)))
+			}
+			if seq < count {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(pingInterval):
+				}
+			}
+		}
+		summary := fmt.Sprintf("%d sent, %d received, %d%% loss", count, received, (count-received)*100/count)
+		if received > 0 {
+			summary += fmt.Sprintf(", min/avg/max %s/%s/%s", best.Round(10*time.Microsecond),
+				(total / time.Duration(received)).Round(10*time.Microsecond), worst.Round(10*time.Microsecond))
+		}
+		print(summary)
+		return nil
+	}), nil
+}
+
+// icmpProbe opens an unprivileged ICMP socket to ip and returns a function
+// sending one echo request per call, and one closing the socket
+func icmpProbe(ip net.IP) (func(ctx context.Context, seq int) (time.Duration, error), func() error, error) {
+	network, reqType, replyType, proto := "udp4", icmp.Type(ipv4.ICMPTypeEcho), icmp.Type(ipv4.ICMPTypeEchoReply), 1
+	if ip.To4() == nil {
+		network, reqType, replyType, proto = "udp6", ipv6.ICMPTypeEchoRequest, ipv6.ICMPTypeEchoReply, 58
+	}
+	conn, err := icmp.ListenPacket(network, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	id := os.Getpid() & 0xffff
+	return func(ctx context.Context, seq int) (time.Duration, error) {
+		msg := icmp.Message{Type: reqType, Body: &icmp.Echo{ID: id, Seq: seq, Data: []byte("overlae")}}
+		data, err := msg.Marshal(nil)
+		if err != nil {
+			return 0, err
+		}
+		deadline, _ := ctx.Deadline()
+		conn.SetDeadline(deadline)
+		start := time.Now()
+		if _, err := conn.WriteTo(data, &net.UDPAddr{IP: ip}); err != nil {
+			return 0, err
+		}
+		buf := make([]byte, 1500)
+		for {
+			n, _, err := conn.ReadFrom(buf)
+			if err != nil {
+				return 0, errors.New("request timed out")
+			}
+			reply, err := icmp.ParseMessage(proto, buf[:n])
+			if err != nil || reply.Type != replyType {
+				continue
+			}
+			// Unprivileged sockets rewrite the ID, so only the sequence
+			// identifies our reply
+			if echo, ok := reply.Body.(*icmp.Echo); ok && echo.Seq == seq {
+				return time.Since(start), nil
+			}
+		}
+	}, conn.Close, nil
+}
+
+// DNSLookup resolves name: kind is "A", "AAAA", "CNAME", "MX", "NS" or
+// "TXT", or "PTR" for an IP address. Empty looks up both A and AAAA, or PTR
+// when name is an address.
+func (a *App) DNSLookup(name, kind string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errors.New("enter a name to look up")
+	}
+	kind = strings.ToUpper(strings.TrimSpace(kind))
+	if kind == "" && net.ParseIP(name) != nil {
+		kind = "PTR"
+	}
+	switch kind {
+	case "", "A", "AAAA", "CNAME", "MX", "NS", "TXT", "PTR":
+	default:
+		return "", fmt.Errorf("unsupported record type %q", kind)
+	}
+	return a.startNetTool("dns", func(ctx context.Context, print func(string)) error {
+		r := net.DefaultResolver
+		switch kind {
+		case "", "A", "AAAA":
+			addrs, err := r.LookupIPAddr(ctx, name)
+			if err != nil {
+				return err
+			}
+			for _, addr := range addrs {
+				t := "AAAA"
+				if addr.IP.To4() != nil {
+					t = "A"
+				}
+				if kind == "" || kind == t {
+					print(t + "\t" + addr.IP.String())
+				}
+			}
+		case "CNAME":
+			cname, err := r.LookupCNAME(ctx, name)
+			if err != nil {
+				return err
+			}
+			print("CNAME\t" + cname)
+		case "MX":
+			mxs, err := r.LookupMX(ctx, name)
+			if err != nil {
+				return err
+			}
+			for _, mx := range mxs {
+				print(fmt.Sprintf("MX\t%d %s", mx.Pref, mx.Host))
+			}
+		case "NS":
+			nss, err := r.LookupNS(ctx, name)
+			if err != nil {
+				return err
+			}
+			for _, ns := range nss {
+				print("NS\t" + ns.Host)
+			}
+		case "TXT":
+			txts, err := r.LookupTXT(ctx, name)
+			if err != nil {
+				return err
+			}
+			for _, txt := range txts {
+				print("TXT\t" + strconv.Quote(txt))
+			}
+		case "PTR":
+			names, err := r.LookupAddr(ctx, name)
+			if err != nil {
+				return err
+			}
+			for _, n := range names {
+				print("PTR\t" + n)
+			}
+		}
+		return nil
+	}), nil
+}
+
+// CheckPort reports whether a TCP connection to host:port succeeds, is
+// refused, or gets no answer, which usually means a firewall drops it
+func (a *App) CheckPort(host string, port int) (string, error) {
+	host = strings.TrimSpace(host)
+	if host == "" {
+		return "", errors.New("enter a host")
+	}
+	if port < 1 || port > 65535 {
+		return "", fmt.Errorf("port must be between 1 and 65535")
+	}
+	return a.startNetTool("port", func(ctx context.Context, print func(string)) error {
+		addr := net.JoinHostPort(host, strconv.Itoa(port))
+		dialCtx, cancel := context.WithTimeout(ctx, netDialTimeout)
+		defer cancel()
+		start := time.Now()
+		var d net.Dialer
+		conn, err := d.DialContext(dialCtx, "tcp", addr)
+		switch {
+		case err == nil:
+			conn.Close()
+			print(fmt.Sprintf("%s is open (%s)", addr, time.Since(start).Round(10*time.Microsecond)))
+		case errors.Is(err, context.DeadlineExceeded):
+			print(addr + " did not answer; it is probably filtered")
+		case strings.Contains(err.Error(), "refused"):
+			print(addr + " is closed")
+		default:
+			return err
+		}
+		return nil
+	}), nil
+}
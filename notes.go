@@ -0,0 +1,56 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// SaveNote creates a note when id is empty and otherwise replaces the text
+// of an existing one. Notes live in the history store so they share tags,
+// folders and favorites with responses.
+func (a *App) SaveNote(id, text string) (HistoryRecord, error) {
+	if a.history == nil {
+		return HistoryRecord{}, errors.New("history is unavailable")
+	}
+	var r HistoryRecord
+	var err error
+	if id == "" {
+		r = HistoryRecord{ID: newID(), Kind: "note", Text: text, CreatedAt: time.Now()}
+		err = a.history.put(r)
+	} else {
+		r, err = a.history.update(id, func(r *HistoryRecord) error {
+			if r.Kind != "note" {
+				return fmt.Errorf("record %q is not a note", id)
+			}
+			r.Text = text
+			return nil
+		})
+	}
+	if err != nil {
+		return r, err
+	}
+	eventNoteSaved.emit(a.ctx, r)
+	return r, nil
+}
+
+// DeleteNote removes a note
+func (a *App) DeleteNote(id string) error {
+	if a.history == nil {
+		return errors.New("history is unavailable")
+	}
+	r, ok := a.history.get(id)
+	if !ok || r.Kind != "note" {
+		return fmt.Errorf("no note %q", id)
+	}
+	if err := a.history.remove(id); err != nil {
+		return err
+	}
+	for _, att := range r.Attachments {
+		if att.Path != "" {
+			_ = os.Remove(att.Path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"golang.org/x/mod/semver"
+)
+
+// onboardingFile is kept out of syncedFiles: each install has its own
+// tour and release notes to see
+const onboardingFile = "onboarding.json"
+
+//go:embed changelog.json
+var changelogData []byte
+
+// onboardingSteps are the tour steps in the order they are shown
+var onboardingSteps = []OnboardingStep{
+	{ID: "hotkey", Title: "Open the overlay with the hotkey"},
+	{ID: "first-prompt", Title: "Ask your first question"},
+	{ID: "modes", Title: "Switch between chat, notes, favorites and clipboard"},
+	{ID: "shortcuts", Title: "Learn the overlay shortcuts"},
+	{ID: "permissions", Title: "Grant the permissions features need"},
+}
+
+// ReleaseNote is one release in changelog.json
+type ReleaseNote struct {
+	Version    string   `json:"version"`
+	Date       string   `json:"date"`
+	Highlights []string `json:"highlights"`
+}
+
+// OnboardingStep is one step of the first-run tour
+type OnboardingStep struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Done  bool   `json:"done"`
+}
+
+// Onboarding is the tour's progress and the release notes not yet seen
+type Onboarding struct {
+	Steps []OnboardingStep `json:"steps"`
+	// TourPending is true until every step is done or the tour is skipped
+	TourPending bool      `json:"tourPending"`
+	FirstRunAt  time.Time `json:"firstRunAt"`
+	// WhatsNew holds the releases since the notes were last seen, newest
+	// first
+	WhatsNew []ReleaseNote `json:"whatsNew"`
+}
+
+// onboardingState is what onboarding.json stores
+type onboardingState struct {
+	FirstRunAt     time.Time `json:"firstRunAt"`
+	CompletedSteps []string  `json:"completedSteps"`
+	TourSkipped    bool      `json:"tourSkipped"`
+	// SeenVersion is the version whose release notes were last seen
+	SeenVersion string `json:"seenVersion"`
+}
+
+var onboardingMu sync.Mutex
+
+// releaseNotes returns the embedded changelog, newest first
+func releaseNotes() []ReleaseNote {
+	var notes []ReleaseNote
+	if err := json.Unmarshal(changelogData, &notes); err != nil {
+		println("Error reading changelog:", err.Error())
+		return nil
+	}
+	slices.SortFunc(notes, func(a, b ReleaseNote) int {
+		return semver.Compare(canonicalVersion(b.Version), canonicalVersion(a.Version))
+	})
+	return notes
+}
+
+// unseenReleaseNotes returns the releases after seen up to current. A dev
+// build has no notes; an unknown seen version shows only current's.
+func unseenReleaseNotes(notes []ReleaseNote, seen, current string) []ReleaseNote {
+	unseen := []ReleaseNote{}
+	cur, last := canonicalVersion(current), canonicalVersion(seen)
+	if cur == "" {
+		return unseen
+	}
+	for _, n := range notes {
+		v := canonicalVersion(n.Version)
+		switch {
+		case v == "" || semver.Compare(v, cur) > 0:
+			continue
+		case last == "" && v != cur:
+			continue
+		case last != "" && semver.Compare(v, last) <= 0:
+			continue
+		}
+		unseen = append(unseen, n)
+	}
+	return unseen
+}
+
+// loadOnboarding reads onboarding.json, starting a first run when it is
+// missing. A fresh install has nothing new to see, so its notes count as
+// seen.
+func loadOnboarding() (onboardingState, error) {
+	var state onboardingState
+	if err := readJSONConfig(onboardingFile, &state); err != nil {
+		return state, err
+	}
+	if state.FirstRunAt.IsZero() {
+		state.FirstRunAt = time.Now()
+		state.SeenVersion = appVersion
+		if err := writeJSONConfig(onboardingFile, state, 0o644); err != nil {
+			return state, err
+		}
+	}
+	return state, nil
+}
+
+func (s onboardingState) onboarding() Onboarding {
+	o := Onboarding{FirstRunAt: s.FirstRunAt, WhatsNew: unseenReleaseNotes(releaseNotes(), s.SeenVersion, appVersion)}
+	for _, step := range onboardingSteps {
+		step.Done = slices.Contains(s.CompletedSteps, step.ID)
+		o.TourPending = o.TourPending || !step.Done
+		o.Steps = append(o.Steps, step)
+	}
+	o.TourPending = o.TourPending && !s.TourSkipped
+	return o
+}
+
+// updateOnboarding applies fn to the saved state and sends the result in
+// an onboarding-updated event
+func (a *App) updateOnboarding(fn func(*onboardingState)) error {
+	onboardingMu.Lock()
+	state, err := loadOnboarding()
+	if err != nil {
+		onboardingMu.Unlock()
+		return err
+	}
+	fn(&state)
+	err = writeJSONConfig(onboardingFile, state, 0o644)
+	onboardingMu.Unlock()
+	if err != nil {
+		return err
+	}
+	eventOnboarding.emit(a.ctx, state.onboarding())
+	return nil
+}
+
+// GetOnboarding returns the tour's progress and the unseen release notes
+func (a *App) GetOnboarding() (Onboarding, error) {
+	onboardingMu.Lock()
+	defer onboardingMu.Unlock()
+	state, err := loadOnboarding()
+	return state.onboarding(), err
+}
+
+// CompleteOnboardingStep marks a tour step done
+func (a *App) CompleteOnboardingStep(id string) error {
+	if !slices.ContainsFunc(onboardingSteps, func(s OnboardingStep) bool { return s.ID == id }) {
+		return fmt.Errorf("unknown onboarding step %q", id)
+	}
+	return a.updateOnboarding(func(s *onboardingState) {
+		if !slices.Contains(s.CompletedSteps, id) {
+			s.CompletedSteps = append(s.CompletedSteps, id)
+		}
+	})
+}
+
+// SkipOnboarding ends the tour without finishing it
+func (a *App) SkipOnboarding() error {
+	return a.updateOnboarding(func(s *onboardingState) { s.TourSkipped = true })
+}
+
+// ResetOnboarding starts the tour over
+func (a *App) ResetOnboarding() error {
+	return a.updateOnboarding(func(s *onboardingState) {
+		s.CompletedSteps = nil
+		s.TourSkipped = false
+	})
+}
+
+// MarkReleaseNotesSeen stops showing the notes up to this version
+func (a *App) MarkReleaseNotesSeen() error {
+	return a.updateOnboarding(func(s *onboardingState) { s.SeenVersion = appVersion })
+}
+
+// GetReleaseNotes returns every release in the changelog, newest first
+func (a *App) GetReleaseNotes() []ReleaseNote {
+	return releaseNotes()
+}
+
+// startupOnboarding sends a whats-new event when the app was updated since
+// the notes were last seen
+func (a *App) startupOnboarding() {
+	o, err := a.GetOnboarding()
+	if err != nil {
+		println("Error loading onboarding:", err.Error())
+		return
+	}
+	if len(o.WhatsNew) > 0 {
+		eventWhatsNew.emit(a.ctx, o.WhatsNew)
+	}
+}
@@ -0,0 +1,16 @@
+package main
+
+import "os/exec"
+
+func openFile(path string) error {
+	return exec.Command("open", path).Run()
+}
+
+// appExecutable is unused on macOS; apps launch by bundle identifier
+func appExecutable(ActiveApp) string {
+	return ""
+}
+
+func launchApp(item RecentItem) error {
+	return exec.Command("open", "-b", item.ID).Run()
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strconv"
+)
+
+func openFile(path string) error {
+	return exec.Command("xdg-open", path).Start()
+}
+
+// appExecutable resolves the app's binary while it is still running; the
+// WM_CLASS in its ID can't be launched
+func appExecutable(app ActiveApp) string {
+	exe, _ := os.Readlink("/proc/" + strconv.Itoa(app.PID) + "/exe")
+	return exe
+}
+
+// launchApp starts the app again; most apps forward a second launch to
+// the running instance
+func launchApp(item RecentItem) error {
+	if item.Path == "" {
+		return errors.New("the app's location is unknown")
+	}
+	cmd := exec.Command(item.Path)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
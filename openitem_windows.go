@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+func shellOpen(path string) error {
+	verb, _ := syscall.UTF16PtrFromString("open")
+	file, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return err
+	}
+	// ShellExecute returns a value above 32 on success
+	if r, _, _ := procShellExecuteW.Call(0, uintptr(unsafe.Pointer(verb)), uintptr(unsafe.Pointer(file)), 0, 0, 1); r <= 32 {
+		return fmt.Errorf("could not open %s (error %d)", path, r)
+	}
+	return nil
+}
+
+func openFile(path string) error {
+	return shellOpen(path)
+}
+
+// appExecutable is the ID, which on Windows is the executable path
+func appExecutable(app ActiveApp) string {
+	return app.ID
+}
+
+func launchApp(item RecentItem) error {
+	return shellOpen(item.Path)
+}
@@ -0,0 +1,86 @@
+package main
+
+import "strings"
+
+const (
+	// clipboardMode opens the overlay on the clipboard contents
+	clipboardMode = "clipboard"
+	// noteMode saves what is typed as a note instead of sending it
+	noteMode = "note"
+)
+
+// overlayVariant is the show-overlay hotkey with extra modifiers, opening
+// the overlay straight into another mode
+type overlayVariant struct {
+	// name is the binding name, which Settings.Hotkeys can override
+	name      string
+	modifiers string
+	mode      string
+}
+
+// overlayVariants follow the show-overlay shortcut, so with the default
+// Cmd+G they are Cmd+Shift+G, Cmd+Alt+G and Cmd+Alt+Shift+G
+var overlayVariants = []overlayVariant{
+	{name: "show-clipboard", modifiers: "Shift", mode: clipboardMode},
+	{name: "favorites", modifiers: "Alt", mode: favoritesMode},
+	{name: "show-note", modifiers: "Alt+Shift", mode: noteMode},
+}
+
+// variantSpec adds modifiers to spec, e.g. "CmdOrCtrl+G" with "Shift" is
+// "CmdOrCtrl+Shift+G"
+func variantSpec(spec, modifiers string) string {
+	if spec == "" {
+		return ""
+	}
+	// Search before the last character, which may be a "+" key
+	i := strings.LastIndex(spec[:len(spec)-1], "+")
+	return spec[:i+1] + modifiers + "+" + spec[i+1:]
+}
+
+// bindOverlayVariants registers a hotkey per variant of the show-overlay
+// shortcut
+func (a *App) bindOverlayVariants() {
+	base := a.hotkeySpec("show-overlay", defaultHotkeys["show-overlay"])
+	for _, v := range overlayVariants {
+		_ = a.bindHotkey(v.name, variantSpec(base, v.modifiers), func() {
+			a.emitShowVariant(v)
+		})
+	}
+}
+
+// followOverlayVariants moves the variants without an override of their
+// own onto a new show-overlay shortcut
+func (a *App) followOverlayVariants(base string) {
+	overrides := a.GetSettings().Hotkeys
+	a.hotkeysMu.Lock()
+	defer a.hotkeysMu.Unlock()
+	for _, v := range overlayVariants {
+		if overrides[v.name] != "" {
+			continue
+		}
+		for _, b := range a.hotkeys {
+			if b.name != v.name {
+				continue
+			}
+			b.unregister()
+			b.spec = variantSpec(base, v.modifiers)
+			if a.activeLocked(b) {
+				if err := b.register(); err != nil {
+					println("Error registering hotkey", v.name+":", err.Error())
+				}
+			}
+		}
+	}
+}
+
+// emitShowVariant opens the overlay for the pressed variant. The plain
+// show-overlay hotkey has no mode of its own and keeps the one context
+// rules pick.
+func (a *App) emitShowVariant(v overlayVariant) {
+	ev := a.showOverlayEvent("hotkey")
+	ev.Variant = v.name
+	if v.mode != "" {
+		ev.Context.Mode = v.mode
+	}
+	eventShowOverlay.emit(a.ctx, ev)
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"crypto/rand"
+	_ "embed"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+//go:embed wordlist.txt
+var wordlistData string
+
+var wordlist = strings.Fields(wordlistData)
+
+const (
+	lowerChars     = "abcdefghijklmnopqrstuvwxyz"
+	upperChars     = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digitChars     = "0123456789"
+	symbolChars    = "!@#$%^&*()-_=+[]{};:,.?/"
+	ambiguousChars = "Il1O0o|"
+)
+
+// PasswordOptions controls the character classes used by GeneratePassword
+type PasswordOptions struct {
+	Length           int  `json:"length"`
+	Lowercase        bool `json:"lowercase"`
+	Uppercase        bool `json:"uppercase"`
+	Digits           bool `json:"digits"`
+	Symbols          bool `json:"symbols"`
+	ExcludeAmbiguous bool `json:"excludeAmbiguous"`
+}
+
+// PassphraseOptions controls the shape of a diceware passphrase
+type PassphraseOptions struct {
+	Words         int    `json:"words"`
+	Separator     string `json:"separator"`
+	Capitalize    bool   `json:"capitalize"`
+	IncludeNumber bool   `json:"includeNumber"`
+}
+
+// GeneratedSecret is a generated password or passphrase together with
+// its estimated strength in bits
+type GeneratedSecret struct {
+	Value   string  `json:"value"`
+	Entropy float64 `json:"entropy"`
+}
+
+// GeneratePassword returns a random password drawn from the selected
+// character classes, with at least one character from each class
+func (a *App) GeneratePassword(opts PasswordOptions) (GeneratedSecret, error) {
+	if opts.Length < 4 || opts.Length > 256 {
+		return GeneratedSecret{}, fmt.Errorf("password length must be between 4 and 256")
+	}
+
+	var classes []string
+	for _, c := range []struct {
+		enabled bool
+		chars   string
+	}{
+		{opts.Lowercase, lowerChars},
+		{opts.Uppercase, upperChars},
+		{opts.Digits, digitChars},
+		{opts.Symbols, symbolChars},
+	} {
+		if !c.enabled {
+			continue
+		}
+		chars := c.chars
+		if opts.ExcludeAmbiguous {
+			chars = stripChars(chars, ambiguousChars)
+		}
+		classes = append(classes, chars)
+	}
+	if len(classes) == 0 {
+		return GeneratedSecret{}, fmt.Errorf("at least one character class must be selected")
+	}
+	if opts.Length < len(classes) {
+		return GeneratedSecret{}, fmt.Errorf("password length is shorter than the number of character classes")
+	}
+
+	pool := strings.Join(classes, "")
+	out := make([]byte, 0, opts.Length)
+	for _, chars := range classes {
+		b, err := randomChar(chars)
+		if err != nil {
+			return GeneratedSecret{}, err
+		}
+		out = append(out, b)
+	}
+	for len(out) < opts.Length {
+		b, err := randomChar(pool)
+		if err != nil {
+			return GeneratedSecret{}, err
+		}
+		out = append(out, b)
+	}
+	if err := shuffle(len(out), func(i, j int) { out[i], out[j] = out[j], out[i] }); err != nil {
+		return GeneratedSecret{}, err
+	}
+
+	return GeneratedSecret{
+		Value:   string(out),
+		Entropy: float64(opts.Length) * math.Log2(float64(len(pool))),
+	}, nil
+}
+
+// GeneratePassphrase returns a diceware-style passphrase built from the
+// embedded wordlist
+func (a *App) GeneratePassphrase(opts PassphraseOptions) (GeneratedSecret, error) {
+	if opts.Words < 3 || opts.Words > 20 {
+		return GeneratedSecret{}, fmt.Errorf("passphrase must have between 3 and 20 words")
+	}
+	if opts.Separator == "" {
+		opts.Separator = "-"
+	}
+
+	words := make([]string, opts.Words)
+	for i := range words {
+		n, err := randomInt(len(wordlist))
+		if err != nil {
+			return GeneratedSecret{}, err
+		}
+		word := wordlist[n]
+		if opts.Capitalize {
+			word = strings.ToUpper(word[:1]) + word[1:]
+		}
+		words[i] = word
+	}
+
+	entropy := float64(opts.Words) * math.Log2(float64(len(wordlist)))
+	if opts.IncludeNumber {
+		n, err := randomInt(len(words))
+		if err != nil {
+			return GeneratedSecret{}, err
+		}
+		d, err := randomChar(digitChars)
+		if err != nil {
+			return GeneratedSecret{}, err
+		}
+		words[n] += string(d)
+		entropy += math.Log2(float64(len(words) * len(digitChars)))
+	}
+
+	return GeneratedSecret{
+		Value:   strings.Join(words, opts.Separator),
+		Entropy: entropy,
+	}, nil
+}
+
+// CopySecret puts value on the clipboard and clears it again after
+// clearAfterSeconds, unless the user has copied something else meanwhile.
+// A clearAfterSeconds of 0 disables the auto-clear.
+func (a *App) CopySecret(value string, clearAfterSeconds int) error {
+	a.markOwnClipboard(value)
+	if err := wailsruntime.ClipboardSetText(a.ctx, value); err != nil {
+		return err
+	}
+
+	a.clipboardMu.Lock()
+	defer a.clipboardMu.Unlock()
+
+	if a.clipboardClear != nil {
+		a.clipboardClear.Stop()
+		a.clipboardClear = nil
+	}
+	if clearAfterSeconds <= 0 {
+		return nil
+	}
+
+	a.clipboardClear = time.AfterFunc(time.Duration(clearAfterSeconds)*time.Second, func() {
+		current, err := wailsruntime.ClipboardGetText(a.ctx)
+		if err == nil && current == value {
+			_ = wailsruntime.ClipboardSetText(a.ctx, "")
+			eventClipboardCleared.emit(a.ctx, struct{}{})
+		}
+	})
+	return nil
+}
+
+func randomInt(n int) (int, error) {
+	v, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+	return int(v.Int64()), nil
+}
+
+func randomChar(chars string) (byte, error) {
+	n, err := randomInt(len(chars))
+	if err != nil {
+		return 0, err
+	}
+	return chars[n], nil
+}
+
+// shuffle is a Fisher-Yates shuffle driven by crypto/rand
+func shuffle(n int, swap func(i, j int)) error {
+	for i := n - 1; i > 0; i-- {
+		j, err := randomInt(i + 1)
+		if err != nil {
+			return err
+		}
+		swap(i, j)
+	}
+	return nil
+}
+
+func stripChars(s, remove string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(remove, r) {
+			return -1
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"time"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	// pasteHotkeyDelay lets the hotkey's own modifiers be released before
+	// the paste shortcut is injected
+	pasteHotkeyDelay = 150 * time.Millisecond
+	// clipboardRestoreDelay leaves the target app time to read the
+	// clipboard before the previous contents go back
+	clipboardRestoreDelay = 500 * time.Millisecond
+)
+
+// lastResponse returns the text of the newest response in history
+func (a *App) lastResponse() (string, bool) {
+	if a.history == nil {
+		return "", false
+	}
+	responses := a.history.list(func(r HistoryRecord) bool { return r.Kind == "response" && r.Text != "" })
+	if len(responses) == 0 {
+		return "", false
+	}
+	return responses[len(responses)-1].Text, true
+}
+
+// PasteLastResponse pastes the most recent response into the frontmost
+// app without opening the overlay. The clipboard is borrowed for the paste
+// and then restored.
+func (a *App) PasteLastResponse() error {
+	if err := a.requirePermission(capKeystrokes); err != nil {
+		return err
+	}
+	text, ok := a.lastResponse()
+	if !ok {
+		return errors.New("there is no response to paste yet")
+	}
+	return a.pasteText(text)
+}
+
+// pasteText pastes text into the frontmost app, borrowing the clipboard
+func (a *App) pasteText(text string) error {
+	prev, _ := wailsruntime.ClipboardGetText(a.ctx)
+	a.markOwnClipboard(text)
+	a.markOwnClipboard(prev)
+	if err := wailsruntime.ClipboardSetText(a.ctx, text); err != nil {
+		return err
+	}
+	time.Sleep(pasteHotkeyDelay)
+	if err := sendPaste(); err != nil {
+		_ = wailsruntime.ClipboardSetText(a.ctx, prev)
+		return err
+	}
+	time.AfterFunc(clipboardRestoreDelay, func() {
+		_ = wailsruntime.ClipboardSetText(a.ctx, prev)
+	})
+	return nil
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"io"
+)
+
+// A4 in PDF points, with the margin kept clear on every side
+const (
+	pdfPageWidth  = 595
+	pdfPageHeight = 842
+	pdfMargin     = 36
+)
+
+// pdfPage is the part of a rendered image shown on one page
+type pdfPage struct {
+	img   image.Image
+	rect  image.Rectangle
+	scale float64
+}
+
+// paginate splits img into A4 pages, scaled to the printable width. Page
+// breaks are moved up to the nearest blank row so lines of text are not
+// cut in half.
+func paginate(img image.Image) []pdfPage {
+	b := img.Bounds()
+	scale := float64(b.Dx()) / (pdfPageWidth - 2*pdfMargin)
+	pageRows := int(float64(pdfPageHeight-2*pdfMargin) * scale)
+
+	var pages []pdfPage
+	for top := b.Min.Y; top < b.Max.Y; {
+		bottom := min(top+pageRows, b.Max.Y)
+		if bottom < b.Max.Y {
+			for y := bottom; y > bottom-pageRows/5; y-- {
+				if blankRow(img, y) {
+					bottom = y
+					break
+				}
+			}
+		}
+		pages = append(pages, pdfPage{img: img, rect: image.Rect(b.Min.X, top, b.Max.X, bottom), scale: scale})
+		top = bottom
+	}
+	return pages
+}
+
+// writeImagePDF writes imgs as a PDF, each starting on a new page
+func writeImagePDF(w io.Writer, imgs ...image.Image) error {
+	var pages []pdfPage
+	for _, img := range imgs {
+		pages = append(pages, paginate(img)...)
+	}
+
+	// Objects: 1 catalog, 2 page tree, then page, content and image for
+	// each page
+	var out bytes.Buffer
+	var offsets []int
+	obj := func(body string, stream []byte) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\n", len(offsets), body)
+		if stream != nil {
+			out.WriteString("stream\n")
+			out.Write(stream)
+			out.WriteString("\nendstream\n")
+		}
+		out.WriteString("endobj\n")
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	obj("<< /Type /Catalog /Pages 2 0 R >>", nil)
+	kids := ""
+	for i := range pages {
+		kids += fmt.Sprintf("%d 0 R ", 3+3*i)
+	}
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", kids, len(pages)), nil)
+
+	for i, p := range pages {
+		r := p.rect
+		pixels, err := deflateRGB(p.img, r)
+		if err != nil {
+			return err
+		}
+		n := 3 + 3*i
+		wpt, hpt := float64(r.Dx())/p.scale, float64(r.Dy())/p.scale
+		content := fmt.Sprintf("q %.2f 0 0 %.2f %d %.2f cm /Im0 Do Q", wpt, hpt, pdfMargin, pdfPageHeight-pdfMargin-hpt)
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Contents %d 0 R /Resources << /XObject << /Im0 %d 0 R >> >> >>",
+			pdfPageWidth, pdfPageHeight, n+1, n+2), nil)
+		obj(fmt.Sprintf("<< /Length %d >>", len(content)), []byte(content))
+		obj(fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /FlateDecode /Length %d >>",
+			r.Dx(), r.Dy(), len(pixels)), pixels)
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	_, err := w.Write(out.Bytes())
+	return err
+}
+
+// blankRow reports whether row y is a single colour, i.e. between lines
+func blankRow(img image.Image, y int) bool {
+	b := img.Bounds()
+	first := img.At(b.Min.X, y)
+	for x := b.Min.X + 1; x < b.Max.X; x++ {
+		if img.At(x, y) != first {
+			return false
+		}
+	}
+	return true
+}
+
+func deflateRGB(img image.Image, r image.Rectangle) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	row := make([]byte, 0, 3*r.Dx())
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		row = row[:0]
+		for x := r.Min.X; x < r.Max.X; x++ {
+			cr, cg, cb, _ := img.At(x, y).RGBA()
+			row = append(row, byte(cr>>8), byte(cg>>8), byte(cb>>8))
+		}
+		if _, err := zw.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
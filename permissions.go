@@ -0,0 +1,147 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// Capabilities that need an explicit grant before the app uses them
+const (
+	capScreenCapture = "screen-capture"
+	capKeystrokes    = "keystrokes"
+	capShell         = "shell"
+	capFiles         = "files"
+	capWindows       = "windows"
+	capProcesses     = "processes"
+)
+
+// permissionPrompts explains each capability in the grant dialog
+var permissionPrompts = map[string]string{
+	capScreenCapture: "take screenshots and screen recordings",
+	capKeystrokes:    "type and paste into other apps",
+	capShell:         "run the commands configured in settings",
+	capFiles:         "read and save files in folders outside its own",
+	capWindows:       "move and resize other apps' windows",
+	capProcesses:     "quit and force quit other apps",
+}
+
+// permissionsFile keeps the grants of this install. It is not in
+// syncedFiles, so neither a sync remote nor another device can grant
+// anything here.
+const permissionsFile = "permissions.json"
+
+// loadGrants reads permissions.json; a missing file grants nothing
+func loadGrants() (map[string]bool, error) {
+	grants := map[string]bool{}
+	err := readJSONConfig(permissionsFile, &grants)
+	return grants, err
+}
+
+// errPermissionDenied is wrapped by every failed permission check
+var errPermissionDenied = errors.New("permission not granted")
+
+// PermissionStatus is one capability and whether it has been granted
+type PermissionStatus struct {
+	Capability  string `json:"capability"`
+	Description string `json:"description"`
+	Granted     bool   `json:"granted"`
+}
+
+// requirePermission fails unless capability has been granted. Entry points
+// that capture, inject, run or write call it before doing so.
+func (a *App) requirePermission(capability string) error {
+	a.grantsMu.Lock()
+	granted := a.grants[capability]
+	a.grantsMu.Unlock()
+	if granted {
+		return nil
+	}
+	return fmt.Errorf("%w: %s; call RequestPermission first", errPermissionDenied, capability)
+}
+
+// setPermission records a grant or revocation
+func (a *App) setPermission(capability string, granted bool) error {
+	a.grantsMu.Lock()
+	defer a.grantsMu.Unlock()
+	// Copy so maps handed out earlier are not changed under them
+	perms := maps.Clone(a.grants)
+	if perms == nil {
+		perms = map[string]bool{}
+	}
+	if granted {
+		perms[capability] = true
+	} else {
+		delete(perms, capability)
+	}
+	if err := writeJSONConfig(permissionsFile, perms, 0o600); err != nil {
+		return err
+	}
+	a.grants = perms
+	return nil
+}
+
+// RequestPermission asks the user to grant capability, unless it already
+// has been, and reports whether it is granted. A refusal is not
+// remembered, so the next request asks again.
+func (a *App) RequestPermission(capability string) (bool, error) {
+	prompt, ok := permissionPrompts[capability]
+	if !ok {
+		return false, fmt.Errorf("unknown capability %q", capability)
+	}
+	if a.requirePermission(capability) == nil {
+		return true, nil
+	}
+	choice, err := wailsruntime.MessageDialog(a.ctx, wailsruntime.MessageDialogOptions{
+		Type:          wailsruntime.QuestionDialog,
+		Title:         "Allow Access?",
+		Message:       "Allow overlae to " + prompt + "? You can revoke this in settings.",
+		Buttons:       []string{"Allow", "Don't Allow"},
+		DefaultButton: "Don't Allow",
+		CancelButton:  "Don't Allow",
+	})
+	// Windows reports Yes/No for two-button question dialogs
+	if err != nil || (choice != "Allow" && choice != "Yes") {
+		return false, err
+	}
+	return true, a.setPermission(capability, true)
+}
+
+// RevokePermission withdraws a grant; features that need it fail until it
+// is requested again
+func (a *App) RevokePermission(capability string) error {
+	if _, ok := permissionPrompts[capability]; !ok {
+		return fmt.Errorf("unknown capability %q", capability)
+	}
+	if capability == capShell {
+		a.stopWakeWordListener()
+	}
+	return a.setPermission(capability, false)
+}
+
+// GetPermissions lists every capability and whether it is granted
+func (a *App) GetPermissions() []PermissionStatus {
+	a.grantsMu.Lock()
+	granted := a.grants
+	a.grantsMu.Unlock()
+	var out []PermissionStatus
+	for _, c := range []string{capScreenCapture, capKeystrokes, capShell, capFiles, capWindows, capProcesses} {
+		out = append(out, PermissionStatus{Capability: c, Description: permissionPrompts[c], Granted: granted[c]})
+	}
+	return out
+}
+
+// withPermission runs a hotkey action after asking for capability, since
+// the key press itself is the user's intent to use it
+func (a *App) withPermission(capability string, fn func() error) error {
+	ok, err := a.RequestPermission(capability)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: %s", errPermissionDenied, capability)
+	}
+	return fn()
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+const personasFile = "personas.json"
+
+// Persona is a named mode such as "code reviewer" or "translator": a
+// system prompt and optionally a model, applied to every prompt of the
+// session it is active in
+type Persona struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	SystemPrompt string `json:"systemPrompt"`
+	// Model replaces the provider's model when set; a template's own
+	// model still wins
+	Model string `json:"model,omitempty"`
+	// Icon is an emoji or icon name shown in the overlay
+	Icon string `json:"icon,omitempty"`
+}
+
+func (a *App) loadPersonasLocked() ([]Persona, error) {
+	var personas []Persona
+	err := readJSONConfig(personasFile, &personas)
+	return personas, err
+}
+
+// ListPersonas returns the saved personas by name
+func (a *App) ListPersonas() ([]Persona, error) {
+	a.personasMu.Lock()
+	defer a.personasMu.Unlock()
+	personas, err := a.loadPersonasLocked()
+	if personas == nil {
+		personas = []Persona{}
+	}
+	return personas, err
+}
+
+func (a *App) persona(id string) (Persona, error) {
+	personas, err := a.ListPersonas()
+	if err != nil {
+		return Persona{}, err
+	}
+	i := slices.IndexFunc(personas, func(p Persona) bool { return p.ID == id })
+	if i < 0 {
+		return Persona{}, fmt.Errorf("no persona %q", id)
+	}
+	return personas[i], nil
+}
+
+// SavePersona adds p, or replaces the persona with the same ID
+func (a *App) SavePersona(p Persona) (Persona, error) {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" || strings.TrimSpace(p.SystemPrompt) == "" {
+		return Persona{}, errors.New("a persona needs a name and a system prompt")
+	}
+	if p.ID == "" {
+		p.ID = newID()
+	}
+
+	a.personasMu.Lock()
+	defer a.personasMu.Unlock()
+	personas, err := a.loadPersonasLocked()
+	if err != nil {
+		return Persona{}, err
+	}
+	if i := slices.IndexFunc(personas, func(o Persona) bool { return o.ID == p.ID }); i >= 0 {
+		personas[i] = p
+	} else {
+		personas = append(personas, p)
+	}
+	sort.Slice(personas, func(i, j int) bool { return personas[i].Name < personas[j].Name })
+	return p, writeJSONConfig(personasFile, personas, 0o644)
+}
+
+// DeletePersona removes the persona with id, switching it off in the
+// current session if it was active
+func (a *App) DeletePersona(id string) error {
+	a.personasMu.Lock()
+	personas, err := a.loadPersonasLocked()
+	n := len(personas)
+	if err == nil {
+		personas = slices.DeleteFunc(personas, func(p Persona) bool { return p.ID == id })
+		if len(personas) == n {
+			err = fmt.Errorf("no persona %q", id)
+		} else {
+			err = writeJSONConfig(personasFile, personas, 0o644)
+		}
+	}
+	a.personasMu.Unlock()
+	if err != nil {
+		return err
+	}
+	a.sessionMu.Lock()
+	if a.sessionPersonaID == id {
+		a.sessionPersonaID = ""
+	}
+	a.sessionMu.Unlock()
+	return nil
+}
+
+// SetSessionPersona makes id the persona of the current session; ""
+// switches back to none. A resumed session gets its last persona back.
+func (a *App) SetSessionPersona(id string) error {
+	if id != "" {
+		if _, err := a.persona(id); err != nil {
+			return err
+		}
+	}
+	a.sessionMu.Lock()
+	a.sessionPersonaID = id
+	a.sessionMu.Unlock()
+	return nil
+}
+
+// GetSessionPersona returns the active persona, or nil
+func (a *App) GetSessionPersona() *Persona {
+	a.sessionMu.Lock()
+	id := a.sessionPersonaID
+	a.sessionMu.Unlock()
+	if id == "" {
+		return nil
+	}
+	p, err := a.persona(id)
+	if err != nil {
+		return nil
+	}
+	return &p
+}
+
+// withPersona layers the active persona under o: its system prompt comes
+// first and its model applies unless o names one. It returns the persona
+// ID for the history record.
+func (a *App) withPersona(o promptOverrides) (promptOverrides, string) {
+	p := a.GetSessionPersona()
+	if p == nil {
+		return o, ""
+	}
+	if o.Model == "" {
+		o.Model = p.Model
+	}
+	if o.System == "" {
+		o.System = p.SystemPrompt
+	} else {
+		o.System = p.SystemPrompt + "\n\n" + o.System
+	}
+	return o, p.ID
+}
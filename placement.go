@@ -0,0 +1,97 @@
+package main
+
+import "sync"
+
+const placementsFile = "window-placements.json"
+
+// placement is the overlay geometry remembered for one display. It is
+// stored in logical units relative to the display's work area so it
+// survives rearranging monitors and changing their scale.
+type placement struct {
+	OffsetX int `json:"offsetX"`
+	OffsetY int `json:"offsetY"`
+	Width   int `json:"width"`
+	Height  int `json:"height"`
+}
+
+var placementsMu sync.Mutex
+
+// rememberPlacement stores the overlay geometry for the display it is on
+func (a *App) rememberPlacement() {
+	frame, err := ownWindowFrame()
+	if err != nil {
+		return
+	}
+	displays, err := listDisplays()
+	if err != nil {
+		return
+	}
+	display, ok := displayFor(displays, frame)
+	if !ok {
+		return
+	}
+
+	placementsMu.Lock()
+	defer placementsMu.Unlock()
+	placements := map[string]placement{}
+	_ = readJSONConfig(placementsFile, &placements)
+	placements[display.ID] = placement{
+		OffsetX: display.fromFrame(frame.X - display.WorkArea.X),
+		OffsetY: display.fromFrame(frame.Y - display.WorkArea.Y),
+		Width:   display.fromFrame(frame.Width),
+		Height:  display.fromFrame(frame.Height),
+	}
+	_ = writeJSONConfig(placementsFile, placements, 0o644)
+}
+
+// placeOverlay positions the overlay on the display under the cursor,
+// restoring the geometry remembered for that display or centring it. It
+// reports false when the platform cannot position windows absolutely.
+func (a *App) placeOverlay() bool {
+	frame, err := ownWindowFrame()
+	if err != nil {
+		return false
+	}
+	displays, err := listDisplays()
+	if err != nil || len(displays) == 0 {
+		return false
+	}
+
+	display, ok := cursorDisplay(displays)
+	if !ok {
+		return false
+	}
+
+	placementsMu.Lock()
+	placements := map[string]placement{}
+	_ = readJSONConfig(placementsFile, &placements)
+	placementsMu.Unlock()
+
+	var target Rect
+	if p, ok := placements[display.ID]; ok {
+		target = clampInto(Rect{
+			X:      display.WorkArea.X + display.toFrame(p.OffsetX),
+			Y:      display.WorkArea.Y + display.toFrame(p.OffsetY),
+			Width:  display.toFrame(p.Width),
+			Height: display.toFrame(p.Height),
+		}, display.WorkArea)
+	} else {
+		// Keep the logical size the window has on the display it is on now
+		current, _ := displayFor(displays, frame)
+		width := display.toFrame(current.fromFrame(frame.Width))
+		height := display.toFrame(current.fromFrame(frame.Height))
+		target = centerIn(display.WorkArea, width, height)
+	}
+	return setOwnWindowFrame(target) == nil
+}
+
+// ResetOverlayPlacements forgets all remembered per-display positions
+func (a *App) ResetOverlayPlacements() error {
+	placementsMu.Lock()
+	defer placementsMu.Unlock()
+	err := writeJSONConfig(placementsFile, map[string]placement{}, 0o644)
+	if err == nil {
+		eventPlacementsReset.emit(a.ctx, struct{}{})
+	}
+	return err
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	// portableFlag and portableMarker, a file beside the executable, both
+	// turn on portable mode
+	portableFlag   = "--portable"
+	portableMarker = "overlae.portable"
+	// portableEnv carries the flag to child window processes
+	portableEnv = "OVERLAE_PORTABLE"
+	// portableDataDir holds config, history and cache beside the executable
+	portableDataDir = "overlae-data"
+)
+
+var (
+	portableOnce sync.Once
+	portableRoot string
+)
+
+// enablePortableFromArgs turns on portable mode for this process and its
+// children when args contain --portable, and returns args without it
+func enablePortableFromArgs(args []string) []string {
+	var rest []string
+	for _, arg := range args {
+		if arg == portableFlag {
+			os.Setenv(portableEnv, "1")
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return rest
+}
+
+// executableDir is the folder a user sees the app in: the one holding the
+// binary, or on macOS the one holding the .app bundle
+func executableDir() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	dir := filepath.Dir(exe)
+	if bundle := filepath.Dir(filepath.Dir(dir)); filepath.Base(dir) == "MacOS" && strings.HasSuffix(bundle, ".app") {
+		return filepath.Dir(bundle), nil
+	}
+	return dir, nil
+}
+
+// portableDir returns the data directory beside the executable in portable
+// mode, or "" when state lives in the OS locations. Secrets stay in the OS
+// keychain either way.
+func portableDir() string {
+	portableOnce.Do(func() {
+		dir, err := executableDir()
+		if err != nil {
+			return
+		}
+		_, statErr := os.Stat(filepath.Join(dir, portableMarker))
+		if os.Getenv(portableEnv) == "" && statErr != nil {
+			return
+		}
+		portableRoot = filepath.Join(dir, portableDataDir)
+	})
+	return portableRoot
+}
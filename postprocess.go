@@ -0,0 +1,176 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Post-processing steps, applied in the order PostProcessSettings lists
+// them
+const (
+	stepStripMarkdown = "strip-markdown"
+	stepTrim          = "trim"
+	stepRedact        = "redact"
+	stepMaxLength     = "max-length"
+)
+
+var postProcessSteps = []string{stepStripMarkdown, stepTrim, stepRedact, stepMaxLength}
+
+// redacted replaces text matching a redact pattern
+const redacted = "[redacted]"
+
+// PostProcessSettings cleans responses up before they are sent to the
+// overlay and stored. With steps other than trim, a response is not
+// streamed but sent once it is complete and processed.
+type PostProcessSettings struct {
+	// Steps are "strip-markdown", "trim", "redact" and "max-length", run
+	// in the order given; empty leaves responses as the model wrote them
+	Steps []string `json:"steps"`
+	// RedactPatterns are regular expressions the redact step replaces
+	// with [redacted], such as API keys or internal hostnames
+	RedactPatterns []string `json:"redactPatterns,omitempty"`
+	// MaxLength is the most characters the max-length step keeps
+	MaxLength int `json:"maxLength,omitempty"`
+}
+
+// SetPostProcessing validates and saves the pipeline
+func (a *App) SetPostProcessing(cfg PostProcessSettings) error {
+	for _, step := range cfg.Steps {
+		if !slices.Contains(postProcessSteps, step) {
+			return fmt.Errorf("unknown post-processing step %q", step)
+		}
+	}
+	for _, p := range cfg.RedactPatterns {
+		if _, err := regexp.Compile(p); err != nil {
+			return fmt.Errorf("invalid redact pattern %q: %w", p, err)
+		}
+	}
+	if cfg.MaxLength < 0 {
+		return errors.New("max length cannot be negative")
+	}
+	return a.updateSettings(func(s *Settings) { s.PostProcess = cfg })
+}
+
+// needsWholeText reports whether a step works on the whole response, so
+// streaming it chunk by chunk would show what the step removes
+func (cfg PostProcessSettings) needsWholeText() bool {
+	return slices.ContainsFunc(cfg.Steps, func(step string) bool { return step != stepTrim })
+}
+
+// postProcess runs text through the configured pipeline
+func (a *App) postProcess(text string) string {
+	return postProcess(text, a.GetSettings().PostProcess)
+}
+
+func postProcess(text string, cfg PostProcessSettings) string {
+	for _, step := range cfg.Steps {
+		switch step {
+		case stepStripMarkdown:
+			text = stripMarkdown(text)
+		case stepTrim:
+			text = trimResponse(text)
+		case stepRedact:
+			text = redact(text, cfg.RedactPatterns)
+		case stepMaxLength:
+			text = truncateRunes(text, cfg.MaxLength)
+		}
+	}
+	return text
+}
+
+var (
+	mdFence      = regexp.MustCompile("^\\s*(```|~~~)")
+	mdHeading    = regexp.MustCompile(`^\s{0,3}#{1,6}\s+`)
+	mdQuote      = regexp.MustCompile(`^\s{0,3}>\s?`)
+	mdBullet     = regexp.MustCompile(`^(\s*)[*+]\s+`)
+	mdRule       = regexp.MustCompile(`^\s{0,3}(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	mdImage      = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	mdLink       = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)[^)]*\)`)
+	mdBold       = regexp.MustCompile(`\*\*(\S(?:.*?\S)?)\*\*|__(\S(?:.*?\S)?)__`)
+	mdItalicStar = regexp.MustCompile(`\*(\S(?:[^*]*?\S)?)\*`)
+	// Underscores inside words, as in snake_case, are not emphasis
+	mdItalicUnder = regexp.MustCompile(`(^|[^\p{L}\p{N}_])_(\S(?:[^_]*?\S)?)_($|[^\p{L}\p{N}_])`)
+	mdStrike      = regexp.MustCompile(`~~(.+?)~~`)
+	mdCode        = regexp.MustCompile("`([^`]+)`")
+)
+
+// stripMarkdown turns Markdown into the plain text a reader would see:
+// code keeps its content, links keep their target in parentheses and
+// bullets become dashes
+func stripMarkdown(text string) string {
+	var out []string
+	inCode := false
+	for _, line := range strings.Split(text, "\n") {
+		if mdFence.MatchString(line) {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			out = append(out, line)
+			continue
+		}
+		if mdRule.MatchString(line) {
+			continue
+		}
+		line = mdHeading.ReplaceAllString(line, "")
+		line = mdQuote.ReplaceAllString(line, "")
+		line = mdBullet.ReplaceAllString(line, "$1- ")
+		line = mdImage.ReplaceAllString(line, "$1")
+		line = mdLink.ReplaceAllStringFunc(line, func(m string) string {
+			parts := mdLink.FindStringSubmatch(m)
+			if parts[1] == parts[2] {
+				return parts[1]
+			}
+			return parts[1] + " (" + parts[2] + ")"
+		})
+		line = mdCode.ReplaceAllString(line, "$1")
+		line = mdBold.ReplaceAllString(line, "$1$2")
+		line = mdItalicStar.ReplaceAllString(line, "$1")
+		line = mdItalicUnder.ReplaceAllString(line, "$1$2$3")
+		line = mdStrike.ReplaceAllString(line, "$1")
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
+
+var blankLines = regexp.MustCompile(`\n{3,}`)
+
+// trimResponse drops leading and trailing whitespace, trailing spaces on
+// each line and runs of blank lines
+func trimResponse(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimSpace(blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
+
+// redact replaces every match of patterns; invalid patterns are skipped,
+// since SetPostProcessing rejects them
+func redact(text string, patterns []string) string {
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			continue
+		}
+		text = re.ReplaceAllLiteralString(text, redacted)
+	}
+	return text
+}
+
+// truncateRunes keeps at most max characters, ending at a word boundary
+// when one is near, and marks the cut with an ellipsis
+func truncateRunes(text string, max int) string {
+	if max <= 0 || utf8.RuneCountInString(text) <= max {
+		return text
+	}
+	cut := string([]rune(text)[:max-1])
+	if i := strings.LastIndexAny(cut, " \n\t"); i > len(cut)*4/5 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " \n\t") + "…"
+}
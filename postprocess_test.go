@@ -0,0 +1,142 @@
+package main
+
+import "testing"
+
+func TestStripMarkdown(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"heading", "## Title", "Title"},
+		{"quote", "> quoted", "quoted"},
+		{"bullets become dashes", "* one\n  + two", "- one\n  - two"},
+		{"rule dropped", "above\n---\nbelow", "above\nbelow"},
+		{"bold and italic", "**bold** and *italic* and __also__", "bold and italic and also"},
+		{"snake_case kept", "call my_func_name now", "call my_func_name now"},
+		{"underscore emphasis", "an _emphasised_ word", "an emphasised word"},
+		{"strike", "~~gone~~ kept", "gone kept"},
+		{"inline code", "run `go test`", "run go test"},
+		{"link keeps target", "[docs](https://example.com)", "docs (https://example.com)"},
+		{"bare link", "[https://example.com](https://example.com)", "https://example.com"},
+		{"image keeps alt", "![a chart](chart.png)", "a chart"},
+		{"fenced code untouched", "```go\n**x** := 1\n```", "**x** := 1"},
+		{"plain text", "nothing to do", "nothing to do"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stripMarkdown(tt.in); got != tt.want {
+				t.Errorf("stripMarkdown(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrimResponse(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"  hello  ", "hello"},
+		{"a  \nb\t", "a\nb"},
+		{"a\n\n\n\nb", "a\n\nb"},
+		{"a\r\nb\r\n", "a\nb"},
+		{"\n\n", ""},
+	}
+	for _, tt := range tests {
+		if got := trimResponse(tt.in); got != tt.want {
+			t.Errorf("trimResponse(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		patterns []string
+		want     string
+	}{
+		{"no patterns", "sk-123", nil, "sk-123"},
+		{"every match", "sk-1 and sk-2", []string{`sk-\d+`}, "[redacted] and [redacted]"},
+		{"several patterns", "host db.internal key sk-1", []string{`\S+\.internal`, `sk-\d+`}, "host [redacted] key [redacted]"},
+		{"invalid pattern skipped", "sk-1", []string{`(`, `sk-\d+`}, "[redacted]"},
+		{"replacement is literal", "a", []string{`(a)`}, "[redacted]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redact(tt.in, tt.patterns); got != tt.want {
+				t.Errorf("redact(%q, %q) = %q, want %q", tt.in, tt.patterns, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncateRunes(t *testing.T) {
+	tests := []struct {
+		in   string
+		max  int
+		want string
+	}{
+		{"short", 10, "short"},
+		{"exactly", 7, "exactly"},
+		{"anything", 0, "anything"},
+		{"abcdefghij", 5, "abcd…"},
+		{"héllo wörld", 6, "héllo…"},
+		{"one two three four five six", 26, "one two three four five…"},
+		{"one twothreefourfivesix", 20, "one twothreefourfiv…"},
+	}
+	for _, tt := range tests {
+		if got := truncateRunes(tt.in, tt.max); got != tt.want {
+			t.Errorf("truncateRunes(%q, %d) = %q, want %q", tt.in, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestPostProcess(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		cfg  PostProcessSettings
+		want string
+	}{
+		{"no steps", "  **x**  ", PostProcessSettings{}, "  **x**  "},
+		{"strip then trim", "  **x**  \n\n\n", PostProcessSettings{Steps: []string{stepStripMarkdown, stepTrim}}, "x"},
+		{
+			name: "redact before max-length",
+			in:   "key sk-123456789",
+			cfg:  PostProcessSettings{Steps: []string{stepRedact, stepMaxLength}, RedactPatterns: []string{`sk-\d+`}, MaxLength: 20},
+			want: "key [redacted]",
+		},
+		{
+			// Cutting first leaves a partial key the pattern no longer matches
+			name: "max-length before redact",
+			in:   "key sk-123456789",
+			cfg:  PostProcessSettings{Steps: []string{stepMaxLength, stepRedact}, RedactPatterns: []string{`sk-\d{9}`}, MaxLength: 10},
+			want: "key sk-12…",
+		},
+		{"unknown steps ignored", "x", PostProcessSettings{Steps: []string{"shout"}}, "x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := postProcess(tt.in, tt.cfg); got != tt.want {
+				t.Errorf("postProcess(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNeedsWholeText(t *testing.T) {
+	tests := []struct {
+		steps []string
+		want  bool
+	}{
+		{nil, false},
+		{[]string{stepTrim}, false},
+		{[]string{stepStripMarkdown}, true},
+		{[]string{stepTrim, stepRedact}, true},
+		{[]string{stepMaxLength}, true},
+	}
+	for _, tt := range tests {
+		if got := (PostProcessSettings{Steps: tt.steps}).needsWholeText(); got != tt.want {
+			t.Errorf("needsWholeText(%q) = %v, want %v", tt.steps, got, tt.want)
+		}
+	}
+}
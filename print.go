@@ -0,0 +1,39 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// PrintRecords renders history records or notes as a paginated PDF, each
+// starting on a new page, and hands it to the system print dialog. The
+// webview's own printing only sees the frameless overlay window.
+func (a *App) PrintRecords(ids []string) error {
+	if len(ids) == 0 {
+		return errors.New("nothing to print")
+	}
+	docs := make([]exportDoc, len(ids))
+	for i, id := range ids {
+		doc, err := a.exportDocument(id)
+		if err != nil {
+			return err
+		}
+		docs[i] = doc
+	}
+	data, err := documentsPDF(docs...)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(os.TempDir(), "overlae")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	path := filepath.Join(dir, "print-"+time.Now().Format("20060102-150405.000")+".pdf")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return err
+	}
+	return printPDF(path)
+}
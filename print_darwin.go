@@ -0,0 +1,51 @@
+package main
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Cocoa -framework PDFKit
+#import <Cocoa/Cocoa.h>
+#import <PDFKit/PDFKit.h>
+#include <stdlib.h>
+
+static void ovOnMain(void (^block)(void)) {
+	if ([NSThread isMainThread]) block();
+	else dispatch_sync(dispatch_get_main_queue(), block);
+}
+
+// ovPrintPDF runs the print panel for the PDF at path; it returns 0 when
+// the file can't be opened
+static int ovPrintPDF(const char *path) {
+	__block int ok = 0;
+	ovOnMain(^{
+		@autoreleasepool {
+			NSURL *url = [NSURL fileURLWithPath:[NSString stringWithUTF8String:path]];
+			PDFDocument *doc = [[[PDFDocument alloc] initWithURL:url] autorelease];
+			if (!doc) return;
+			NSPrintOperation *op = [doc printOperationForPrintInfo:[NSPrintInfo sharedPrintInfo]
+				scalingMode:kPDFPrintPageScaleToFit autoRotate:YES];
+			op.showsPrintPanel = YES;
+			op.showsProgressPanel = YES;
+			[op runOperation];
+			ok = 1;
+		}
+	});
+	return ok;
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// printPDF shows the standard print panel; it returns once the user
+// prints or cancels
+func printPDF(path string) error {
+	cpath := C.CString(path)
+	defer C.free(unsafe.Pointer(cpath))
+	if C.ovPrintPDF(cpath) == 0 {
+		return fmt.Errorf("could not open %s for printing", path)
+	}
+	return nil
+}
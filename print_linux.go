@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// printTools are tried in order. The first ones open a print dialog
+// directly; a plain viewer is the fallback.
+var printTools = [][]string{
+	{"evince", "--preview"},
+	{"okular", "--print"},
+	{"xdg-open"},
+}
+
+// printPDF opens path in the first available print-capable viewer
+func printPDF(path string) error {
+	for _, tool := range printTools {
+		if _, err := exec.LookPath(tool[0]); err != nil {
+			continue
+		}
+		args := append(append([]string{}, tool[1:]...), path)
+		cmd := exec.Command(tool[0], args...)
+		if err := cmd.Start(); err != nil {
+			return err
+		}
+		go cmd.Wait()
+		return nil
+	}
+	return fmt.Errorf("no PDF viewer found to print with (install evince or okular)")
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	shell32           = syscall.NewLazyDLL("shell32.dll")
+	procShellExecuteW = shell32.NewProc("ShellExecuteW")
+)
+
+// printPDF asks the default PDF handler to print path, which shows its
+// print dialog
+func printPDF(path string) error {
+	verb, _ := syscall.UTF16PtrFromString("print")
+	file, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return err
+	}
+	// ShellExecute returns a value above 32 on success
+	if r, _, _ := procShellExecuteW.Call(0, uintptr(unsafe.Pointer(verb)), uintptr(unsafe.Pointer(file)), 0, 0, 1); r <= 32 {
+		return fmt.Errorf("no application is set up to print PDF files (error %d)", r)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// processSampleInterval is the wait between the two samples CPU usage
+	// is measured over when there is no recent earlier sample
+	processSampleInterval = 250 * time.Millisecond
+	// processSampleMaxAge is how old an earlier sample may be and still
+	// serve as the base for CPU usage
+	processSampleMaxAge = 10 * time.Second
+)
+
+// ProcessInfo is a running process. CPU is the percentage of one core
+// used since the previous sample, Memory the resident size in bytes.
+type ProcessInfo struct {
+	PID    int     `json:"pid"`
+	Name   string  `json:"name"`
+	Path   string  `json:"path,omitempty"`
+	CPU    float64 `json:"cpu"`
+	Memory uint64  `json:"memory"`
+}
+
+// processSample is what the platform reports for one process
+type processSample struct {
+	name   string
+	path   string
+	cpu    time.Duration
+	memory uint64
+}
+
+// ListProcesses returns the running processes, busiest first
+func (a *App) ListProcesses() ([]ProcessInfo, error) {
+	a.processMu.Lock()
+	defer a.processMu.Unlock()
+	prev, prevAt := a.processSamples, a.processSampledAt
+	if prev == nil || time.Since(prevAt) > processSampleMaxAge {
+		var err error
+		if prev, err = sampleProcesses(); err != nil {
+			return nil, err
+		}
+		prevAt = time.Now()
+		time.Sleep(processSampleInterval)
+	}
+	cur, err := sampleProcesses()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	a.processSamples, a.processSampledAt = cur, now
+
+	wall := now.Sub(prevAt)
+	out := []ProcessInfo{}
+	for pid, s := range cur {
+		p := ProcessInfo{PID: pid, Name: s.name, Path: s.path, Memory: s.memory}
+		if old, ok := prev[pid]; ok && wall > 0 && s.cpu >= old.cpu {
+			p.CPU = float64(s.cpu-old.cpu) / float64(wall) * 100
+		}
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].CPU != out[j].CPU {
+			return out[i].CPU > out[j].CPU
+		}
+		return out[i].Memory > out[j].Memory
+	})
+	return out, nil
+}
+
+// matchProcesses returns the processes whose executable path or name is
+// id, ignoring case and a Windows .exe suffix
+func matchProcesses(id string, procs map[int]processSample) []int {
+	want := strings.ToLower(strings.TrimSuffix(id, ".exe"))
+	var pids []int
+	for pid, s := range procs {
+		name := strings.ToLower(strings.TrimSuffix(s.name, ".exe"))
+		if strings.EqualFold(s.path, id) || name == want || strings.ToLower(strings.TrimSuffix(filepath.Base(s.path), ".exe")) == want {
+			pids = append(pids, pid)
+		}
+	}
+	sort.Ints(pids)
+	return pids
+}
+
+// QuitApp quits target, which is a PID or an app ID as ActiveApp reports
+// it: a bundle identifier on macOS, an executable path or name elsewhere.
+// Without force the app is asked to quit and may prompt to save; force
+// kills it at once.
+func (a *App) QuitApp(target string, force bool) error {
+	if err := a.requirePermission(capProcesses); err != nil {
+		return err
+	}
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return errors.New("choose an app to quit")
+	}
+	var pids []int
+	if pid, err := strconv.Atoi(target); err == nil {
+		pids = []int{pid}
+	} else {
+		procs, err := sampleProcesses()
+		if err != nil {
+			return err
+		}
+		if pids = appPIDs(target, procs); len(pids) == 0 {
+			return fmt.Errorf("%s is not running", target)
+		}
+	}
+
+	var errs []error
+	for _, pid := range pids {
+		// PID 1 and Windows' System process are never app processes
+		if pid <= 1 || pid == 4 || pid == os.Getpid() {
+			errs = append(errs, fmt.Errorf("process %d can't be quit", pid))
+			continue
+		}
+		if err := quitProcess(pid, force); err != nil {
+			errs = append(errs, fmt.Errorf("process %d: %w", pid, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// sampleProcesses parses ps, whose cumulative CPU time column is
+// [[dd-]hh:]mm:ss.cc
+func sampleProcesses() (map[int]processSample, error) {
+	out, err := exec.Command("ps", "-axo", "pid=,rss=,time=,comm=").Output()
+	if err != nil {
+		return nil, err
+	}
+	procs := map[int]processSample{}
+	for _, line := range strings.Split(string(out), "\n") {
+		f := strings.Fields(line)
+		if len(f) < 4 {
+			continue
+		}
+		pid, err := strconv.Atoi(f[0])
+		if err != nil {
+			continue
+		}
+		rss, _ := strconv.ParseUint(f[1], 10, 64)
+		// comm is the executable path and may contain spaces
+		path := strings.Join(f[3:], " ")
+		procs[pid] = processSample{name: filepath.Base(path), path: path, cpu: parseCPUTime(f[2]), memory: rss << 10}
+	}
+	return procs, nil
+}
+
+func parseCPUTime(s string) time.Duration {
+	var days int
+	if d, rest, ok := strings.Cut(s, "-"); ok {
+		days, _ = strconv.Atoi(d)
+		s = rest
+	}
+	var total float64
+	for _, part := range strings.Split(s, ":") {
+		n, _ := strconv.ParseFloat(part, 64)
+		total = total*60 + n
+	}
+	return time.Duration((total + float64(days)*86400) * float64(time.Second))
+}
+
+// appPIDs looks a bundle identifier up through System Events, falling back
+// to matching the executable
+func appPIDs(id string, procs map[int]processSample) []int {
+	script := fmt.Sprintf(`tell application "System Events" to get unix id of every process whose bundle identifier is %q`, id)
+	if out, err := exec.Command("osascript", "-e", script).Output(); err == nil {
+		var pids []int
+		for _, s := range strings.Split(strings.TrimSpace(string(out)), ", ") {
+			if pid, err := strconv.Atoi(s); err == nil {
+				pids = append(pids, pid)
+			}
+		}
+		if len(pids) > 0 {
+			return pids
+		}
+	}
+	return matchProcesses(id, procs)
+}
+
+// quitProcess asks an app to quit through Apple Events, as the Quit menu
+// item would; background processes get SIGTERM
+func quitProcess(pid int, force bool) error {
+	if force {
+		return syscall.Kill(pid, syscall.SIGKILL)
+	}
+	script := fmt.Sprintf(`tell application "System Events" to set b to bundle identifier of first process whose unix id is %d
+tell application id b to quit`, pid)
+	if exec.Command("osascript", "-e", script).Run() == nil {
+		return nil
+	}
+	return syscall.Kill(pid, syscall.SIGTERM)
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// clockTicks is USER_HZ, the unit of the CPU times in /proc, which is 100
+// on every architecture Linux supports today
+const clockTicks = 100
+
+// sampleProcesses reads /proc. Processes that exit while it is read are
+// skipped.
+func sampleProcesses() (map[int]processSample, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, err
+	}
+	page := uint64(os.Getpagesize())
+	procs := map[int]processSample{}
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue
+		}
+		dir := "/proc/" + e.Name() + "/"
+		stat, err := os.ReadFile(dir + "stat")
+		if err != nil {
+			continue
+		}
+		// The name is in parentheses and may itself contain spaces
+		open, end := strings.IndexByte(string(stat), '('), strings.LastIndexByte(string(stat), ')')
+		if open < 0 || end < open {
+			continue
+		}
+		fields := strings.Fields(string(stat[end+1:]))
+		if len(fields) < 13 {
+			continue
+		}
+		utime, _ := strconv.ParseUint(fields[11], 10, 64)
+		stime, _ := strconv.ParseUint(fields[12], 10, 64)
+		s := processSample{
+			name: string(stat[open+1 : end]),
+			cpu:  time.Duration(utime+stime) * time.Second / clockTicks,
+		}
+		if statm, err := os.ReadFile(dir + "statm"); err == nil {
+			if f := strings.Fields(string(statm)); len(f) > 1 {
+				rss, _ := strconv.ParseUint(f[1], 10, 64)
+				s.memory = rss * page
+			}
+		}
+		s.path, _ = os.Readlink(dir + "exe")
+		procs[pid] = s
+	}
+	return procs, nil
+}
+
+func appPIDs(id string, procs map[int]processSample) []int {
+	return matchProcesses(id, procs)
+}
+
+func quitProcess(pid int, force bool) error {
+	sig := syscall.SIGTERM
+	if force {
+		sig = syscall.SIGKILL
+	}
+	return syscall.Kill(pid, sig)
+}
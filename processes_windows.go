@@ -0,0 +1,102 @@
+package main
+
+import (
+	"errors"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+var (
+	procK32GetProcessMemoryInfo = kernel32.NewProc("K32GetProcessMemoryInfo")
+	procPostMessageW            = user32.NewProc("PostMessageW")
+)
+
+const (
+	processTerminate = 0x0001
+	wmClose          = 0x0010
+)
+
+type processMemoryCounters struct {
+	Cb                         uint32
+	PageFaultCount             uint32
+	PeakWorkingSetSize         uintptr
+	WorkingSetSize             uintptr
+	QuotaPeakPagedPoolUsage    uintptr
+	QuotaPagedPoolUsage        uintptr
+	QuotaPeakNonPagedPoolUsage uintptr
+	QuotaNonPagedPoolUsage     uintptr
+	PagefileUsage              uintptr
+	PeakPagefileUsage          uintptr
+}
+
+func filetimeDuration(ft syscall.Filetime) time.Duration {
+	return time.Duration(uint64(ft.HighDateTime)<<32|uint64(ft.LowDateTime)) * 100
+}
+
+// sampleProcesses walks a Toolhelp snapshot. Processes of other users and
+// protected ones can't be opened and are listed without times or memory.
+func sampleProcesses() (map[int]processSample, error) {
+	snap, err := syscall.CreateToolhelp32Snapshot(syscall.TH32CS_SNAPPROCESS, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer syscall.CloseHandle(snap)
+
+	procs := map[int]processSample{}
+	entry := syscall.ProcessEntry32{}
+	entry.Size = uint32(unsafe.Sizeof(entry))
+	for err = syscall.Process32First(snap, &entry); err == nil; err = syscall.Process32Next(snap, &entry) {
+		s := processSample{name: syscall.UTF16ToString(entry.ExeFile[:])}
+		if h, err := syscall.OpenProcess(processQueryLimitedInformation, false, entry.ProcessID); err == nil {
+			var creation, exit, kernel, user syscall.Filetime
+			if syscall.GetProcessTimes(h, &creation, &exit, &kernel, &user) == nil {
+				s.cpu = filetimeDuration(kernel) + filetimeDuration(user)
+			}
+			mem := processMemoryCounters{}
+			mem.Cb = uint32(unsafe.Sizeof(mem))
+			if r, _, _ := procK32GetProcessMemoryInfo.Call(uintptr(h), uintptr(unsafe.Pointer(&mem)), uintptr(mem.Cb)); r != 0 {
+				s.memory = uint64(mem.WorkingSetSize)
+			}
+			path := make([]uint16, syscall.MAX_PATH)
+			size := uint32(len(path))
+			if r, _, _ := procQueryFullProcessImageW.Call(uintptr(h), 0, uintptr(unsafe.Pointer(&path[0])), uintptr(unsafe.Pointer(&size))); r != 0 {
+				s.path = syscall.UTF16ToString(path[:size])
+			}
+			syscall.CloseHandle(h)
+		}
+		procs[int(entry.ProcessID)] = s
+	}
+	return procs, nil
+}
+
+func appPIDs(id string, procs map[int]processSample) []int {
+	return matchProcesses(id, procs)
+}
+
+// quitProcess closes the process' windows as the close button would, or
+// with force terminates it
+func quitProcess(pid int, force bool) error {
+	if force {
+		h, err := syscall.OpenProcess(processTerminate, false, uint32(pid))
+		if err != nil {
+			return err
+		}
+		defer syscall.CloseHandle(h)
+		return syscall.TerminateProcess(h, 1)
+	}
+	closed := 0
+	enumWindows(func(hwnd uintptr) bool {
+		if windowPID(hwnd) == uint32(pid) {
+			if visible, _, _ := procIsWindowVisible.Call(hwnd); visible != 0 {
+				procPostMessageW.Call(hwnd, wmClose, 0, 0)
+				closed++
+			}
+		}
+		return true
+	})
+	if closed == 0 {
+		return errors.New("it has no window to close; force quit it instead")
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"strings"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ContextPrivacy toggles which pieces of context may be injected into
+// outgoing prompts. Everything is off until the user opts in.
+type ContextPrivacy struct {
+	App         bool `json:"app"`
+	WindowTitle bool `json:"windowTitle"`
+	Selection   bool `json:"selection"`
+	Clipboard   bool `json:"clipboard"`
+	// Page covers the URL and title sent by the browser extension
+	Page bool `json:"page"`
+	// Workspace covers the project tree and files; see SetWorkspace
+	Workspace bool `json:"workspace"`
+}
+
+// ContextItem is one template variable and the value it resolves to
+type ContextItem struct {
+	Variable string `json:"variable"`
+	Value    string `json:"value"`
+	// Enabled is false when the privacy setting blocks the item; its
+	// variable then renders as an empty string
+	Enabled bool `json:"enabled"`
+	// Used reports whether the template references the variable at all
+	Used bool `json:"used"`
+}
+
+// PromptPreview is exactly what would be sent for a template
+type PromptPreview struct {
+	Prompt string        `json:"prompt"`
+	Items  []ContextItem `json:"items"`
+}
+
+// rememberShowContext keeps the context captured when the overlay was
+// summoned; once the overlay has focus the frontmost app is overlae itself
+func (a *App) rememberShowContext(show ShowContext) {
+	a.showMu.Lock()
+	a.lastShow = show
+	a.showMu.Unlock()
+}
+
+func (a *App) lastShowContext() ShowContext {
+	a.showMu.Lock()
+	defer a.showMu.Unlock()
+	return a.lastShow
+}
+
+// buildPrompt expands template against the context captured when the
+// overlay was last shown
+func (a *App) buildPrompt(template string) PromptPreview {
+	return a.expandPrompt(template, a.lastShowContext())
+}
+
+// expandPrompt expands {{app}}, {{window}}, {{selection}}, {{clipboard}},
+// {{url}}, {{page}}, {{tree}} and {{file:path}} in template, honouring the
+// privacy toggles
+func (a *App) expandPrompt(template string, show ShowContext) PromptPreview {
+	privacy := a.GetSettings().ContextPrivacy
+	var page PageContext
+	if show.Page != nil {
+		page = *show.Page
+	}
+	selection := show.Selection
+	if selection == "" {
+		selection = page.Selection
+	}
+
+	clipboard := ""
+	if privacy.Clipboard && strings.Contains(template, "{{clipboard}}") {
+		clipboard, _ = wailsruntime.ClipboardGetText(a.ctx)
+	}
+
+	items := []ContextItem{
+		{Variable: "app", Value: show.App.Name, Enabled: privacy.App},
+		{Variable: "window", Value: show.App.WindowTitle, Enabled: privacy.WindowTitle},
+		{Variable: "selection", Value: selection, Enabled: privacy.Selection},
+		{Variable: "clipboard", Value: clipboard, Enabled: privacy.Clipboard},
+		{Variable: "url", Value: page.URL, Enabled: privacy.Page},
+		{Variable: "page", Value: page.Title, Enabled: privacy.Page},
+	}
+	items = append(items, a.workspaceItems(template, privacy.Workspace)...)
+
+	var pairs []string
+	for i := range items {
+		item := &items[i]
+		placeholder := "{{" + item.Variable + "}}"
+		item.Used = strings.Contains(template, placeholder)
+		if !item.Enabled {
+			item.Value = ""
+		}
+		pairs = append(pairs, placeholder, item.Value)
+	}
+
+	return PromptPreview{
+		Prompt: strings.NewReplacer(pairs...).Replace(template),
+		Items:  items,
+	}
+}
+
+// PreviewPrompt shows the fully expanded prompt and every context item
+// before anything is sent
+func (a *App) PreviewPrompt(template string) PromptPreview {
+	return a.buildPrompt(template)
+}
+
+// SetContextPrivacy persists the per-item context injection toggles
+func (a *App) SetContextPrivacy(privacy ContextPrivacy) error {
+	return a.updateSettings(func(s *Settings) { s.ContextPrivacy = privacy })
+}
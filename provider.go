@@ -0,0 +1,205 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// providerCredentialsFile held the single API key of older versions; it
+// is moved into the keychain on first use
+const providerCredentialsFile = "provider-credentials.json"
+
+// ProviderSettings selects the model endpoint prompts are sent to. Any
+// OpenAI-compatible chat completions API works, including local servers
+// such as Ollama or LM Studio.
+type ProviderSettings struct {
+	Kind    string `json:"kind"`
+	BaseURL string `json:"baseUrl"`
+	Model   string `json:"model"`
+	// KeyID is the default API key; see ListProviderKeys
+	KeyID string `json:"keyId,omitempty"`
+}
+
+// ProviderCredentials are kept out of settings.json so they never sync
+type ProviderCredentials struct {
+	APIKey string `json:"apiKey"`
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+	// Images are data URLs sent with Content to multimodal models
+	Images []string `json:"-"`
+}
+
+// MarshalJSON sends a message with images as content parts, and a plain
+// one as a string for servers that only accept text
+func (m chatMessage) MarshalJSON() ([]byte, error) {
+	if len(m.Images) == 0 {
+		type plain chatMessage
+		return json.Marshal(plain(m))
+	}
+	type imageURL struct {
+		URL string `json:"url"`
+	}
+	type part struct {
+		Type     string    `json:"type"`
+		Text     string    `json:"text,omitempty"`
+		ImageURL *imageURL `json:"image_url,omitempty"`
+	}
+	parts := []part{{Type: "text", Text: m.Content}}
+	for _, url := range m.Images {
+		parts = append(parts, part{Type: "image_url", ImageURL: &imageURL{URL: url}})
+	}
+	return json.Marshal(struct {
+		Role    string `json:"role"`
+		Content []part `json:"content"`
+	}{m.Role, parts})
+}
+
+type chatRequest struct {
+	Model       string        `json:"model"`
+	Messages    []chatMessage `json:"messages"`
+	Temperature *float64      `json:"temperature,omitempty"`
+	MaxTokens   int           `json:"max_tokens,omitempty"`
+}
+
+// chatResult is a finished completion
+type chatResult struct {
+	Text         string
+	Model        string
+	FinishReason string
+}
+
+type provider interface {
+	// stream sends req and calls onDelta for every text chunk as it
+	// arrives. Cancelling ctx aborts the underlying HTTP request.
+	stream(ctx context.Context, req chatRequest, onDelta func(string)) (chatResult, error)
+	// listModels returns the model IDs the credentials can use
+	listModels(ctx context.Context) ([]string, error)
+}
+
+// SetProviderCredentials adds creds.APIKey as a new key and makes it the
+// default for the current provider
+func (a *App) SetProviderCredentials(creds ProviderCredentials) error {
+	key, err := a.AddProviderKey("Default", creds.APIKey)
+	if err != nil {
+		return err
+	}
+	return a.SetDefaultProviderKey(key.ID)
+}
+
+func newProvider(cfg ProviderSettings, creds ProviderCredentials) (provider, error) {
+	switch cfg.Kind {
+	case "", "openai":
+		base := cfg.BaseURL
+		if base == "" {
+			base = "https://api.openai.com/v1"
+		}
+		return &openAIProvider{baseURL: strings.TrimRight(base, "/"), apiKey: creds.APIKey}, nil
+	default:
+		return nil, fmt.Errorf("unknown provider %q", cfg.Kind)
+	}
+}
+
+// currentProvider builds the provider from the saved settings. The
+// returned settings have KeyID set to the key actually used.
+func (a *App) currentProvider() (provider, ProviderSettings, error) {
+	return a.providerFor(a.GetSettings().Provider)
+}
+
+// providerFor builds the provider for cfg, such as the settings with a
+// template's overrides applied
+func (a *App) providerFor(cfg ProviderSettings) (provider, ProviderSettings, error) {
+	keyID, apiKey, err := a.resolveProviderKey(cfg)
+	if err != nil {
+		return nil, cfg, err
+	}
+	cfg.KeyID = keyID
+	p, err := newProvider(cfg, ProviderCredentials{APIKey: apiKey})
+	return p, cfg, err
+}
+
+// openAIProvider speaks the chat completions API with server-sent events
+type openAIProvider struct {
+	baseURL string
+	apiKey  string
+}
+
+func (p *openAIProvider) stream(ctx context.Context, req chatRequest, onDelta func(string)) (chatResult, error) {
+	body, err := json.Marshal(struct {
+		chatRequest
+		Stream bool `json:"stream"`
+	}{req, true})
+	if err != nil {
+		return chatResult{}, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return chatResult{}, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "text/event-stream")
+	if p.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return chatResult{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return chatResult{}, fmt.Errorf("provider returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	result := chatResult{Model: req.Model}
+	var text strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok {
+			continue
+		}
+		if data == "[DONE]" {
+			break
+		}
+		var chunk struct {
+			Model   string `json:"model"`
+			Choices []struct {
+				Delta struct {
+					Content string `json:"content"`
+				} `json:"delta"`
+				FinishReason string `json:"finish_reason"`
+			} `json:"choices"`
+		}
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return chatResult{}, fmt.Errorf("invalid stream chunk: %w", err)
+		}
+		if chunk.Model != "" {
+			result.Model = chunk.Model
+		}
+		for _, c := range chunk.Choices {
+			if c.Delta.Content != "" {
+				text.WriteString(c.Delta.Content)
+				onDelta(c.Delta.Content)
+			}
+			if c.FinishReason != "" {
+				result.FinishReason = c.FinishReason
+			}
+		}
+	}
+	result.Text = text.String()
+	if err := scanner.Err(); err != nil {
+		return result, err
+	}
+	return result, ctx.Err()
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+const providerKeysFile = "provider-keys.json"
+
+// ProviderKey describes a stored API key. The key itself lives in the OS
+// keychain; only this metadata is written to the config dir.
+type ProviderKey struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+	// Provider is the ProviderSettings.Kind the key belongs to
+	Provider string `json:"provider"`
+	// Hint is the last four characters, enough to tell keys apart
+	Hint      string    `json:"hint"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// KeyUsage counts the responses received with a key
+type KeyUsage struct {
+	Requests   int       `json:"requests"`
+	Characters int       `json:"characters"`
+	LastUsed   time.Time `json:"lastUsed"`
+}
+
+// ProviderKeyInfo is a key with its status and usage
+type ProviderKeyInfo struct {
+	ProviderKey
+	// Default is the key new sessions use for its provider
+	Default bool `json:"default"`
+	// Selected is the key chosen for the current session only
+	Selected bool     `json:"selected"`
+	Usage    KeyUsage `json:"usage"`
+}
+
+func providerKind(kind string) string {
+	if kind == "" {
+		return "openai"
+	}
+	return kind
+}
+
+func providerKeyAccount(id string) string {
+	return "provider-key:" + id
+}
+
+// loadProviderKeysLocked reads the key list, first moving a key saved by
+// older versions in provider-credentials.json into the keychain
+func (a *App) loadProviderKeysLocked() ([]ProviderKey, error) {
+	var keys []ProviderKey
+	if err := readJSONConfig(providerKeysFile, &keys); err != nil {
+		return nil, err
+	}
+
+	var legacy ProviderCredentials
+	if err := readJSONConfig(providerCredentialsFile, &legacy); err != nil || legacy.APIKey == "" {
+		return keys, err
+	}
+	key, err := newProviderKey("Default", providerKind(a.GetSettings().Provider.Kind), legacy.APIKey)
+	if err != nil {
+		return nil, fmt.Errorf("moving the API key to the keychain: %w", err)
+	}
+	keys = append(keys, key)
+	if err := writeJSONConfig(providerKeysFile, keys, 0o644); err != nil {
+		return nil, err
+	}
+	if path, err := configPath(providerCredentialsFile); err == nil {
+		_ = os.Remove(path)
+	}
+	return keys, a.updateSettings(func(s *Settings) {
+		if s.Provider.KeyID == "" {
+			s.Provider.KeyID = key.ID
+		}
+	})
+}
+
+// newProviderKey stores apiKey in the keychain and returns its metadata
+func newProviderKey(label, provider, apiKey string) (ProviderKey, error) {
+	key := ProviderKey{ID: newID(), Label: label, Provider: provider, CreatedAt: time.Now()}
+	if n := len(apiKey); n > 4 {
+		key.Hint = apiKey[n-4:]
+	}
+	return key, setSecret(providerKeyAccount(key.ID), apiKey)
+}
+
+// ListProviderKeys returns every stored key with its usage
+func (a *App) ListProviderKeys() ([]ProviderKeyInfo, error) {
+	a.keysMu.Lock()
+	keys, err := a.loadProviderKeysLocked()
+	a.keysMu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	usage := map[string]KeyUsage{}
+	if a.history != nil {
+		for _, r := range a.history.list(func(r HistoryRecord) bool { return r.Kind == "response" && r.KeyID != "" }) {
+			u := usage[r.KeyID]
+			u.Requests++
+			u.Characters += len(r.Text)
+			u.LastUsed = r.CreatedAt
+			usage[r.KeyID] = u
+		}
+	}
+
+	defaultID := a.GetSettings().Provider.KeyID
+	selected := a.sessionKey()
+	infos := make([]ProviderKeyInfo, len(keys))
+	for i, k := range keys {
+		infos[i] = ProviderKeyInfo{ProviderKey: k, Default: k.ID == defaultID, Selected: k.ID == selected, Usage: usage[k.ID]}
+	}
+	return infos, nil
+}
+
+// AddProviderKey stores another API key for the current provider, e.g. a
+// work key next to a personal one. The first key becomes the default. The
+// key is checked in the background; see ValidateProviderKey.
+func (a *App) AddProviderKey(label, apiKey string) (ProviderKey, error) {
+	apiKey = strings.TrimSpace(apiKey)
+	if apiKey == "" {
+		return ProviderKey{}, errors.New("API key is empty")
+	}
+	label = strings.TrimSpace(label)
+	if label == "" {
+		label = "Key"
+	}
+
+	a.keysMu.Lock()
+	defer a.keysMu.Unlock()
+	keys, err := a.loadProviderKeysLocked()
+	if err != nil {
+		return ProviderKey{}, err
+	}
+	key, err := newProviderKey(label, providerKind(a.GetSettings().Provider.Kind), apiKey)
+	if err != nil {
+		return ProviderKey{}, err
+	}
+	if err := writeJSONConfig(providerKeysFile, append(keys, key), 0o644); err != nil {
+		_ = deleteSecret(providerKeyAccount(key.ID))
+		return ProviderKey{}, err
+	}
+	if err := a.updateSettings(func(s *Settings) {
+		if s.Provider.KeyID == "" {
+			s.Provider.KeyID = key.ID
+		}
+	}); err != nil {
+		return key, err
+	}
+	a.ValidateProviderKey(key.ID)
+	return key, nil
+}
+
+// RemoveProviderKey deletes a key from the keychain and the key list
+func (a *App) RemoveProviderKey(id string) error {
+	a.keysMu.Lock()
+	defer a.keysMu.Unlock()
+	keys, err := a.loadProviderKeysLocked()
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(keys, func(k ProviderKey) bool { return k.ID == id }) {
+		return fmt.Errorf("no provider key %q", id)
+	}
+	if err := deleteSecret(providerKeyAccount(id)); err != nil {
+		return err
+	}
+	keys = slices.DeleteFunc(keys, func(k ProviderKey) bool { return k.ID == id })
+	if err := writeJSONConfig(providerKeysFile, keys, 0o644); err != nil {
+		return err
+	}
+
+	a.sessionMu.Lock()
+	if a.sessionKeyID == id {
+		a.sessionKeyID = ""
+	}
+	a.sessionMu.Unlock()
+	if a.GetSettings().Provider.KeyID != id {
+		return nil
+	}
+	return a.updateSettings(func(s *Settings) { s.Provider.KeyID = "" })
+}
+
+// SetDefaultProviderKey picks the key sessions use unless they select
+// another one
+func (a *App) SetDefaultProviderKey(id string) error {
+	if _, err := a.providerKey(id); err != nil {
+		return err
+	}
+	return a.updateSettings(func(s *Settings) { s.Provider.KeyID = id })
+}
+
+// SelectSessionKey uses a key for the current session only; "" goes back
+// to the default. Starting or resuming a session clears the choice.
+func (a *App) SelectSessionKey(id string) error {
+	if id != "" {
+		if _, err := a.providerKey(id); err != nil {
+			return err
+		}
+	}
+	a.sessionMu.Lock()
+	a.sessionKeyID = id
+	a.sessionMu.Unlock()
+	return nil
+}
+
+func (a *App) sessionKey() string {
+	a.sessionMu.Lock()
+	defer a.sessionMu.Unlock()
+	return a.sessionKeyID
+}
+
+func (a *App) providerKey(id string) (ProviderKey, error) {
+	a.keysMu.Lock()
+	keys, err := a.loadProviderKeysLocked()
+	a.keysMu.Unlock()
+	if err != nil {
+		return ProviderKey{}, err
+	}
+	for _, k := range keys {
+		if k.ID == id {
+			return k, nil
+		}
+	}
+	return ProviderKey{}, fmt.Errorf("no provider key %q", id)
+}
+
+// resolveProviderKey picks the key for a request: the session's choice,
+// then the default, then any key for the provider. No key at all is fine
+// for local servers, which don't need one.
+func (a *App) resolveProviderKey(cfg ProviderSettings) (string, string, error) {
+	a.keysMu.Lock()
+	keys, err := a.loadProviderKeysLocked()
+	a.keysMu.Unlock()
+	if err != nil {
+		return "", "", err
+	}
+	kind := providerKind(cfg.Kind)
+	candidates := []string{a.sessionKey(), cfg.KeyID}
+	for _, k := range keys {
+		if k.Provider == kind {
+			candidates = append(candidates, k.ID)
+		}
+	}
+	for _, id := range candidates {
+		i := slices.IndexFunc(keys, func(k ProviderKey) bool { return k.ID == id && k.Provider == kind })
+		if id == "" || i < 0 {
+			continue
+		}
+		apiKey, err := getSecret(providerKeyAccount(id))
+		if err != nil {
+			return "", "", fmt.Errorf("reading key %q from the keychain: %w", keys[i].Label, err)
+		}
+		return id, apiKey, nil
+	}
+	return "", "", nil
+}
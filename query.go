@@ -0,0 +1,217 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// localResultsPerSource caps each local source so one can't crowd out the
+// rest
+const localResultsPerSource = 3
+
+// LocalResult is an instant answer from the machine itself. Kind is
+// "calculator", "command", "quicklink", "app", "file", "snippet" or
+// "history"; ID is what the matching open or run method takes.
+type LocalResult struct {
+	Kind     string `json:"kind"`
+	ID       string `json:"id,omitempty"`
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle,omitempty"`
+}
+
+// QueryLocalResults is the first phase of a query, sent in a
+// query-local-results event
+type QueryLocalResults struct {
+	Input   string        `json:"input"`
+	Results []LocalResult `json:"results"`
+	// EscalateInMs is when the query goes to the model on its own; zero
+	// means only EscalateQuery sends it
+	EscalateInMs int `json:"escalateInMs"`
+}
+
+// QueryEscalation is the second phase, sent in a query-escalated event:
+// the prompt request whose response events follow
+type QueryEscalation struct {
+	RequestID string `json:"requestId"`
+	// Reason is "confirmed" or "timeout"
+	Reason string `json:"reason"`
+}
+
+// pendingQuery is the latest query; typing replaces it
+type pendingQuery struct {
+	id        string
+	input     string
+	timer     *time.Timer
+	requestID string
+	// sending is closed once escalating has finished and err is set, so
+	// a second escalation waits for the first
+	sending chan struct{}
+	err     error
+}
+
+// Query looks input up locally first and may then send it to the model.
+// Local results arrive in a query-local-results event and the model's
+// request ID in a query-escalated event, both carrying the returned ID.
+// With QueryEscalateMs set the query escalates on its own unless a
+// calculation already answered it; otherwise EscalateQuery sends it.
+func (a *App) Query(input string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return "", errors.New("query is empty")
+	}
+	q := &pendingQuery{id: newRequestID(), input: input}
+	a.queryMu.Lock()
+	if prev := a.query; prev != nil && prev.timer != nil {
+		prev.timer.Stop()
+	}
+	a.query = q
+	a.queryMu.Unlock()
+
+	go func() {
+		results := a.localResults(input)
+		delay := a.GetSettings().QueryEscalateMs
+		if len(results) > 0 && results[0].Kind == "calculator" {
+			delay = 0
+		}
+		a.queryMu.Lock()
+		if a.query != q {
+			a.queryMu.Unlock()
+			return
+		}
+		if delay > 0 && q.sending == nil {
+			q.timer = time.AfterFunc(time.Duration(delay)*time.Millisecond, func() {
+				if _, err := a.escalate(q.id, "timeout"); err != nil {
+					println("Error escalating query:", err.Error())
+				}
+			})
+		}
+		a.queryMu.Unlock()
+		eventQueryLocal.respond(a.ctx, q.id, QueryLocalResults{Input: input, Results: results, EscalateInMs: delay}, nil)
+	}()
+	return q.id, nil
+}
+
+// EscalateQuery sends the query to the model now and returns the prompt's
+// request ID. Escalating twice returns the same request.
+func (a *App) EscalateQuery(id string) (string, error) {
+	return a.escalate(id, "confirmed")
+}
+
+func (a *App) escalate(id, reason string) (string, error) {
+	a.queryMu.Lock()
+	q := a.query
+	if q == nil || q.id != id {
+		a.queryMu.Unlock()
+		return "", fmt.Errorf("query %q is no longer current", id)
+	}
+	if q.timer != nil {
+		q.timer.Stop()
+	}
+	if q.sending != nil {
+		sending := q.sending
+		a.queryMu.Unlock()
+		<-sending
+		return q.requestID, q.err
+	}
+	q.sending = make(chan struct{})
+	input := q.input
+	a.queryMu.Unlock()
+
+	// Sending can wait on related context lookups, so typing must not
+	// block behind it
+	requestID, err := a.SendPrompt(input)
+	a.queryMu.Lock()
+	current := a.query == q
+	if err == nil && !current {
+		err = fmt.Errorf("query %q is no longer current", id)
+	}
+	if err == nil {
+		q.requestID = requestID
+	}
+	q.err = err
+	close(q.sending)
+	a.queryMu.Unlock()
+	if requestID != "" && !current {
+		_ = a.CancelRequest(requestID)
+		return "", err
+	}
+	if err != nil {
+		eventQueryEscalated.respond(a.ctx, id, QueryEscalation{Reason: reason}, err)
+		return "", err
+	}
+	eventQueryEscalated.respond(a.ctx, id, QueryEscalation{RequestID: requestID, Reason: reason}, nil)
+	return requestID, nil
+}
+
+// CancelQuery stops a query from escalating on its own
+func (a *App) CancelQuery(id string) {
+	a.queryMu.Lock()
+	defer a.queryMu.Unlock()
+	if q := a.query; q != nil && q.id == id {
+		if q.timer != nil {
+			q.timer.Stop()
+		}
+		a.query = nil
+	}
+}
+
+// localResults gathers the instant answers for input, a few per source,
+// in a fixed order of sources
+func (a *App) localResults(input string) []LocalResult {
+	results := []LocalResult{}
+	if v, err := evaluate(input); err == nil {
+		results = append(results, LocalResult{Kind: "calculator", ID: formatNumber(v), Title: formatNumber(v), Subtitle: input})
+	}
+	for _, c := range a.SearchSystemCommands(input, localResultsPerSource) {
+		results = append(results, LocalResult{Kind: "command", ID: c.ID, Title: c.Title})
+	}
+	if links, err := a.SearchQuicklinks(input, localResultsPerSource); err == nil {
+		for _, l := range links {
+			results = append(results, LocalResult{Kind: "quicklink", ID: l.ID, Title: l.Name, Subtitle: l.Target})
+		}
+	}
+
+	if items, err := a.GetRecentItems(); err == nil {
+		for _, kind := range []string{"app", "file"} {
+			var candidates []MatchCandidate
+			byID := map[string]RecentItem{}
+			for _, item := range items {
+				if item.Kind == kind {
+					key := item.ID + item.Path
+					byID[key] = item
+					candidates = append(candidates, MatchCandidate{ID: key, Text: item.Name, LastUsed: item.Time})
+				}
+			}
+			for _, m := range fuzzyMatch(input, candidates, MatchOptions{Limit: localResultsPerSource}) {
+				item := byID[m.ID]
+				results = append(results, LocalResult{Kind: kind, ID: item.ID, Title: item.Name, Subtitle: item.Path})
+				if kind == "file" {
+					results[len(results)-1].ID = item.Path
+				}
+			}
+		}
+	}
+
+	var snippets []MatchCandidate
+	byID := map[string]HistoryRecord{}
+	for _, r := range a.ListFavorites() {
+		if r.Kind == "snippet" {
+			byID[r.ID] = r
+			snippets = append(snippets, MatchCandidate{ID: r.ID, Text: r.Text, LastUsed: r.CreatedAt})
+		}
+	}
+	for _, m := range fuzzyMatch(input, snippets, MatchOptions{Limit: localResultsPerSource}) {
+		results = append(results, LocalResult{Kind: "snippet", ID: m.ID, Title: firstLine(byID[m.ID].Text)})
+	}
+	for _, r := range a.SearchHistory(input, localResultsPerSource) {
+		results = append(results, LocalResult{Kind: "history", ID: r.ID, Title: firstLine(r.Text), Subtitle: r.Kind})
+	}
+	return results
+}
+
+func firstLine(s string) string {
+	s, _, _ = strings.Cut(strings.TrimSpace(s), "\n")
+	return s
+}
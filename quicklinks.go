@@ -0,0 +1,320 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+const quicklinksFile = "quicklinks.json"
+
+// Quicklink kinds
+const (
+	quicklinkURL    = "url"
+	quicklinkFolder = "folder"
+	quicklinkFile   = "file"
+)
+
+// Quicklink is a named shortcut to a web page, folder or file. Keywords
+// are extra search terms; typing one exactly puts the link first.
+type Quicklink struct {
+	ID       string    `json:"id"`
+	Name     string    `json:"name"`
+	Kind     string    `json:"kind"`
+	Target   string    `json:"target"`
+	Keywords []string  `json:"keywords,omitempty"`
+	Uses     int       `json:"uses,omitempty"`
+	LastUsed time.Time `json:"lastUsed,omitempty"`
+}
+
+func (a *App) loadQuicklinksLocked() ([]Quicklink, error) {
+	var links []Quicklink
+	err := readJSONConfig(quicklinksFile, &links)
+	return links, err
+}
+
+// ListQuicklinks returns the saved quicklinks by name
+func (a *App) ListQuicklinks() ([]Quicklink, error) {
+	a.quicklinksMu.Lock()
+	defer a.quicklinksMu.Unlock()
+	links, err := a.loadQuicklinksLocked()
+	if links == nil {
+		links = []Quicklink{}
+	}
+	return links, err
+}
+
+// normalizeQuicklink checks l and fills in its kind: http(s) links are
+// URLs, file: URLs and absolute paths are files or folders
+func normalizeQuicklink(l Quicklink) (Quicklink, error) {
+	l.Name = strings.TrimSpace(l.Name)
+	l.Target = strings.TrimSpace(l.Target)
+	if l.Name == "" || l.Target == "" {
+		return l, errors.New("a quicklink needs a name and a target")
+	}
+	if u, err := url.Parse(l.Target); err == nil && strings.EqualFold(u.Scheme, "file") {
+		path := u.Path
+		// file:///C:/Users/… on Windows
+		if len(path) > 2 && path[0] == '/' && path[2] == ':' {
+			path = path[1:]
+		}
+		l.Target = filepath.FromSlash(path)
+	}
+	if filepath.IsAbs(l.Target) {
+		l.Kind = quicklinkFile
+		if info, err := os.Stat(l.Target); err == nil && info.IsDir() {
+			l.Kind = quicklinkFolder
+		}
+	} else {
+		u, err := url.Parse(l.Target)
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			return l, fmt.Errorf("%q is not a web address or an absolute path", l.Target)
+		}
+		l.Kind = quicklinkURL
+	}
+	keywords := []string{}
+	for _, k := range l.Keywords {
+		if k = strings.ToLower(strings.TrimSpace(k)); k != "" && !slices.Contains(keywords, k) {
+			keywords = append(keywords, k)
+		}
+	}
+	l.Keywords = keywords
+	return l, nil
+}
+
+// SaveQuicklink adds l, or replaces the quicklink with the same ID
+func (a *App) SaveQuicklink(l Quicklink) (Quicklink, error) {
+	l, err := normalizeQuicklink(l)
+	if err != nil {
+		return Quicklink{}, err
+	}
+	if l.ID == "" {
+		l.ID = newID()
+	}
+
+	a.quicklinksMu.Lock()
+	defer a.quicklinksMu.Unlock()
+	links, err := a.loadQuicklinksLocked()
+	if err != nil {
+		return Quicklink{}, err
+	}
+	if i := slices.IndexFunc(links, func(o Quicklink) bool { return o.ID == l.ID }); i >= 0 {
+		l.Uses, l.LastUsed = links[i].Uses, links[i].LastUsed
+		links[i] = l
+	} else {
+		links = append(links, l)
+	}
+	return l, a.saveQuicklinksLocked(links)
+}
+
+func (a *App) saveQuicklinksLocked(links []Quicklink) error {
+	sort.Slice(links, func(i, j int) bool { return strings.ToLower(links[i].Name) < strings.ToLower(links[j].Name) })
+	return writeJSONConfig(quicklinksFile, links, 0o644)
+}
+
+// DeleteQuicklink removes the quicklink with id
+func (a *App) DeleteQuicklink(id string) error {
+	a.quicklinksMu.Lock()
+	defer a.quicklinksMu.Unlock()
+	links, err := a.loadQuicklinksLocked()
+	if err != nil {
+		return err
+	}
+	n := len(links)
+	links = slices.DeleteFunc(links, func(l Quicklink) bool { return l.ID == id })
+	if len(links) == n {
+		return fmt.Errorf("no quicklink %q", id)
+	}
+	return a.saveQuicklinksLocked(links)
+}
+
+// SearchQuicklinks fuzzy-matches query against the names and keywords of
+// the quicklinks, favouring the ones opened often and lately
+func (a *App) SearchQuicklinks(query string, limit int) ([]Quicklink, error) {
+	links, err := a.ListQuicklinks()
+	if err != nil {
+		return nil, err
+	}
+	byID := map[string]Quicklink{}
+	var candidates []MatchCandidate
+	for _, l := range links {
+		byID[l.ID] = l
+		for _, text := range append([]string{l.Name}, l.Keywords...) {
+			candidates = append(candidates, MatchCandidate{ID: l.ID, Text: text, Frequency: l.Uses, LastUsed: l.LastUsed})
+		}
+	}
+	// Each link is ranked by its best matching name or keyword
+	out := []Quicklink{}
+	for _, m := range fuzzyMatch(query, candidates, MatchOptions{MaxTypos: 1}) {
+		if l, ok := byID[m.ID]; ok {
+			out = append(out, l)
+			delete(byID, m.ID)
+		}
+		if limit > 0 && len(out) == limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+// OpenQuicklink opens a web link through OpenURL, with its confirmation
+// and scheme checks, and files and folders with their default app
+func (a *App) OpenQuicklink(id string) error {
+	a.quicklinksMu.Lock()
+	links, err := a.loadQuicklinksLocked()
+	i := slices.IndexFunc(links, func(l Quicklink) bool { return l.ID == id })
+	var l Quicklink
+	if err == nil && i >= 0 {
+		links[i].Uses++
+		links[i].LastUsed = time.Now()
+		l = links[i]
+		err = a.saveQuicklinksLocked(links)
+	}
+	a.quicklinksMu.Unlock()
+	if err != nil {
+		return err
+	}
+	if i < 0 {
+		return fmt.Errorf("no quicklink %q", id)
+	}
+
+	if l.Kind == quicklinkURL {
+		_, err := a.OpenURL(l.Target)
+		return err
+	}
+	if err := a.requirePermission(capFiles); err != nil {
+		return err
+	}
+	if _, err := os.Stat(l.Target); err != nil {
+		return err
+	}
+	return openFile(l.Target)
+}
+
+// parseBookmarks reads the Netscape bookmark file every browser exports.
+// Folder names become keywords, as do Firefox keywords and tags.
+func parseBookmarks(r io.Reader) ([]Quicklink, error) {
+	var links []Quicklink
+	var folders []string
+	folder := ""
+	var link *Quicklink
+	inFolderTitle := false
+	z := html.NewTokenizer(r)
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			if errors.Is(z.Err(), io.EOF) {
+				return links, nil
+			}
+			return nil, z.Err()
+		case html.StartTagToken:
+			name, hasAttr := z.TagName()
+			switch string(name) {
+			case "h3":
+				inFolderTitle, folder = true, ""
+			case "dl":
+				folders = append(folders, folder)
+				folder = ""
+			case "a":
+				link = &Quicklink{}
+				for hasAttr {
+					var k, v []byte
+					k, v, hasAttr = z.TagAttr()
+					switch string(k) {
+					case "href":
+						link.Target = string(v)
+					case "shortcuturl":
+						link.Keywords = append(link.Keywords, string(v))
+					case "tags":
+						link.Keywords = append(link.Keywords, strings.Split(string(v), ",")...)
+					}
+				}
+				for _, f := range folders {
+					if f != "" {
+						link.Keywords = append(link.Keywords, f)
+					}
+				}
+			}
+		case html.TextToken:
+			switch {
+			case inFolderTitle:
+				folder += string(z.Text())
+			case link != nil:
+				link.Name += string(z.Text())
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			switch string(name) {
+			case "h3":
+				inFolderTitle = false
+				folder = strings.TrimSpace(folder)
+			case "dl":
+				if len(folders) > 0 {
+					folders = folders[:len(folders)-1]
+				}
+			case "a":
+				if link != nil {
+					if strings.TrimSpace(link.Name) == "" {
+						link.Name = link.Target
+					}
+					links = append(links, *link)
+					link = nil
+				}
+			}
+		}
+	}
+}
+
+// ImportBookmarks adds the links of a browser's bookmark export (the HTML
+// file from "Export bookmarks"), skipping javascript: and place: entries
+// and targets already saved. It returns how many were added.
+func (a *App) ImportBookmarks(path string) (int, error) {
+	if err := a.requirePermission(capFiles); err != nil {
+		return 0, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	parsed, err := parseBookmarks(f)
+	if err != nil {
+		return 0, fmt.Errorf("invalid bookmarks file: %w", err)
+	}
+
+	a.quicklinksMu.Lock()
+	defer a.quicklinksMu.Unlock()
+	links, err := a.loadQuicklinksLocked()
+	if err != nil {
+		return 0, err
+	}
+	seen := map[string]bool{}
+	for _, l := range links {
+		seen[l.Target] = true
+	}
+	added := 0
+	for _, l := range parsed {
+		l, err := normalizeQuicklink(l)
+		if err != nil || seen[l.Target] {
+			continue
+		}
+		l.ID = newID()
+		seen[l.Target] = true
+		links = append(links, l)
+		added++
+	}
+	if added == 0 {
+		return 0, nil
+	}
+	return added, a.saveQuicklinksLocked(links)
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// recentAppsFile remembers the apps the overlay was summoned over
+	recentAppsFile = "recentapps.json"
+	recentAppsMax  = 20
+	// recentFilesMaxAge drops files not touched for a while
+	recentFilesMaxAge = 14 * 24 * time.Hour
+	// recentFilesDepth is how many folder levels below each root are scanned
+	recentFilesDepth = 2
+	// recentFilesScanMax bounds the entries visited per root
+	recentFilesScanMax = 5000
+	// recentItemsTTL is how long a scan is reused
+	recentItemsTTL = 30 * time.Second
+	// At most this many of each kind are suggested
+	recentItemsFiles = 12
+	recentItemsApps  = 8
+)
+
+// recentFolders are scanned in the home directory, besides the downloads
+// folder
+var recentFolders = []string{"Documents", "Desktop"}
+
+// RecentItem is a jump-back-in suggestion for the empty overlay. Kind is
+// "file" or "app"; for apps ID is the ActiveApp ID.
+type RecentItem struct {
+	Kind string    `json:"kind"`
+	Name string    `json:"name"`
+	Path string    `json:"path,omitempty"`
+	ID   string    `json:"id,omitempty"`
+	Time time.Time `json:"time"`
+}
+
+type recentFiles struct {
+	items   []RecentItem
+	builtAt time.Time
+}
+
+// noteRecentApp records the frontmost app each time the overlay is
+// summoned
+func (a *App) noteRecentApp(app ActiveApp) {
+	if app.ID == "" || app.PID == os.Getpid() {
+		return
+	}
+	item := RecentItem{Kind: "app", Name: app.Name, ID: app.ID, Path: appExecutable(app), Time: time.Now()}
+	a.recentMu.Lock()
+	defer a.recentMu.Unlock()
+	apps := a.loadRecentAppsLocked()
+	apps = slices.DeleteFunc(apps, func(r RecentItem) bool { return r.ID == item.ID })
+	apps = append([]RecentItem{item}, apps...)
+	if len(apps) > recentAppsMax {
+		apps = apps[:recentAppsMax]
+	}
+	a.recentApps = apps
+	if err := writeJSONConfig(recentAppsFile, apps, 0o600); err != nil {
+		println("Error saving recent apps:", err.Error())
+	}
+}
+
+func (a *App) loadRecentAppsLocked() []RecentItem {
+	if a.recentApps == nil {
+		a.recentApps = []RecentItem{}
+		if err := readJSONConfig(recentAppsFile, &a.recentApps); err != nil {
+			println("Error reading recent apps:", err.Error())
+		}
+	}
+	return a.recentApps
+}
+
+// GetRecentItems returns recently modified files in Documents, Downloads
+// and Desktop and recently used apps, newest first. Files are left out
+// until file access is allowed.
+func (a *App) GetRecentItems() ([]RecentItem, error) {
+	a.recentMu.Lock()
+	apps := slices.Clone(a.loadRecentAppsLocked())
+	a.recentMu.Unlock()
+	if len(apps) > recentItemsApps {
+		apps = apps[:recentItemsApps]
+	}
+	items := apps
+	if a.requirePermission(capFiles) == nil {
+		items = append(items, a.recentFileItems()...)
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Time.After(items[j].Time) })
+	return items, nil
+}
+
+func (a *App) recentFileItems() []RecentItem {
+	a.recentMu.Lock()
+	defer a.recentMu.Unlock()
+	if c := a.recentFiles; c != nil && time.Since(c.builtAt) < recentItemsTTL {
+		return slices.Clone(c.items)
+	}
+	var roots []string
+	if home, err := os.UserHomeDir(); err == nil {
+		for _, f := range recentFolders {
+			roots = append(roots, filepath.Join(home, f))
+		}
+		downloads := a.GetSettings().DownloadsDir
+		if downloads == "" {
+			downloads = filepath.Join(home, "Downloads")
+		}
+		roots = append(roots, downloads)
+	}
+	since := time.Now().Add(-recentFilesMaxAge)
+	items := []RecentItem{}
+	for _, root := range roots {
+		items = append(items, scanRecentFiles(root, since)...)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Time.After(items[j].Time) })
+	if len(items) > recentItemsFiles {
+		items = items[:recentItemsFiles]
+	}
+	a.recentFiles = &recentFiles{items: items, builtAt: time.Now()}
+	return slices.Clone(items)
+}
+
+// scanRecentFiles lists the files under root modified after since,
+// skipping hidden entries, partial downloads and dependency folders
+func scanRecentFiles(root string, since time.Time) []RecentItem {
+	var items []RecentItem
+	visited := 0
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if d != nil && d.IsDir() && path != root {
+				return fs.SkipDir
+			}
+			return err
+		}
+		if visited++; visited > recentFilesScanMax {
+			return fs.SkipAll
+		}
+		name := d.Name()
+		if path == root {
+			return nil
+		}
+		if strings.HasPrefix(name, ".") {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			rel, _ := filepath.Rel(root, path)
+			if workspaceSkipDirs[name] || strings.Count(rel, string(filepath.Separator)) >= recentFilesDepth-1 {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || strings.HasSuffix(name, ".part") || strings.HasSuffix(name, ".crdownload") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || info.ModTime().Before(since) {
+			return nil
+		}
+		items = append(items, RecentItem{Kind: "file", Name: name, Path: path, Time: info.ModTime()})
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		println("Error scanning recent files:", err.Error())
+	}
+	return items
+}
+
+// OpenRecentItem opens a suggested file with its default app or brings a
+// suggested app to the front
+func (a *App) OpenRecentItem(item RecentItem) error {
+	switch item.Kind {
+	case "file":
+		if err := a.requirePermission(capFiles); err != nil {
+			return err
+		}
+		if _, err := os.Stat(item.Path); err != nil {
+			return err
+		}
+		return openFile(item.Path)
+	case "app":
+		a.recentMu.Lock()
+		i := slices.IndexFunc(a.loadRecentAppsLocked(), func(r RecentItem) bool { return r.ID == item.ID })
+		if i >= 0 {
+			item = a.recentApps[i]
+		}
+		a.recentMu.Unlock()
+		// Only apps the overlay recorded are launched
+		if i < 0 {
+			return errors.New("unknown app")
+		}
+		a.HideOverlay()
+		return launchApp(item)
+	}
+	return errors.New("unknown item kind " + item.Kind)
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	maxRecordingSeconds = 30
+	attachmentsDir      = "attachments"
+)
+
+// RecordingOptions describes a short screen recording
+type RecordingOptions struct {
+	Seconds int `json:"seconds"`
+	// Region limits the recording to part of the screen, in screen
+	// coordinates; nil records the main display
+	Region *Rect `json:"region,omitempty"`
+	// GIF converts the clip to an animated GIF, which needs ffmpeg
+	GIF bool `json:"gif"`
+}
+
+// Recording is a finished clip in the temp directory
+type Recording struct {
+	Path    string `json:"path"`
+	Format  string `json:"format"`
+	Seconds int    `json:"seconds"`
+	Size    int64  `json:"size"`
+}
+
+// StartRecording records the screen for opts.Seconds in the background and
+// reports the clip as a recording-done event tagged with the returned
+// request ID. Only one recording runs at a time.
+func (a *App) StartRecording(opts RecordingOptions) (string, error) {
+	if err := a.requirePermission(capScreenCapture); err != nil {
+		return "", err
+	}
+	if opts.Seconds < 1 || opts.Seconds > maxRecordingSeconds {
+		return "", fmt.Errorf("recordings must be between 1 and %d seconds", maxRecordingSeconds)
+	}
+	if opts.Region != nil && (opts.Region.Width <= 0 || opts.Region.Height <= 0) {
+		return "", errors.New("recording region is empty")
+	}
+	a.recordingMu.Lock()
+	if a.recording {
+		a.recordingMu.Unlock()
+		return "", errors.New("a recording is already running")
+	}
+	a.recording = true
+	a.recordingMu.Unlock()
+
+	id := newRequestID()
+	go func() {
+		rec, err := a.record(opts)
+		a.recordingMu.Lock()
+		a.recording = false
+		a.recordingMu.Unlock()
+		eventRecordingDone.respond(a.ctx, id, rec, err)
+	}()
+	return id, nil
+}
+
+func (a *App) record(opts RecordingOptions) (Recording, error) {
+	path, err := tempCapturePath("recording", recordingFormat)
+	if err != nil {
+		return Recording{}, err
+	}
+	if err := recordScreen(path, opts.Seconds, opts.Region); err != nil {
+		_ = os.Remove(path)
+		return Recording{}, err
+	}
+	rec := Recording{Path: path, Format: recordingFormat, Seconds: opts.Seconds}
+	if opts.GIF {
+		gif := strings.TrimSuffix(path, filepath.Ext(path)) + ".gif"
+		err := convertToGIF(path, gif)
+		_ = os.Remove(path)
+		if err != nil {
+			return Recording{}, err
+		}
+		rec.Path, rec.Format = gif, "gif"
+	}
+	info, err := os.Stat(rec.Path)
+	if err != nil {
+		return Recording{}, err
+	}
+	rec.Size = info.Size()
+	return rec, nil
+}
+
+// convertToGIF renders a clip as a GIF at a size that stays shareable
+func convertToGIF(src, dst string) error {
+	if _, err := exec.LookPath("ffmpeg"); err != nil {
+		return errors.New("GIF recordings need ffmpeg")
+	}
+	out, err := exec.Command("ffmpeg", "-y", "-loglevel", "error", "-i", src,
+		"-vf", "fps=10,scale='min(960,iw)':-1:flags=lanczos", dst).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("ffmpeg: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// AttachRecording moves a clip from StartRecording into the config
+// directory and lists it on the record with id, e.g. a note
+func (a *App) AttachRecording(id, path string) (HistoryRecord, error) {
+	if a.history == nil {
+		return HistoryRecord{}, errors.New("history is unavailable")
+	}
+	// Only clips this app recorded may be attached
+	if rel, err := filepath.Rel(captureTempDir(), path); err != nil || strings.HasPrefix(rel, "..") || !strings.HasPrefix(filepath.Base(path), "recording-") {
+		return HistoryRecord{}, fmt.Errorf("%s is not a recording", path)
+	}
+	if _, ok := a.history.get(id); !ok {
+		return HistoryRecord{}, fmt.Errorf("no history record %q", id)
+	}
+
+	dir, err := configPath(attachmentsDir)
+	if err != nil {
+		return HistoryRecord{}, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return HistoryRecord{}, err
+	}
+	dst := filepath.Join(dir, newID()+filepath.Ext(path))
+	size, err := moveFile(path, dst)
+	if err != nil {
+		return HistoryRecord{}, err
+	}
+	return a.history.update(id, func(r *HistoryRecord) error {
+		r.Attachments = append(r.Attachments, Attachment{Kind: "recording", Size: int(size), Path: dst})
+		return nil
+	})
+}
+
+// moveFile renames src to dst, copying when they are on different volumes
+// as the temp directory often is
+func moveFile(src, dst string) (int64, error) {
+	if err := os.Rename(src, dst); err == nil {
+		info, err := os.Stat(dst)
+		if err != nil {
+			return 0, err
+		}
+		return info.Size(), nil
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return 0, err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return 0, err
+	}
+	n, err := io.Copy(out, in)
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(dst)
+		return 0, err
+	}
+	return n, os.Remove(src)
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+const recordingFormat = "mov"
+
+// recordScreen records with screencapture, which needs the screen
+// recording permission like screenshots do
+func recordScreen(path string, seconds int, region *Rect) error {
+	args := []string{"-x", "-v", "-V" + strconv.Itoa(seconds)}
+	if region != nil {
+		args = append(args, fmt.Sprintf("-R%d,%d,%d,%d", region.X, region.Y, region.Width, region.Height))
+	}
+	out, err := exec.Command("screencapture", append(args, path)...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("screencapture: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const recordingFormat = "mp4"
+
+// recordScreen uses wf-recorder on Wayland and ffmpeg's X11 grabber
+// otherwise
+func recordScreen(path string, seconds int, region *Rect) error {
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		if _, err := exec.LookPath("wf-recorder"); err == nil {
+			return recordWayland(path, seconds, region)
+		}
+	}
+	if _, err := exec.LookPath("ffmpeg"); err != nil {
+		return errors.New("screen recording needs ffmpeg or wf-recorder")
+	}
+	display := os.Getenv("DISPLAY")
+	if display == "" {
+		display = ":0"
+	}
+	args := []string{"-y", "-loglevel", "error", "-f", "x11grab", "-framerate", "15"}
+	if region != nil {
+		args = append(args, "-video_size", fmt.Sprintf("%dx%d", region.Width, region.Height))
+		display += fmt.Sprintf("+%d,%d", region.X, region.Y)
+	}
+	args = append(args, "-i", display, "-t", strconv.Itoa(seconds), "-pix_fmt", "yuv420p", path)
+	out, err := exec.Command("ffmpeg", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("ffmpeg: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// recordWayland runs wf-recorder, which has no duration flag, and stops it
+// with SIGINT so it finalizes the file
+func recordWayland(path string, seconds int, region *Rect) error {
+	args := []string{"-f", path}
+	if region != nil {
+		args = append(args, "-g", fmt.Sprintf("%d,%d %dx%d", region.X, region.Y, region.Width, region.Height))
+	}
+	cmd := exec.Command("wf-recorder", args...)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	timer := time.AfterFunc(time.Duration(seconds)*time.Second, func() {
+		_ = cmd.Process.Signal(os.Interrupt)
+	})
+	defer timer.Stop()
+	if err := cmd.Wait(); err != nil && cmd.ProcessState.ExitCode() != 0 {
+		return fmt.Errorf("wf-recorder: %w", err)
+	}
+	return nil
+}
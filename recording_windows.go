@@ -0,0 +1,31 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+const recordingFormat = "mp4"
+
+// recordScreen records the desktop with ffmpeg's GDI grabber; Windows has
+// no recorder a program can drive without it
+func recordScreen(path string, seconds int, region *Rect) error {
+	if _, err := exec.LookPath("ffmpeg"); err != nil {
+		return errors.New("screen recording needs ffmpeg on PATH")
+	}
+	args := []string{"-y", "-loglevel", "error", "-f", "gdigrab", "-framerate", "15"}
+	if region != nil {
+		args = append(args,
+			"-offset_x", strconv.Itoa(region.X), "-offset_y", strconv.Itoa(region.Y),
+			"-video_size", fmt.Sprintf("%dx%d", region.Width, region.Height))
+	}
+	args = append(args, "-i", "desktop", "-t", strconv.Itoa(seconds), "-pix_fmt", "yuv420p", path)
+	out, err := exec.Command("ffmpeg", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("ffmpeg: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// inflightRequest is a queued or running request; cancel aborts its
+// stream, run starts it once a slot is free
+type inflightRequest struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	run     func(ctx context.Context)
+	running bool
+}
+
+// RequestQueued is the payload of the request-queued event
+type RequestQueued struct {
+	// Position is 1 for the next request to start
+	Position int `json:"position"`
+	Running  int `json:"running"`
+}
+
+// RequestStarted is the payload of the request-started event, sent when a
+// request leaves the queue
+type RequestStarted struct {
+	Model string `json:"model"`
+}
+
+// ResponseChunk is the payload of a response-chunk event. Deltas that
+// arrive together are merged, see SetStreamBatch; Seq starts at 0 for
+// every request and counts events.
+type ResponseChunk struct {
+	Seq   int    `json:"seq"`
+	Delta string `json:"delta"`
+}
+
+// ResponseResult is the payload of the response-done event
+type ResponseResult struct {
+	Text         string `json:"text"`
+	Model        string `json:"model"`
+	FinishReason string `json:"finishReason,omitempty"`
+	// Cancelled is set when CancelRequest stopped the request; Text then
+	// holds whatever had streamed so far
+	Cancelled bool `json:"cancelled"`
+	// Cached is set when the answer came from the response cache
+	Cached bool `json:"cached"`
+	// Failures are the providers given up on before Model answered; see
+	// FailoverSettings
+	Failures   []ProviderFailure `json:"failures,omitempty"`
+	DurationMs int64             `json:"durationMs"`
+	// HistoryID is the stored response record, for SetFavorite
+	HistoryID string `json:"historyId,omitempty"`
+}
+
+// SendPrompt expands the context variables in prompt and streams the
+// answer as response-chunk events followed by response-done, all tagged
+// with the returned request ID. A cached answer is replayed at once.
+func (a *App) SendPrompt(prompt string) (string, error) {
+	return a.sendPrompt(prompt, a.lastShowContext())
+}
+
+// sendPrompt is SendPrompt against an explicit context, for prompts that
+// come from integrations rather than the overlay. images are PNGs sent
+// along for multimodal models; such requests bypass the response cache.
+func (a *App) sendPrompt(prompt string, show ShowContext, images ...[]byte) (string, error) {
+	return a.sendPromptWith(prompt, show, promptOverrides{}, images...)
+}
+
+// sendPromptWith is sendPrompt with a template's model, parameters and
+// system prompt in place of the saved settings
+func (a *App) sendPromptWith(prompt string, show ShowContext, o promptOverrides, images ...[]byte) (string, error) {
+	o, persona := a.withPersona(o)
+	p, cfg, err := a.providerFor(o.apply(a.GetSettings().Provider))
+	if err != nil {
+		return "", err
+	}
+	preview := a.expandPrompt(prompt, show)
+	msg := chatMessage{Role: "user", Content: preview.Prompt}
+	attachments := attachmentsOf(preview)
+	for _, img := range images {
+		msg.Images = append(msg.Images, "data:image/png;base64,"+base64.StdEncoding.EncodeToString(img))
+		attachments = append(attachments, Attachment{Kind: "image", Size: len(img)})
+	}
+	messages := []chatMessage{msg}
+	related := a.relatedContext(preview.Prompt)
+	if related != "" {
+		messages = append([]chatMessage{{Role: "system", Content: related}}, messages...)
+		attachments = append(attachments, Attachment{Kind: "related", Size: len(related)})
+	}
+	if o.System != "" {
+		messages = append([]chatMessage{{Role: "system", Content: o.System}}, messages...)
+	}
+	req := chatRequest{Model: cfg.Model, Messages: messages, Temperature: o.Temperature, MaxTokens: o.MaxTokens}
+	cachePrompt := o.cacheTag() + "\x00" + related + msg.Content
+	key := responseCacheKey(cfg, cachePrompt)
+	cacheable := len(images) == 0
+
+	id := newRequestID()
+	session := a.currentSession()
+	a.resetInputCursor()
+	a.recordHistory(HistoryRecord{Kind: "input", Text: prompt, RequestID: id, SessionID: session, PersonaID: persona, Attachments: attachments})
+	if cached, ok := a.cachedResult(key); ok && cacheable {
+		cached.Cached, cached.DurationMs = true, 0
+		// The cache holds the model's own text; see runRequest
+		cached.Text = a.postProcess(cached.Text)
+		cached.HistoryID = a.recordHistory(HistoryRecord{Kind: "response", Text: cached.Text, RequestID: id, Model: cached.Model, SessionID: session, Cached: true}).ID
+		// Emit after returning so the caller knows the ID first
+		go func() {
+			eventResponseChunk.respond(a.ctx, id, ResponseChunk{Delta: cached.Text}, nil)
+			eventResponseDone.respond(a.ctx, id, cached, nil)
+		}()
+		return id, nil
+	}
+	a.enqueueRequest(id, func(ctx context.Context) {
+		// A fallback's answer is kept under its own key, so the primary is
+		// never served it
+		if result, raw, answered, err := a.runRequest(ctx, id, session, a.failoverChain(p, cfg), req); err == nil && cacheable {
+			result.Text = raw
+			a.storeResult(responseCacheKey(answered, cachePrompt), result)
+		}
+	})
+	return id, nil
+}
+
+// enqueueRequest queues run behind the requests already waiting. Requests
+// start in submission order, at most MaxConcurrentRequests at a time;
+// waiting ones get request-queued events as their position changes.
+func (a *App) enqueueRequest(id string, run func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	a.requestsMu.Lock()
+	if a.requests == nil {
+		a.requests = map[string]*inflightRequest{}
+	}
+	a.requests[id] = &inflightRequest{ctx: ctx, cancel: cancel, run: run}
+	a.requestQueue = append(a.requestQueue, id)
+	positions := a.dispatchRequestsLocked()
+	a.requestsMu.Unlock()
+
+	a.emitQueuePositions(positions)
+}
+
+// dispatchRequestsLocked starts queued requests while slots are free and
+// returns the remaining queue
+func (a *App) dispatchRequestsLocked() []string {
+	limit := a.GetSettings().MaxConcurrentRequests
+	if limit < 1 {
+		limit = 1
+	}
+	for a.requestsRunning < limit && len(a.requestQueue) > 0 {
+		id := a.requestQueue[0]
+		a.requestQueue = a.requestQueue[1:]
+		r := a.requests[id]
+		r.running = true
+		a.requestsRunning++
+		go func() {
+			defer a.finishRequest(id)
+			r.run(r.ctx)
+		}()
+	}
+	return append([]string(nil), a.requestQueue...)
+}
+
+func (a *App) emitQueuePositions(queue []string) {
+	a.requestsMu.Lock()
+	running := a.requestsRunning
+	a.requestsMu.Unlock()
+	for i, id := range queue {
+		eventRequestQueued.respond(a.ctx, id, RequestQueued{Position: i + 1, Running: running}, nil)
+	}
+}
+
+// runRequest streams req from the first provider in chain that answers.
+// It also returns the text as the model wrote it, before post-processing,
+// and the provider that wrote it.
+func (a *App) runRequest(ctx context.Context, id, session string, chain []providerCandidate, req chatRequest) (ResponseResult, string, ProviderSettings, error) {
+	started := time.Now()
+	eventRequestStarted.respond(a.ctx, id, RequestStarted{Model: req.Model}, nil)
+
+	s := a.GetSettings()
+	interval := time.Duration(s.StreamBatchMs) * time.Millisecond
+	batch := newChunkBatcher(interval, func(c ResponseChunk) {
+		eventResponseChunk.respond(a.ctx, id, c, nil)
+	})
+	onDelta := batch.add
+	// Chunks would show what these steps remove, so the processed text
+	// is sent in one chunk at the end instead
+	buffered := s.PostProcess.needsWholeText()
+	if buffered {
+		onDelta = func(string) {}
+	}
+	res, answered, failures, err := a.streamWithFailover(ctx, id, chain, req, onDelta)
+	batch.close()
+
+	result := ResponseResult{
+		Text:         postProcess(res.Text, s.PostProcess),
+		Model:        res.Model,
+		FinishReason: res.FinishReason,
+		Failures:     failures,
+		DurationMs:   time.Since(started).Milliseconds(),
+	}
+	if errors.Is(ctx.Err(), context.Canceled) {
+		result.Cancelled, err = true, nil
+	}
+	if result.Text != "" {
+		result.HistoryID = a.recordHistory(HistoryRecord{Kind: "response", Text: result.Text, RequestID: id, Model: result.Model, SessionID: session, KeyID: answered.cfg.KeyID, DurationMs: result.DurationMs}).ID
+		if buffered {
+			eventResponseChunk.respond(a.ctx, id, ResponseChunk{Delta: result.Text}, nil)
+		}
+	}
+	eventResponseDone.respond(a.ctx, id, result, err)
+	return result, res.Text, answered.cfg, err
+}
+
+func (a *App) finishRequest(id string) {
+	a.requestsMu.Lock()
+	if r, ok := a.requests[id]; ok {
+		r.cancel()
+		delete(a.requests, id)
+		if r.running {
+			a.requestsRunning--
+		}
+	}
+	positions := a.dispatchRequestsLocked()
+	a.requestsMu.Unlock()
+
+	a.emitQueuePositions(positions)
+}
+
+// CancelRequest aborts a running request, or drops a queued one before it
+// starts; either way its response-done event reports Cancelled
+func (a *App) CancelRequest(id string) error {
+	a.requestsMu.Lock()
+	r, ok := a.requests[id]
+	if !ok {
+		a.requestsMu.Unlock()
+		return fmt.Errorf("no request %q in flight", id)
+	}
+	if r.running {
+		a.requestsMu.Unlock()
+		r.cancel()
+		return nil
+	}
+	a.requestQueue = slices.DeleteFunc(a.requestQueue, func(q string) bool { return q == id })
+	a.requestsMu.Unlock()
+
+	eventResponseDone.respond(a.ctx, id, ResponseResult{Cancelled: true}, nil)
+	a.finishRequest(id)
+	return nil
+}
+
+// cancelAllRequests aborts every in-flight request and waits for them to
+// wind down, or for ctx to expire
+func (a *App) cancelAllRequests(ctx context.Context) error {
+	a.requestsMu.Lock()
+	queued := a.requestQueue
+	a.requestQueue = nil
+	for _, r := range a.requests {
+		r.cancel()
+	}
+	a.requestsMu.Unlock()
+	for _, id := range queued {
+		a.finishRequest(id)
+	}
+
+	ticker := time.NewTicker(20 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		a.requestsMu.Lock()
+		n := len(a.requests)
+		a.requestsMu.Unlock()
+		if n == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%d requests still running", n)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	responseCacheFile = "response-cache.json"
+	// responseCacheMax bounds the cache; the oldest entries go first
+	responseCacheMax = 500
+)
+
+// CacheSettings controls reuse of answers to identical prompts
+type CacheSettings struct {
+	Enabled    bool `json:"enabled"`
+	TTLMinutes int  `json:"ttlMinutes"`
+}
+
+type cachedResponse struct {
+	Result ResponseResult `json:"result"`
+	Stored time.Time      `json:"stored"`
+}
+
+// cacheDir returns the directory for data that can be thrown away at any
+// time, creating it if needed
+func cacheDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if p := portableDir(); p != "" {
+		base, err = filepath.Join(p, "cache"), nil
+	}
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(base, "overlae")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// responseCacheKey hashes the endpoint, model and the prompt after context
+// expansion, so the same template in a different app or with a different
+// selection is a different entry. Whitespace differences are ignored.
+func responseCacheKey(cfg ProviderSettings, prompt string) string {
+	normalized := strings.Join(strings.Fields(prompt), " ")
+	sum := sha256.Sum256([]byte(strings.Join([]string{cfg.Kind, cfg.BaseURL, cfg.Model, normalized}, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// loadResponseCacheLocked reads the cache file on first use
+func (a *App) loadResponseCacheLocked() {
+	if a.responseCache != nil {
+		return
+	}
+	a.responseCache = map[string]cachedResponse{}
+	dir, err := cacheDir()
+	if err != nil {
+		return
+	}
+	data, err := os.ReadFile(filepath.Join(dir, responseCacheFile))
+	if err == nil {
+		_ = json.Unmarshal(data, &a.responseCache)
+	}
+}
+
+func (a *App) saveResponseCacheLocked() error {
+	dir, err := cacheDir()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(a.responseCache)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(dir, responseCacheFile), data, 0o600)
+}
+
+// cachedResult returns a fresh cached answer for key, if caching is on
+func (a *App) cachedResult(key string) (ResponseResult, bool) {
+	cfg := a.GetSettings().ResponseCache
+	if !cfg.Enabled {
+		return ResponseResult{}, false
+	}
+	a.responseCacheMu.Lock()
+	defer a.responseCacheMu.Unlock()
+	a.loadResponseCacheLocked()
+
+	entry, ok := a.responseCache[key]
+	if !ok {
+		return ResponseResult{}, false
+	}
+	if time.Since(entry.Stored) > time.Duration(cfg.TTLMinutes)*time.Minute {
+		delete(a.responseCache, key)
+		return ResponseResult{}, false
+	}
+	return entry.Result, true
+}
+
+// storeResult caches a complete answer
+func (a *App) storeResult(key string, result ResponseResult) {
+	if !a.GetSettings().ResponseCache.Enabled || result.Cancelled || result.Text == "" {
+		return
+	}
+	a.responseCacheMu.Lock()
+	defer a.responseCacheMu.Unlock()
+	a.loadResponseCacheLocked()
+
+	a.responseCache[key] = cachedResponse{Result: result, Stored: time.Now()}
+	for len(a.responseCache) > responseCacheMax {
+		oldest := ""
+		for k, e := range a.responseCache {
+			if oldest == "" || e.Stored.Before(a.responseCache[oldest].Stored) {
+				oldest = k
+			}
+		}
+		delete(a.responseCache, oldest)
+	}
+	_ = a.saveResponseCacheLocked()
+}
+
+// ClearResponseCache drops every cached answer and returns how many there
+// were
+func (a *App) ClearResponseCache() (int, error) {
+	a.responseCacheMu.Lock()
+	defer a.responseCacheMu.Unlock()
+	a.loadResponseCacheLocked()
+
+	n := len(a.responseCache)
+	a.responseCache = map[string]cachedResponse{}
+	dir, err := cacheDir()
+	if err != nil {
+		return n, err
+	}
+	if err := os.Remove(filepath.Join(dir, responseCacheFile)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return n, err
+	}
+	return n, nil
+}
@@ -0,0 +1,467 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+const (
+	// vectorIndexFile is the SQLite database of embeddings in the cache
+	// directory; it is rebuilt from history whenever it is lost
+	vectorIndexFile = "embeddings.db"
+	// embedBatchSize is how many texts go in one embeddings request
+	embedBatchSize = 64
+	// embedMaxRunes cuts long records before embedding
+	embedMaxRunes = 4000
+	// retrievalTimeout bounds the lookup done before a prompt is sent
+	retrievalTimeout = 5 * time.Second
+)
+
+const vectorSchema = `CREATE TABLE IF NOT EXISTS embeddings (
+	record_id TEXT NOT NULL,
+	model     TEXT NOT NULL,
+	hash      TEXT NOT NULL,
+	vector    BLOB NOT NULL,
+	PRIMARY KEY (record_id, model)
+)`
+
+// RetrievalSettings controls semantic search over notes, clipboard
+// history and past conversations. Embedding sends their text to the
+// provider, so it is off by default.
+type RetrievalSettings struct {
+	Enabled bool `json:"enabled"`
+	// Model is the embeddings model; a local server such as Ollama works
+	// through the provider base URL
+	Model string `json:"model"`
+	// AutoInclude adds the TopK closest records scoring at least MinScore
+	// to every prompt as context
+	AutoInclude bool    `json:"autoInclude"`
+	TopK        int     `json:"topK"`
+	MinScore    float64 `json:"minScore"`
+}
+
+// SemanticMatch is a record and its cosine similarity to the query. A
+// clipboard copy comes as a record of kind "clipboard".
+type SemanticMatch struct {
+	Record HistoryRecord `json:"record"`
+	Score  float64       `json:"score"`
+}
+
+// vectorEntry is one embedded text; hash detects edits
+type vectorEntry struct {
+	hash   string
+	vector []float32
+}
+
+// vectorIndex holds one model's embeddings. History records are stored in
+// SQLite and read once when the index opens; clipboard copies are only
+// kept in memory, like the clipboard history itself.
+type vectorIndex struct {
+	db        *sql.DB
+	model     string
+	entries   map[string]*vectorEntry
+	clipboard map[string]*vectorEntry
+}
+
+// embed returns one unit-length vector per input
+func (p *openAIProvider) embed(ctx context.Context, model string, inputs []string) ([][]float32, error) {
+	body, err := json.Marshal(map[string]any{"model": model, "input": inputs})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("provider returned %s", resp.Status)
+	}
+	var out struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("invalid embeddings: %w", err)
+	}
+	vectors := make([][]float32, len(inputs))
+	for _, d := range out.Data {
+		if d.Index >= 0 && d.Index < len(vectors) {
+			vectors[d.Index] = normalize(d.Embedding)
+		}
+	}
+	for _, v := range vectors {
+		if v == nil {
+			return nil, errors.New("provider returned too few embeddings")
+		}
+	}
+	return vectors, nil
+}
+
+func normalize(v []float32) []float32 {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	if sum == 0 {
+		return v
+	}
+	norm := float32(math.Sqrt(sum))
+	for i := range v {
+		v[i] /= norm
+	}
+	return v
+}
+
+func dot(a, b []float32) float64 {
+	var sum float64
+	for i := range min(len(a), len(b)) {
+		sum += float64(a[i]) * float64(b[i])
+	}
+	return sum
+}
+
+// embeddable reports whether a record is worth searching: notes,
+// snippets and both sides of conversations
+func embeddable(r HistoryRecord) bool {
+	return strings.TrimSpace(r.Text) != "" && (r.Kind == "note" || r.Kind == "snippet" || r.Kind == "input" || r.Kind == "response")
+}
+
+func embedText(text string) string {
+	if runes := []rune(text); len(runes) > embedMaxRunes {
+		return string(runes[:embedMaxRunes])
+	}
+	return text
+}
+
+func textHash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:8])
+}
+
+// openVectorIndex opens the database and loads model's vectors. Vectors
+// of other models cannot be compared with these, so they are dropped; a
+// database that cannot be read is started over.
+func openVectorIndex(model string) (*vectorIndex, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, vectorIndexFile)
+	idx, err := loadVectorIndex(path, model)
+	if err != nil {
+		println("Error reading semantic index, rebuilding it:", err.Error())
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		idx, err = loadVectorIndex(path, model)
+	}
+	return idx, err
+}
+
+func loadVectorIndex(path, model string) (*vectorIndex, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+	idx := &vectorIndex{db: db, model: model, entries: map[string]*vectorEntry{}, clipboard: map[string]*vectorEntry{}}
+	if err := idx.load(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return idx, nil
+}
+
+func (idx *vectorIndex) load() error {
+	if _, err := idx.db.Exec(vectorSchema); err != nil {
+		return err
+	}
+	if _, err := idx.db.Exec(`DELETE FROM embeddings WHERE model <> ?`, idx.model); err != nil {
+		return err
+	}
+	rows, err := idx.db.Query(`SELECT record_id, hash, vector FROM embeddings`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id, hash string
+		var raw []byte
+		if err := rows.Scan(&id, &hash, &raw); err != nil {
+			return err
+		}
+		idx.entries[id] = &vectorEntry{hash: hash, vector: unpackVector(raw)}
+	}
+	return rows.Err()
+}
+
+// store saves the vectors of history records in one transaction
+func (idx *vectorIndex) store(ids []string, entries []*vectorEntry) error {
+	tx, err := idx.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for i, id := range ids {
+		if _, err := tx.Exec(`INSERT INTO embeddings (record_id, model, hash, vector) VALUES (?, ?, ?, ?)
+			ON CONFLICT (record_id, model) DO UPDATE SET hash = excluded.hash, vector = excluded.vector`,
+			id, idx.model, entries[i].hash, packVector(entries[i].vector)); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	for i, id := range ids {
+		idx.entries[id] = entries[i]
+	}
+	return nil
+}
+
+func (idx *vectorIndex) remove(id string) error {
+	if _, err := idx.db.Exec(`DELETE FROM embeddings WHERE record_id = ? AND model = ?`, id, idx.model); err != nil {
+		return err
+	}
+	delete(idx.entries, id)
+	return nil
+}
+
+func (idx *vectorIndex) close() error {
+	return idx.db.Close()
+}
+
+// packVector stores v as little-endian float32s
+func packVector(v []float32) []byte {
+	raw := make([]byte, 4*len(v))
+	for i, x := range v {
+		binary.LittleEndian.PutUint32(raw[i*4:], math.Float32bits(x))
+	}
+	return raw
+}
+
+func unpackVector(raw []byte) []float32 {
+	v := make([]float32, len(raw)/4)
+	for i := range v {
+		v[i] = math.Float32frombits(binary.LittleEndian.Uint32(raw[i*4:]))
+	}
+	return v
+}
+
+// retrievalProvider returns the provider embeddings are requested from
+func (a *App) retrievalProvider() (*openAIProvider, RetrievalSettings, error) {
+	cfg := a.GetSettings().Retrieval
+	if !cfg.Enabled {
+		return nil, cfg, errors.New("semantic search is turned off")
+	}
+	p, _, err := a.currentProvider()
+	if err != nil {
+		return nil, cfg, err
+	}
+	oai, ok := p.(*openAIProvider)
+	if !ok {
+		return nil, cfg, errors.New("the provider does not support embeddings")
+	}
+	return oai, cfg, nil
+}
+
+// embedItem is a history record or clipboard copy to index
+type embedItem struct {
+	id        string
+	text      string
+	clipboard bool
+}
+
+// syncVectorIndexLocked embeds new and edited records and copies and drops
+// deleted ones, so only changes since the last search cost a request
+func (a *App) syncVectorIndexLocked(ctx context.Context, p *openAIProvider, model string, copies []clipboardCopy) error {
+	if a.vectors != nil && a.vectors.model != model {
+		if err := a.vectors.close(); err != nil {
+			println("Error closing semantic index:", err.Error())
+		}
+		a.vectors = nil
+	}
+	if a.vectors == nil {
+		idx, err := openVectorIndex(model)
+		if err != nil {
+			return err
+		}
+		a.vectors = idx
+	}
+
+	var stale []embedItem
+	live := map[string]bool{}
+	for _, r := range a.history.list(embeddable) {
+		live[r.ID] = true
+		text := embedText(r.Text)
+		if e, ok := a.vectors.entries[r.ID]; !ok || e.hash != textHash(text) {
+			stale = append(stale, embedItem{id: r.ID, text: text})
+		}
+	}
+	for id := range a.vectors.entries {
+		if !live[id] {
+			if err := a.vectors.remove(id); err != nil {
+				return err
+			}
+		}
+	}
+	liveCopies := map[string]bool{}
+	for _, c := range copies {
+		liveCopies[c.id] = true
+		if _, ok := a.vectors.clipboard[c.id]; !ok && strings.TrimSpace(c.text) != "" {
+			stale = append(stale, embedItem{id: c.id, text: embedText(c.text), clipboard: true})
+		}
+	}
+	for id := range a.vectors.clipboard {
+		if !liveCopies[id] {
+			delete(a.vectors.clipboard, id)
+		}
+	}
+
+	for start := 0; start < len(stale); start += embedBatchSize {
+		batch := stale[start:min(start+embedBatchSize, len(stale))]
+		texts := make([]string, len(batch))
+		for i, item := range batch {
+			texts[i] = item.text
+		}
+		// What was embedded so far is kept for next time
+		vectors, err := p.embed(ctx, model, texts)
+		if err != nil {
+			return err
+		}
+		var ids []string
+		var entries []*vectorEntry
+		for i, item := range batch {
+			e := &vectorEntry{hash: textHash(item.text), vector: vectors[i]}
+			if item.clipboard {
+				a.vectors.clipboard[item.id] = e
+				continue
+			}
+			ids = append(ids, item.id)
+			entries = append(entries, e)
+		}
+		if err := a.vectors.store(ids, entries); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *App) semanticSearch(ctx context.Context, query string, limit int) ([]SemanticMatch, error) {
+	if a.history == nil {
+		return nil, errors.New("history is unavailable")
+	}
+	p, cfg, err := a.retrievalProvider()
+	if err != nil {
+		return nil, err
+	}
+	copies := a.clipboardCopies()
+	a.vectorsMu.Lock()
+	defer a.vectorsMu.Unlock()
+	if err := a.syncVectorIndexLocked(ctx, p, cfg.Model, copies); err != nil {
+		return nil, err
+	}
+	q, err := p.embed(ctx, cfg.Model, []string{query})
+	if err != nil {
+		return nil, err
+	}
+	matches := []SemanticMatch{}
+	for id, e := range a.vectors.entries {
+		if r, ok := a.history.get(id); ok {
+			matches = append(matches, SemanticMatch{Record: r, Score: dot(q[0], e.vector)})
+		}
+	}
+	for _, c := range copies {
+		if e, ok := a.vectors.clipboard[c.id]; ok {
+			r := HistoryRecord{ID: c.id, Kind: "clipboard", Text: c.text, CreatedAt: c.at}
+			matches = append(matches, SemanticMatch{Record: r, Score: dot(q[0], e.vector)})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
+
+// closeVectorIndex closes the database on shutdown
+func (a *App) closeVectorIndex() error {
+	a.vectorsMu.Lock()
+	defer a.vectorsMu.Unlock()
+	if a.vectors == nil {
+		return nil
+	}
+	err := a.vectors.close()
+	a.vectors = nil
+	return err
+}
+
+// SemanticSearch finds the notes, snippets, clipboard copies and past
+// conversation turns closest in meaning to query, embedding any not yet
+// indexed
+func (a *App) SemanticSearch(query string, limit int) ([]SemanticMatch, error) {
+	return a.semanticSearch(a.ctx, query, limit)
+}
+
+// relatedContext returns the auto-included records for prompt as a system
+// message, or "" when auto-include is off or nothing is close enough
+func (a *App) relatedContext(prompt string) string {
+	cfg := a.GetSettings().Retrieval
+	if !cfg.Enabled || !cfg.AutoInclude {
+		return ""
+	}
+	ctx, cancel := context.WithTimeout(a.ctx, retrievalTimeout)
+	defer cancel()
+	matches, err := a.semanticSearch(ctx, prompt, cfg.TopK)
+	if err != nil {
+		println("Error finding related notes:", err.Error())
+		return ""
+	}
+	var b strings.Builder
+	n := 0
+	for _, m := range matches {
+		if m.Score < cfg.MinScore {
+			continue
+		}
+		if n == 0 {
+			b.WriteString("Possibly relevant notes, copies and earlier messages from the user's history:\n")
+		}
+		fmt.Fprintf(&b, "\n[%s, %s]\n%s\n", m.Record.Kind, m.Record.CreatedAt.Format("2006-01-02"), embedText(m.Record.Text))
+		n++
+	}
+	return b.String()
+}
+
+// SetRetrieval saves the semantic search settings
+func (a *App) SetRetrieval(cfg RetrievalSettings) error {
+	if cfg.TopK < 0 || cfg.TopK > 20 {
+		return fmt.Errorf("top matches must be between 0 and 20")
+	}
+	return a.updateSettings(func(s *Settings) { s.Retrieval = cfg })
+}
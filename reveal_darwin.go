@@ -0,0 +1,8 @@
+package main
+
+import "os/exec"
+
+// revealInFolder selects path in a Finder window
+func revealInFolder(path string) error {
+	return exec.Command("open", "-R", path).Run()
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"os/exec"
+	"path/filepath"
+)
+
+// revealInFolder asks the file manager to select path over D-Bus, falling
+// back to opening the containing folder
+func revealInFolder(path string) error {
+	uri := "file://" + path
+	err := exec.Command("dbus-send", "--session", "--dest=org.freedesktop.FileManager1", "--type=method_call",
+		"/org/freedesktop/FileManager1", "org.freedesktop.FileManager1.ShowItems",
+		"array:string:"+uri, "string:").Run()
+	if err == nil {
+		return nil
+	}
+	return exec.Command("xdg-open", filepath.Dir(path)).Start()
+}
@@ -0,0 +1,16 @@
+package main
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// revealInFolder selects path in an Explorer window
+func revealInFolder(path string) error {
+	cmd := exec.Command("explorer.exe")
+	// explorer parses its own command line and needs /select, unquoted
+	cmd.SysProcAttr = &syscall.SysProcAttr{CmdLine: `explorer.exe /select,"` + path + `"`}
+	// explorer exits with 1 even on success
+	_ = cmd.Run()
+	return nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"time"
+)
+
+const defaultScreenshotPrompt = "Explain this error"
+
+// ScreenshotToPrompt captures a region, sends it with
+// Settings.ScreenshotPrompt and opens the overlay on the streaming answer.
+// The show-overlay event carries the request ID and prompt. Cancelling the
+// selection does nothing and returns an empty ID.
+func (a *App) ScreenshotToPrompt() (string, error) {
+	if err := a.requirePermission(capScreenCapture); err != nil {
+		return "", err
+	}
+	// Resolve the context while the user's app is still frontmost
+	show := a.resolveShowContext()
+	if a.GetWindowState().Visible {
+		a.HideOverlay()
+		// Give the window server a moment to take the overlay off screen
+		time.Sleep(200 * time.Millisecond)
+	}
+
+	path, err := captureRegionToTemp()
+	if errors.Is(err, errCaptureCancelled) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(path)
+	img, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	prompt := a.GetSettings().ScreenshotPrompt
+	if prompt == "" {
+		prompt = defaultScreenshotPrompt
+	}
+	id, err := a.sendPrompt(prompt, show, img)
+	if err != nil {
+		return "", err
+	}
+	ev := a.overlayEventFor("hotkey", show)
+	ev.Variant, ev.RequestID, ev.Prompt = "screenshot-prompt", id, prompt
+	eventShowOverlay.emit(a.ctx, ev)
+	return id, nil
+}
+
+// SetScreenshotPrompt sets the prompt ScreenshotToPrompt sends; empty
+// restores the default
+func (a *App) SetScreenshotPrompt(prompt string) error {
+	return a.updateSettings(func(s *Settings) { s.ScreenshotPrompt = prompt })
+}
@@ -0,0 +1,13 @@
+package main
+
+import "errors"
+
+// secretService is the service name secrets are filed under in the OS
+// keychain; each secret is an account within it
+const secretService = "overlae"
+
+var errSecretNotFound = errors.New("secret not found")
+
+// The platform files implement getSecret, setSecret and deleteSecret on
+// the OS credential store. getSecret returns errSecretNotFound for a
+// missing account and deleteSecret ignores one.
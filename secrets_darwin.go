@@ -0,0 +1,112 @@
+package main
+
+/*
+#cgo LDFLAGS: -framework Security -framework CoreFoundation
+#include <Security/Security.h>
+#include <stdlib.h>
+
+static CFMutableDictionaryRef ovSecretQuery(const char *service, const char *account) {
+	CFMutableDictionaryRef q = CFDictionaryCreateMutable(NULL, 0, &kCFTypeDictionaryKeyCallBacks, &kCFTypeDictionaryValueCallBacks);
+	CFStringRef svc = CFStringCreateWithCString(NULL, service, kCFStringEncodingUTF8);
+	CFStringRef acct = CFStringCreateWithCString(NULL, account, kCFStringEncodingUTF8);
+	CFDictionarySetValue(q, kSecClass, kSecClassGenericPassword);
+	CFDictionarySetValue(q, kSecAttrService, svc);
+	CFDictionarySetValue(q, kSecAttrAccount, acct);
+	CFRelease(svc);
+	CFRelease(acct);
+	return q;
+}
+
+static OSStatus ovSetSecret(const char *service, const char *account, const void *data, int n) {
+	CFMutableDictionaryRef q = ovSecretQuery(service, account);
+	CFDataRef value = CFDataCreate(NULL, data, n);
+	CFMutableDictionaryRef attrs = CFDictionaryCreateMutable(NULL, 0, &kCFTypeDictionaryKeyCallBacks, &kCFTypeDictionaryValueCallBacks);
+	CFDictionarySetValue(attrs, kSecValueData, value);
+	OSStatus st = SecItemUpdate(q, attrs);
+	if (st == errSecItemNotFound) {
+		CFDictionarySetValue(q, kSecValueData, value);
+		st = SecItemAdd(q, NULL);
+	}
+	CFRelease(attrs);
+	CFRelease(value);
+	CFRelease(q);
+	return st;
+}
+
+// ovGetSecret returns the secret in malloc'd memory
+static OSStatus ovGetSecret(const char *service, const char *account, void **out, int *n) {
+	CFMutableDictionaryRef q = ovSecretQuery(service, account);
+	CFDictionarySetValue(q, kSecReturnData, kCFBooleanTrue);
+	CFDictionarySetValue(q, kSecMatchLimit, kSecMatchLimitOne);
+	CFTypeRef result = NULL;
+	OSStatus st = SecItemCopyMatching(q, &result);
+	CFRelease(q);
+	if (st != errSecSuccess) return st;
+	CFDataRef data = (CFDataRef)result;
+	*n = (int)CFDataGetLength(data);
+	*out = malloc(*n > 0 ? *n : 1);
+	memcpy(*out, CFDataGetBytePtr(data), *n);
+	CFRelease(result);
+	return st;
+}
+
+static OSStatus ovDeleteSecret(const char *service, const char *account) {
+	CFMutableDictionaryRef q = ovSecretQuery(service, account);
+	OSStatus st = SecItemDelete(q);
+	CFRelease(q);
+	return st;
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+func keychainError(op string, st C.OSStatus) error {
+	return fmt.Errorf("keychain %s failed (OSStatus %d)", op, int(st))
+}
+
+// getSecret reads a generic password from the login keychain
+func getSecret(account string) (string, error) {
+	svc, acct := C.CString(secretService), C.CString(account)
+	defer C.free(unsafe.Pointer(svc))
+	defer C.free(unsafe.Pointer(acct))
+
+	var out unsafe.Pointer
+	var n C.int
+	switch st := C.ovGetSecret(svc, acct, &out, &n); st {
+	case C.errSecSuccess:
+		defer C.free(out)
+		return C.GoStringN((*C.char)(out), n), nil
+	case C.errSecItemNotFound:
+		return "", errSecretNotFound
+	default:
+		return "", keychainError("read", st)
+	}
+}
+
+func setSecret(account, value string) error {
+	svc, acct := C.CString(secretService), C.CString(account)
+	defer C.free(unsafe.Pointer(svc))
+	defer C.free(unsafe.Pointer(acct))
+	data := C.CBytes([]byte(value))
+	defer C.free(data)
+
+	if st := C.ovSetSecret(svc, acct, data, C.int(len(value))); st != C.errSecSuccess {
+		return keychainError("write", st)
+	}
+	return nil
+}
+
+func deleteSecret(account string) error {
+	svc, acct := C.CString(secretService), C.CString(account)
+	defer C.free(unsafe.Pointer(svc))
+	defer C.free(unsafe.Pointer(acct))
+
+	if st := C.ovDeleteSecret(svc, acct); st != C.errSecSuccess && st != C.errSecItemNotFound {
+		return keychainError("delete", st)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// secretTool runs libsecret's secret-tool, which talks to GNOME Keyring,
+// KWallet or any other Secret Service provider. It exits non-zero without
+// a message when nothing matches, which is reported as errSecretNotFound.
+func secretTool(stdin string, args ...string) (string, error) {
+	if _, err := exec.LookPath("secret-tool"); err != nil {
+		return "", errors.New("secret-tool not found (install libsecret-tools)")
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("secret-tool", args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("secret-tool: %s", msg)
+		}
+		return "", errSecretNotFound
+	}
+	return stdout.String(), nil
+}
+
+// getSecret looks the account up in the Secret Service
+func getSecret(account string) (string, error) {
+	return secretTool("", "lookup", "service", secretService, "account", account)
+}
+
+func setSecret(account, value string) error {
+	_, err := secretTool(value, "store", "--label", secretService+" "+account, "service", secretService, "account", account)
+	return err
+}
+
+func deleteSecret(account string) error {
+	_, err := secretTool("", "clear", "service", secretService, "account", account)
+	if errors.Is(err, errSecretNotFound) {
+		return nil
+	}
+	return err
+}
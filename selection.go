@@ -0,0 +1,33 @@
+package main
+
+import (
+	"time"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// captureSelection copies the current selection of the frontmost app by
+// injecting the copy shortcut, then puts the previous clipboard back.
+// It returns an empty string when nothing was selected, or when the
+// keystrokes permission has not been granted.
+func (a *App) captureSelection() (string, error) {
+	if err := a.requirePermission(capKeystrokes); err != nil {
+		return "", err
+	}
+	prev, _ := wailsruntime.ClipboardGetText(a.ctx)
+	if err := wailsruntime.ClipboardSetText(a.ctx, ""); err != nil {
+		return "", err
+	}
+	defer wailsruntime.ClipboardSetText(a.ctx, prev)
+
+	if err := sendCopy(); err != nil {
+		return "", err
+	}
+	for deadline := time.Now().Add(400 * time.Millisecond); time.Now().Before(deadline); {
+		time.Sleep(40 * time.Millisecond)
+		if text, err := wailsruntime.ClipboardGetText(a.ctx); err == nil && text != "" {
+			return text, nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,418 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2"
+	"github.com/wailsapp/wails/v2/pkg/options"
+	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	selectionPollInterval = 30 * time.Millisecond
+	// selectionDragMin is how far a press has to move to select text
+	selectionDragMin = 8
+	// selectionDoubleClick is the window for a second click, which selects
+	// a word
+	selectionDoubleClick = 500 * time.Millisecond
+	// selectionSettle lets the app finish selecting before it is read
+	selectionSettle = 150 * time.Millisecond
+	selectionMaxLen = 10000
+	// selectionHintTimeout hides a hint that wasn't used
+	selectionHintTimeout = 6 * time.Second
+	selectionHintWidth   = 240
+	selectionHintHeight  = 40
+	// selectionHintOffset keeps the hint clear of the cursor
+	selectionHintOffset = 12
+	explainPrompt       = "Explain this:\n\n"
+)
+
+// Selection hint actions
+const (
+	selectionTranslate = "translate"
+	selectionExplain   = "explain"
+	selectionCopyClean = "copy-clean"
+)
+
+var selectionActions = []string{selectionTranslate, selectionExplain, selectionCopyClean}
+
+// SelectionHintSettings controls the hint offered next to highlighted
+// text. Selections are only read while it is enabled.
+type SelectionHintSettings struct {
+	Enabled bool `json:"enabled"`
+	// Actions are the hint's buttons in order: "translate", "explain" and
+	// "copy-clean"
+	Actions []string `json:"actions"`
+	// ExcludedApps never get a hint; entries match app names or IDs,
+	// ignoring case
+	ExcludedApps []string `json:"excludedApps"`
+}
+
+// selectionHintShow is what the parent sends the hint window to show it;
+// X and Y place it relative to the display where Frame can't be applied
+type selectionHintShow struct {
+	Frame   Rect     `json:"frame"`
+	X       int      `json:"x"`
+	Y       int      `json:"y"`
+	Actions []string `json:"actions"`
+}
+
+// SetSelectionHint validates and saves the selection hint settings. Where
+// the selection is copied to be read, enabling it needs the keystrokes
+// permission.
+func (a *App) SetSelectionHint(cfg SelectionHintSettings) error {
+	if len(cfg.Actions) == 0 {
+		return errors.New("choose at least one action")
+	}
+	for _, action := range cfg.Actions {
+		if !slices.Contains(selectionActions, action) {
+			return fmt.Errorf("unknown action %q", action)
+		}
+	}
+	if cfg.Enabled && selectionInjectsCopy {
+		if err := a.requirePermission(capKeystrokes); err != nil {
+			return err
+		}
+	}
+	return a.updateSettings(func(s *Settings) { s.SelectionHint = cfg })
+}
+
+// watchSelection polls the left mouse button, since selecting text raises
+// no event other apps can see. A drag or a double click is taken as a
+// selection and offered a hint once the app reports text selected.
+func (a *App) watchSelection(ctx context.Context) {
+	ticker := time.NewTicker(selectionPollInterval)
+	defer ticker.Stop()
+
+	down := false
+	var downX, downY, clickX, clickY int
+	var clickAt time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		cfg := a.GetSettings().SelectionHint
+		if !cfg.Enabled || !a.HotkeysEnabled() {
+			if !cfg.Enabled {
+				a.closeSelectionHint()
+			}
+			down = false
+			continue
+		}
+		pressed, err := leftButtonDown()
+		if err != nil || pressed == down {
+			continue
+		}
+		down = pressed
+		x, y, err := cursorPosition()
+		if err != nil {
+			continue
+		}
+		if pressed {
+			downX, downY = x, y
+			if !a.insideSelectionHint(x, y) {
+				a.hideSelectionHint()
+			}
+			continue
+		}
+
+		dragged := abs(x-downX)+abs(y-downY) >= selectionDragMin
+		double := time.Since(clickAt) < selectionDoubleClick && abs(x-clickX)+abs(y-clickY) < selectionDragMin
+		clickAt, clickX, clickY = time.Now(), x, y
+		if (dragged || double) && !a.insideSelectionHint(x, y) {
+			go a.offerSelectionHint(cfg, x, y)
+		}
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// offerSelectionHint reads what the frontmost app has selected and shows
+// the hint at x, y if there is any text
+func (a *App) offerSelectionHint(cfg SelectionHintSettings, x, y int) {
+	a.selectionMu.Lock()
+	if a.selectionReading {
+		a.selectionMu.Unlock()
+		return
+	}
+	a.selectionReading = true
+	a.selectionMu.Unlock()
+	defer func() {
+		a.selectionMu.Lock()
+		a.selectionReading = false
+		a.selectionMu.Unlock()
+	}()
+
+	time.Sleep(selectionSettle)
+	if selectionInjectsCopy && a.requirePermission(capKeystrokes) != nil {
+		return
+	}
+	app, err := activeApp()
+	if err != nil || a.isOwnProcess(app.PID) || selectionExcluded(cfg, app) {
+		return
+	}
+	text, err := readSelection(a, app)
+	if err != nil {
+		println("Error reading selection:", err.Error())
+		return
+	}
+	if text = strings.TrimSpace(text); text == "" || len(text) > selectionMaxLen {
+		return
+	}
+	show := ShowContext{Mode: a.GetSettings().DefaultMode, App: app, Selection: text}
+	if err := a.showSelectionHint(show, cfg.Actions, x, y); err != nil {
+		println("Error showing selection hint:", err.Error())
+	}
+}
+
+func selectionExcluded(cfg SelectionHintSettings, app ActiveApp) bool {
+	return slices.ContainsFunc(cfg.ExcludedApps, func(e string) bool {
+		return strings.EqualFold(e, app.Name) || strings.EqualFold(e, app.ID)
+	})
+}
+
+// isOwnProcess reports whether pid is overlae or its hint window, whose
+// selections are never offered
+func (a *App) isOwnProcess(pid int) bool {
+	if pid == os.Getpid() {
+		return true
+	}
+	a.selectionMu.Lock()
+	defer a.selectionMu.Unlock()
+	return a.selectionHint.running() && a.selectionHint.cmd.Process.Pid == pid
+}
+
+// showSelectionHint places the hint just below and right of the cursor,
+// starting its window the first time
+func (a *App) showSelectionHint(show ShowContext, actions []string, x, y int) error {
+	displays, err := listDisplays()
+	if err != nil {
+		return err
+	}
+	display, ok := cursorDisplay(displays)
+	if !ok {
+		return errors.New("no displays found")
+	}
+	frame := clampInto(Rect{
+		X:      x + display.toFrame(selectionHintOffset),
+		Y:      y + display.toFrame(selectionHintOffset),
+		Width:  display.toFrame(selectionHintWidth),
+		Height: display.toFrame(selectionHintHeight),
+	}, display.WorkArea)
+
+	a.selectionMu.Lock()
+	defer a.selectionMu.Unlock()
+	if !a.selectionHint.running() {
+		child, err := spawnChildWindow("selection-hint", nil, a.handleSelectionHintMessage)
+		if err != nil {
+			return err
+		}
+		a.selectionHint = child
+	}
+	a.selectionShow, a.selectionFrame = show, frame
+	return a.selectionHint.send("show", selectionHintShow{
+		Frame:   frame,
+		X:       display.fromFrame(frame.X - display.Bounds.X),
+		Y:       display.fromFrame(frame.Y - display.Bounds.Y),
+		Actions: actions,
+	})
+}
+
+func (a *App) insideSelectionHint(x, y int) bool {
+	a.selectionMu.Lock()
+	defer a.selectionMu.Unlock()
+	return a.selectionHint.running() && a.selectionFrame.contains(x, y)
+}
+
+func (a *App) hideSelectionHint() {
+	a.selectionMu.Lock()
+	defer a.selectionMu.Unlock()
+	if a.selectionHint.running() && a.selectionFrame != (Rect{}) {
+		a.selectionFrame = Rect{}
+		_ = a.selectionHint.send("hide", nil)
+	}
+}
+
+// closeSelectionHint quits the hint window once the hint is turned off and
+// on shutdown
+func (a *App) closeSelectionHint() {
+	a.selectionMu.Lock()
+	child := a.selectionHint
+	a.selectionHint, a.selectionFrame = nil, Rect{}
+	a.selectionMu.Unlock()
+	child.close()
+}
+
+func (a *App) handleSelectionHintMessage(msg childCommand) {
+	var action string
+	if msg.Cmd != "action" || json.Unmarshal(msg.Payload, &action) != nil {
+		return
+	}
+	a.selectionMu.Lock()
+	show := a.selectionShow
+	a.selectionFrame = Rect{}
+	a.selectionMu.Unlock()
+	if err := a.runSelectionAction(action, show); err != nil {
+		println("Error running selection action:", err.Error())
+	}
+}
+
+// runSelectionAction carries out a hint button on the selection it was
+// shown for. Translations and explanations open in the overlay.
+func (a *App) runSelectionAction(action string, show ShowContext) error {
+	text := show.Selection
+	switch action {
+	case selectionCopyClean:
+		clean := cleanSelection(text)
+		a.markOwnClipboard(clean)
+		return wailsruntime.ClipboardSetText(a.ctx, clean)
+	case selectionTranslate:
+		t, err := a.Translate(text, "")
+		if err != nil {
+			return err
+		}
+		a.rememberShowContext(show)
+		ev := a.overlayEventFor("selection-hint", show)
+		ev.Variant = "translation"
+		eventShowOverlay.emit(a.ctx, ev)
+		eventTranslation.emit(a.ctx, t)
+		return nil
+	case selectionExplain:
+		prompt := explainPrompt + text
+		a.rememberShowContext(show)
+		id, err := a.sendPrompt(prompt, show)
+		if err != nil {
+			return err
+		}
+		ev := a.overlayEventFor("selection-hint", show)
+		ev.Variant, ev.RequestID, ev.Prompt = "selection-hint", id, prompt
+		eventShowOverlay.emit(a.ctx, ev)
+		return nil
+	}
+	return fmt.Errorf("unknown action %q", action)
+}
+
+var (
+	// hyphenBreak is a word hyphenated across a line break
+	hyphenBreak = regexp.MustCompile(`(\p{L})-\n(\p{Ll})`)
+	// paragraphBreak separates paragraphs, which stay apart
+	paragraphBreak = regexp.MustCompile(`\n\s*\n`)
+	spaceRun       = regexp.MustCompile(`\s+`)
+)
+
+// cleanSelection undoes what copying from PDFs and terminals does to
+// text: lines broken mid-sentence are joined, hyphenated words mended and
+// runs of spaces collapsed, while paragraphs stay separate
+func cleanSelection(text string) string {
+	text = strings.NewReplacer("\r\n", "\n", "\u00ad", "", "\u200b", "", "\u00a0", " ").Replace(text)
+	text = hyphenBreak.ReplaceAllString(text, "$1$2")
+	var paragraphs []string
+	for _, p := range paragraphBreak.Split(text, -1) {
+		if p = strings.TrimSpace(spaceRun.ReplaceAllString(p, " ")); p != "" {
+			paragraphs = append(paragraphs, p)
+		}
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// SelectionHintWindow is bound in the selection hint child process
+type SelectionHintWindow struct {
+	ctx context.Context
+
+	mu        sync.Mutex
+	actions   []string
+	hideTimer *time.Timer
+}
+
+// runSelectionHintWindow runs the frameless hint window, hidden until the
+// parent shows it over a selection
+func runSelectionHintWindow(map[string]string) error {
+	w := &SelectionHintWindow{}
+	return wails.Run(&options.App{
+		Title:            "Overlae Selection",
+		Width:            selectionHintWidth,
+		Height:           selectionHintHeight,
+		DisableResize:    true,
+		Frameless:        true,
+		AlwaysOnTop:      true,
+		StartHidden:      true,
+		BackgroundColour: &options.RGBA{R: 27, G: 38, B: 54, A: 1},
+		AssetServer: &assetserver.Options{
+			Assets: assets,
+		},
+		OnStartup: w.startup,
+		Bind: []interface{}{
+			w,
+			&WindowInfo{kind: "selection-hint"},
+		},
+	})
+}
+
+func (w *SelectionHintWindow) startup(ctx context.Context) {
+	w.ctx = ctx
+	go readParentCommands(func(cmd childCommand) {
+		switch cmd.Cmd {
+		case "show":
+			var show selectionHintShow
+			if json.Unmarshal(cmd.Payload, &show) == nil {
+				w.show(show)
+			}
+		case "hide":
+			w.Dismiss()
+		case "close":
+			wailsruntime.Quit(ctx)
+		}
+	}, func() { wailsruntime.Quit(ctx) })
+}
+
+func (w *SelectionHintWindow) show(show selectionHintShow) {
+	w.mu.Lock()
+	w.actions = show.Actions
+	if w.hideTimer != nil {
+		w.hideTimer.Stop()
+	}
+	w.hideTimer = time.AfterFunc(selectionHintTimeout, w.Dismiss)
+	w.mu.Unlock()
+
+	if err := setOwnWindowFrame(show.Frame); err != nil {
+		wailsruntime.WindowSetPosition(w.ctx, show.X, show.Y)
+	}
+	eventSelectionHintShown.emit(w.ctx, show.Actions)
+	wailsruntime.WindowShow(w.ctx)
+}
+
+// GetActions returns the buttons of the hint being shown
+func (w *SelectionHintWindow) GetActions() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.actions
+}
+
+// Choose hides the hint and has the parent run action
+func (w *SelectionHintWindow) Choose(action string) error {
+	w.Dismiss()
+	return sendParent("action", action)
+}
+
+// Dismiss hides the hint
+func (w *SelectionHintWindow) Dismiss() {
+	wailsruntime.WindowHide(w.ctx)
+}
@@ -0,0 +1,60 @@
+package main
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Cocoa -framework ApplicationServices
+#import <Cocoa/Cocoa.h>
+#include <ApplicationServices/ApplicationServices.h>
+#include <stdlib.h>
+
+static int ovLeftButtonDown(void) {
+	return ([NSEvent pressedMouseButtons] & 1) != 0;
+}
+
+// ovSelectedText returns the selected text of the focused element, or NULL
+// when the app exposes none; the caller frees it
+static char *ovSelectedText(void) {
+	if (!AXIsProcessTrusted()) return NULL;
+	AXUIElementRef sys = AXUIElementCreateSystemWide();
+	CFTypeRef focused = NULL, value = NULL;
+	char *out = NULL;
+	if (AXUIElementCopyAttributeValue(sys, kAXFocusedUIElementAttribute, &focused) == kAXErrorSuccess && focused != NULL) {
+		if (AXUIElementCopyAttributeValue((AXUIElementRef)focused, kAXSelectedTextAttribute, &value) == kAXErrorSuccess && value != NULL) {
+			if (CFGetTypeID(value) == CFStringGetTypeID()) {
+				CFIndex size = CFStringGetMaximumSizeForEncoding(CFStringGetLength(value), kCFStringEncodingUTF8) + 1;
+				out = malloc(size);
+				if (!CFStringGetCString(value, out, size, kCFStringEncodingUTF8)) {
+					free(out);
+					out = NULL;
+				}
+			}
+			CFRelease(value);
+		}
+		CFRelease(focused);
+	}
+	CFRelease(sys);
+	return out;
+}
+*/
+import "C"
+
+import "unsafe"
+
+// Apps that don't expose their selection to Accessibility are copied from
+// with Cmd+C
+const selectionInjectsCopy = true
+
+// leftButtonDown reports whether the left mouse button is held
+func leftButtonDown() (bool, error) {
+	return C.ovLeftButtonDown() != 0, nil
+}
+
+// readSelection asks Accessibility for the selection of the focused
+// element, copying it only for apps that don't say
+func readSelection(a *App, _ ActiveApp) (string, error) {
+	if text := C.ovSelectedText(); text != nil {
+		defer C.free(unsafe.Pointer(text))
+		return C.GoString(text), nil
+	}
+	return a.captureSelection()
+}
@@ -0,0 +1,74 @@
+package main
+
+/*
+#cgo LDFLAGS: -lX11
+#include <X11/Xlib.h>
+
+static Display *ovPointerDisplay;
+
+// ovLeftButtonDown returns -1 when the X display cannot be opened
+static int ovLeftButtonDown(void) {
+	if (ovPointerDisplay == NULL && (ovPointerDisplay = XOpenDisplay(NULL)) == NULL) return -1;
+	Window root, child;
+	int rx, ry, wx, wy;
+	unsigned int mask = 0;
+	XQueryPointer(ovPointerDisplay, DefaultRootWindow(ovPointerDisplay), &root, &child, &rx, &ry, &wx, &wy, &mask);
+	return (mask & Button1Mask) != 0;
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"os/exec"
+	"sync"
+)
+
+// The primary selection is read as is, so no copy is injected
+const selectionInjectsCopy = false
+
+// primarySelectionTools print the primary selection
+var primarySelectionTools = [][]string{
+	{"wl-paste", "--primary", "--no-newline"},
+	{"xclip", "-o", "-selection", "primary"},
+	{"xsel", "-o", "-p"},
+}
+
+var (
+	primaryMu   sync.Mutex
+	lastPrimary string
+)
+
+// leftButtonDown reports whether the left mouse button is held
+func leftButtonDown() (bool, error) {
+	switch C.ovLeftButtonDown() {
+	case -1:
+		return false, errors.New("cannot open the X display")
+	case 0:
+		return false, nil
+	}
+	return true, nil
+}
+
+// readSelection returns the primary selection X11 keeps for whatever was
+// last highlighted. It outlives the highlight, so text already offered is
+// not returned again.
+func readSelection(a *App, _ ActiveApp) (string, error) {
+	for _, tool := range primarySelectionTools {
+		if _, err := exec.LookPath(tool[0]); err != nil {
+			continue
+		}
+		out, err := exec.Command(tool[0], tool[1:]...).Output()
+		if err != nil {
+			return "", nil
+		}
+		primaryMu.Lock()
+		defer primaryMu.Unlock()
+		if string(out) == lastPrimary {
+			return "", nil
+		}
+		lastPrimary = string(out)
+		return lastPrimary, nil
+	}
+	return "", errors.New("no clipboard tool found (install wl-clipboard, xclip or xsel)")
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Windows has no selection API short of UI Automation, so the selection is
+// copied with Ctrl+C
+const selectionInjectsCopy = true
+
+const vkLButton = 0x01
+
+// consoleApps treat Ctrl+C as an interrupt, so their selection is never
+// copied
+var consoleApps = []string{
+	"windowsterminal.exe", "openconsole.exe", "conhost.exe", "cmd.exe", "powershell.exe",
+	"pwsh.exe", "mintty.exe", "alacritty.exe", "wezterm-gui.exe", "putty.exe",
+}
+
+// leftButtonDown reports whether the left mouse button is held
+func leftButtonDown() (bool, error) {
+	return keyDown(vkLButton), nil
+}
+
+// readSelection copies the selection of app, skipping consoles
+func readSelection(a *App, app ActiveApp) (string, error) {
+	if slices.Contains(consoleApps, strings.ToLower(filepath.Base(app.ID))) {
+		return "", nil
+	}
+	return a.captureSelection()
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// System check IDs; the same requirement has the same ID on every
+// platform, though what is checked differs
+const (
+	checkAccessibility   = "accessibility"
+	checkScreenRecording = "screen-recording"
+	checkMicrophone      = "microphone"
+	checkInputMonitoring = "input-monitoring"
+)
+
+// System check states. Not asked means the OS will prompt the first time
+// the feature is used.
+const (
+	checkOK       = "ok"
+	checkMissing  = "missing"
+	checkNotAsked = "not-asked"
+)
+
+// SystemCheck is one OS permission or prerequisite that features depend
+// on, with how to fix it when missing
+type SystemCheck struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	// Status is "ok", "missing" or "not-asked"
+	Status string `json:"status"`
+	// Affects names what fails without it
+	Affects string `json:"affects"`
+	// Repair says how to grant it; empty when OK
+	Repair string `json:"repair,omitempty"`
+	// SettingsURL opens the system settings pane for it, where the
+	// platform has one
+	SettingsURL string `json:"settingsUrl,omitempty"`
+}
+
+// SelfCheck is the result of checking every requirement
+type SelfCheck struct {
+	Checks []SystemCheck `json:"checks"`
+	// Missing counts the checks that failed
+	Missing   int       `json:"missing"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// RunSelfCheck checks the OS permissions this platform gates features
+// behind and sends the result in a self-check event. Grants often only
+// apply after a restart, so it can be rerun any time.
+func (a *App) RunSelfCheck() SelfCheck {
+	result := SelfCheck{Checks: systemChecks(), CheckedAt: time.Now()}
+	for i, c := range result.Checks {
+		if c.Status == checkOK {
+			result.Checks[i].Repair = ""
+		}
+		if c.Status == checkMissing {
+			result.Missing++
+		}
+	}
+	a.selfCheckMu.Lock()
+	a.selfCheck = &result
+	a.selfCheckMu.Unlock()
+	eventSelfCheck.emit(a.ctx, result)
+	return result
+}
+
+// GetSelfCheck returns the last self-check, running one if there is none
+func (a *App) GetSelfCheck() SelfCheck {
+	a.selfCheckMu.Lock()
+	last := a.selfCheck
+	a.selfCheckMu.Unlock()
+	if last != nil {
+		return *last
+	}
+	return a.RunSelfCheck()
+}
+
+// OpenSystemSettings opens the settings pane where the check with id is
+// granted
+func (a *App) OpenSystemSettings(id string) error {
+	checks := systemChecks()
+	i := slices.IndexFunc(checks, func(c SystemCheck) bool { return c.ID == id })
+	if i < 0 {
+		return fmt.Errorf("unknown check %q", id)
+	}
+	url := checks[i].SettingsURL
+	if url == "" {
+		return errors.New("this platform has no settings pane for " + id)
+	}
+	return openSettingsURL(url)
+}
+
+// startupSelfCheck logs what is missing so silent failures later have an
+// explanation
+func (a *App) startupSelfCheck() {
+	for _, c := range a.RunSelfCheck().Checks {
+		if c.Status == checkMissing {
+			println("Missing", c.Title+":", c.Affects, "will not work;", c.Repair)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework ApplicationServices -framework AVFoundation -framework IOKit
+#import <AVFoundation/AVFoundation.h>
+#include <ApplicationServices/ApplicationServices.h>
+#include <IOKit/hidsystem/IOHIDLib.h>
+
+static int ovAccessibilityTrusted(void) {
+	return AXIsProcessTrusted();
+}
+
+static int ovScreenCaptureAllowed(void) {
+	return CGPreflightScreenCaptureAccess();
+}
+
+// ovMicrophoneStatus is 0 when not yet asked, 1 when allowed and 2 when
+// denied or restricted
+static int ovMicrophoneStatus(void) {
+	switch ([AVCaptureDevice authorizationStatusForMediaType:AVMediaTypeAudio]) {
+	case AVAuthorizationStatusNotDetermined:
+		return 0;
+	case AVAuthorizationStatusAuthorized:
+		return 1;
+	default:
+		return 2;
+	}
+}
+
+// ovInputMonitoringStatus uses the same codes as ovMicrophoneStatus
+static int ovInputMonitoringStatus(void) {
+	switch (IOHIDCheckAccess(kIOHIDRequestTypeListenEvent)) {
+	case kIOHIDAccessTypeGranted:
+		return 1;
+	case kIOHIDAccessTypeDenied:
+		return 2;
+	default:
+		return 0;
+	}
+}
+*/
+import "C"
+
+import "os/exec"
+
+const privacySettings = "x-apple.systempreferences:com.apple.preference.security?"
+
+// tccStatus maps the codes returned above to a check status
+func tccStatus(code C.int) string {
+	switch code {
+	case 1:
+		return checkOK
+	case 2:
+		return checkMissing
+	}
+	return checkNotAsked
+}
+
+func boolStatus(ok C.int) string {
+	if ok != 0 {
+		return checkOK
+	}
+	return checkMissing
+}
+
+// systemChecks reads the privacy permissions macOS asks for
+func systemChecks() []SystemCheck {
+	return []SystemCheck{
+		{
+			ID: checkAccessibility, Title: "Accessibility", Status: boolStatus(C.ovAccessibilityTrusted()),
+			Affects:     "pasting, reading selections, arranging windows and the mouse trigger",
+			Repair:      "turn on overlae in System Settings > Privacy & Security > Accessibility",
+			SettingsURL: privacySettings + "Privacy_Accessibility",
+		},
+		{
+			ID: checkScreenRecording, Title: "Screen Recording", Status: boolStatus(C.ovScreenCaptureAllowed()),
+			Affects:     "screenshots and screen recordings",
+			Repair:      "turn on overlae in System Settings > Privacy & Security > Screen Recording, then restart it",
+			SettingsURL: privacySettings + "Privacy_ScreenCapture",
+		},
+		{
+			ID: checkMicrophone, Title: "Microphone", Status: tccStatus(C.ovMicrophoneStatus()),
+			Affects:     "dictation and the wake word",
+			Repair:      "turn on overlae in System Settings > Privacy & Security > Microphone",
+			SettingsURL: privacySettings + "Privacy_Microphone",
+		},
+		{
+			ID: checkInputMonitoring, Title: "Input Monitoring", Status: tccStatus(C.ovInputMonitoringStatus()),
+			Affects:     "recording shortcuts and the mouse trigger",
+			Repair:      "turn on overlae in System Settings > Privacy & Security > Input Monitoring, then restart it",
+			SettingsURL: privacySettings + "Privacy_ListenEvent",
+		},
+	}
+}
+
+func openSettingsURL(url string) error {
+	return exec.Command("open", url).Run()
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"slices"
+)
+
+// systemChecks looks for the session and tools features rely on, since
+// Linux has no permission prompts. Wayland keeps apps from typing into or
+// reading other windows and from grabbing keys globally.
+func systemChecks() []SystemCheck {
+	wayland := os.Getenv("XDG_SESSION_TYPE") == "wayland"
+	x11 := os.Getenv("DISPLAY") != "" && !wayland
+	sessionStatus := func(tools ...string) SystemCheck {
+		switch {
+		case wayland:
+			return SystemCheck{Status: checkMissing, Repair: "log in to an X11 session; Wayland doesn't allow this"}
+		case !x11:
+			return SystemCheck{Status: checkMissing, Repair: "run overlae in an X11 session; no X display was found"}
+		}
+		if len(tools) > 0 && !slices.ContainsFunc(tools, hasTool) {
+			return SystemCheck{Status: checkMissing, Repair: "install " + tools[0]}
+		}
+		return SystemCheck{Status: checkOK}
+	}
+	toolStatus := func(repair string, tools ...string) SystemCheck {
+		if slices.ContainsFunc(tools, hasTool) {
+			return SystemCheck{Status: checkOK}
+		}
+		return SystemCheck{Status: checkMissing, Repair: repair}
+	}
+
+	checks := []SystemCheck{
+		sessionStatus("xdotool"),
+		toolStatus("install grim or gnome-screenshot, and ffmpeg or wf-recorder for recordings",
+			"grim", "gnome-screenshot", "spectacle", "scrot", "import"),
+		toolStatus("install PulseAudio or PipeWire with pactl", "pactl"),
+		sessionStatus(),
+	}
+	for i, c := range []struct{ id, title, affects string }{
+		{checkAccessibility, "Window access", "pasting, reading selections and arranging windows"},
+		{checkScreenRecording, "Screen capture", "screenshots and screen recordings"},
+		{checkMicrophone, "Audio", "dictation and audio device switching"},
+		{checkInputMonitoring, "Global input", "hotkeys and the mouse trigger"},
+	} {
+		checks[i].ID, checks[i].Title, checks[i].Affects = c.id, c.title, c.affects
+	}
+	return checks
+}
+
+func hasTool(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
+
+func openSettingsURL(string) error {
+	return errors.New("no settings pane on Linux")
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+var procRegGetValue = advapi32.NewProc("RegGetValueW")
+
+const (
+	hkeyCurrentUser  = 0x80000001
+	hkeyLocalMachine = 0x80000002
+	rrfRtRegSz       = 0x00000002
+	// consentStore holds the privacy switches of Settings > Privacy
+	consentStore = `Software\Microsoft\Windows\CurrentVersion\CapabilityAccessManager\ConsentStore\`
+)
+
+// regString reads a REG_SZ value, returning "" when it is absent
+func regString(root uintptr, key, name string) string {
+	k, _ := syscall.UTF16PtrFromString(key)
+	n, _ := syscall.UTF16PtrFromString(name)
+	buf := make([]uint16, 64)
+	size := uint32(len(buf) * 2)
+	if r, _, _ := procRegGetValue.Call(root, uintptr(unsafe.Pointer(k)), uintptr(unsafe.Pointer(n)), rrfRtRegSz, 0,
+		uintptr(unsafe.Pointer(&buf[0])), uintptr(unsafe.Pointer(&size))); r != 0 {
+		return ""
+	}
+	return syscall.UTF16ToString(buf)
+}
+
+// consentStatus reads a privacy switch: the device-wide one, the one for
+// the user, and the one for desktop apps, any of which can deny access
+func consentStatus(capability string) string {
+	for _, v := range []string{
+		regString(hkeyLocalMachine, consentStore+capability, "Value"),
+		regString(hkeyCurrentUser, consentStore+capability, "Value"),
+		regString(hkeyCurrentUser, consentStore+capability+`\NonPackaged`, "Value"),
+	} {
+		if v == "Deny" {
+			return checkMissing
+		}
+	}
+	return checkOK
+}
+
+// systemChecks reads the privacy switches. Windows does not gate
+// accessibility, screen capture or input monitoring for desktop apps.
+func systemChecks() []SystemCheck {
+	return []SystemCheck{
+		{
+			ID: checkMicrophone, Title: "Microphone", Status: consentStatus("microphone"),
+			Affects:     "dictation and the wake word",
+			Repair:      `turn on "Microphone access" and "Let desktop apps access your microphone" in Settings > Privacy & security > Microphone`,
+			SettingsURL: "ms-settings:privacy-microphone",
+		},
+	}
+}
+
+func openSettingsURL(url string) error {
+	return shellOpen(url)
+}
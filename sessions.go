@@ -0,0 +1,120 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const sessionFile = "session.json"
+
+// Attachment describes context that went out with an input. Only the kind
+// and size are kept, not the content itself, except for files such as
+// recordings that were explicitly attached to a record.
+type Attachment struct {
+	Kind string `json:"kind"`
+	Size int    `json:"size"`
+	// Path is the stored file, under the config directory
+	Path string `json:"path,omitempty"`
+}
+
+// Session is one conversation: the inputs and responses sent under the
+// same session ID, oldest first
+type Session struct {
+	ID      string          `json:"id"`
+	Records []HistoryRecord `json:"records"`
+	// ScrollAnchor is the record that was at the top of the transcript
+	ScrollAnchor string    `json:"scrollAnchor,omitempty"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// sessionState is the layout of session.json
+type sessionState struct {
+	SessionID    string `json:"sessionId"`
+	ScrollAnchor string `json:"scrollAnchor"`
+}
+
+func (a *App) currentSession() string {
+	a.sessionMu.Lock()
+	defer a.sessionMu.Unlock()
+	return a.sessionID
+}
+
+// attachmentsOf lists the context items a prompt actually carried
+func attachmentsOf(preview PromptPreview) []Attachment {
+	var attachments []Attachment
+	for _, item := range preview.Items {
+		if item.Used && item.Enabled && item.Value != "" {
+			attachments = append(attachments, Attachment{Kind: item.Variable, Size: len(item.Value)})
+		}
+	}
+	return attachments
+}
+
+func (a *App) loadSession(id string) (Session, error) {
+	if a.history == nil {
+		return Session{}, errors.New("history is unavailable")
+	}
+	s := Session{ID: id, Records: a.history.list(func(r HistoryRecord) bool { return r.SessionID == id })}
+	if len(s.Records) == 0 {
+		return Session{}, fmt.Errorf("no session %q", id)
+	}
+	s.UpdatedAt = s.Records[len(s.Records)-1].CreatedAt
+
+	var state sessionState
+	if err := readJSONConfig(sessionFile, &state); err == nil && state.SessionID == id {
+		s.ScrollAnchor = state.ScrollAnchor
+	}
+	return s, nil
+}
+
+// GetLastSession returns the most recent session before this launch, so
+// the overlay can offer to resume it after a restart or crash. The ID is
+// empty when there is none.
+func (a *App) GetLastSession() (Session, error) {
+	if a.history == nil {
+		return Session{}, nil
+	}
+	current := a.currentSession()
+	records := a.history.list(func(r HistoryRecord) bool { return r.SessionID != "" && r.SessionID != current })
+	if len(records) == 0 {
+		return Session{}, nil
+	}
+	return a.loadSession(records[len(records)-1].SessionID)
+}
+
+// ResumeSession makes id the current session, so new prompts continue it,
+// restores its persona and returns its transcript
+func (a *App) ResumeSession(id string) (Session, error) {
+	s, err := a.loadSession(id)
+	if err != nil {
+		return Session{}, err
+	}
+	persona := ""
+	for _, r := range s.Records {
+		if r.Kind == "input" {
+			persona = r.PersonaID
+		}
+	}
+	if _, err := a.persona(persona); err != nil {
+		persona = ""
+	}
+	a.sessionMu.Lock()
+	a.sessionID, a.sessionKeyID, a.sessionPersonaID = id, "", persona
+	a.sessionMu.Unlock()
+	return s, nil
+}
+
+// NewSession starts a fresh conversation and returns its ID
+func (a *App) NewSession() string {
+	a.sessionMu.Lock()
+	defer a.sessionMu.Unlock()
+	a.sessionID, a.sessionKeyID, a.sessionPersonaID = newID(), "", ""
+	return a.sessionID
+}
+
+// SetScrollAnchor remembers the record at the top of the transcript so a
+// resumed session opens where it was left
+func (a *App) SetScrollAnchor(recordID string) error {
+	return writeJSONConfig(sessionFile, sessionState{SessionID: a.currentSession(), ScrollAnchor: recordID}, 0o644)
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const settingsFile = "settings.json"
+
+// Settings holds the user preferences persisted in the config directory
+type Settings struct {
+	// DefaultMode is the overlay mode used when no context rule matches
+	DefaultMode  string        `json:"defaultMode"`
+	ContextRules []ContextRule `json:"contextRules"`
+
+	ContextPrivacy ContextPrivacy `json:"contextPrivacy"`
+
+	// CompanionCorner is where the companion window is docked
+	CompanionCorner string `json:"companionCorner"`
+	// Hotkeys overrides shortcuts by binding name, e.g.
+	// {"show-overlay": "Ctrl+Alt+Space"}; see defaultHotkeys
+	Hotkeys map[string]string `json:"hotkeys"`
+	// Keymap overrides the overlay's own shortcuts by action; see
+	// defaultKeymap
+	Keymap map[string]string `json:"keymap,omitempty"`
+	// MouseTrigger is a mouse button spec such as "Mouse4" that shows the
+	// overlay; empty disables it
+	MouseTrigger string `json:"mouseTrigger"`
+	// HideOnBlur is "always", "never" or "when-empty"; see OnWindowBlur
+	HideOnBlur string `json:"hideOnBlur"`
+	// BlurGraceMs delays that hide, cancelled if focus comes back
+	BlurGraceMs int `json:"blurGraceMs"`
+	// SnapHotkeys enables Cmd/Ctrl+Option/Alt shortcuts for SnapOverlay
+	SnapHotkeys bool `json:"snapHotkeys"`
+	// WindowHotkeys enables Cmd/Ctrl+Option/Alt+Shift shortcuts for
+	// ArrangeWindow
+	WindowHotkeys bool `json:"windowHotkeys"`
+
+	Provider ProviderSettings `json:"provider"`
+	// Failover lists providers to retry a failed request on
+	Failover FailoverSettings `json:"failover"`
+	// PostProcess cleans responses up before they are shown and pasted
+	PostProcess PostProcessSettings `json:"postProcess"`
+	// ScreenshotPrompt is sent with the region ScreenshotToPrompt
+	// captures
+	ScreenshotPrompt string `json:"screenshotPrompt"`
+	// MaxConcurrentRequests caps how many prompts stream at once; the
+	// rest wait in a queue
+	MaxConcurrentRequests int `json:"maxConcurrentRequests"`
+	// StreamBatchMs gathers streamed text into one response-chunk event
+	// per window; 0 sends every delta
+	StreamBatchMs int `json:"streamBatchMs"`
+	// QueryEscalateMs is how long Query shows only local results before
+	// asking the model. It is opt-in, since the typed text then goes to
+	// the provider unasked; 0 waits for EscalateQuery.
+	QueryEscalateMs int `json:"queryEscalateMs"`
+	// ResponseCache reuses answers to identical prompts for a while
+	ResponseCache CacheSettings `json:"responseCache"`
+
+	Appearance AppearanceSettings `json:"appearance"`
+	SpellCheck SpellCheckSettings `json:"spellCheck"`
+	Audio      AudioSettings      `json:"audio"`
+	WakeWord   WakeWordSettings   `json:"wakeWord"`
+
+	ClipboardSuggestions ClipboardSuggestionSettings `json:"clipboardSuggestions"`
+	// SelectionHint offers quick actions next to text highlighted in
+	// other apps
+	SelectionHint SelectionHintSettings `json:"selectionHint"`
+	// Updates selects the release channel and any pinned or skipped
+	// version
+	Updates UpdateSettings `json:"updates"`
+	// Dictionary controls define lookups
+	Dictionary DictionarySettings `json:"dictionary"`
+	// Translation configures the translate quick action
+	Translation TranslationSettings `json:"translation"`
+	// Retrieval is semantic search over notes and history
+	Retrieval RetrievalSettings `json:"retrieval"`
+	// Workspace is the project folder code questions draw on
+	Workspace WorkspaceSettings `json:"workspace"`
+	// Storage bounds the space temp files and caches may use
+	Storage StorageSettings `json:"storage"`
+	// DownloadsDir is where downloads are saved; empty means ~/Downloads
+	DownloadsDir string `json:"downloadsDir"`
+	// Links controls how OpenURL confirms links from responses
+	Links LinkSettings `json:"links"`
+	// SystemControls decides which system commands are confirmed
+	SystemControls SystemControlSettings `json:"systemControls"`
+
+	Sync SyncSettings `json:"sync"`
+	// LocalAPI serves /health, /status and integrations on 127.0.0.1
+	LocalAPI LocalAPISettings `json:"localApi"`
+}
+
+func defaultSettings() Settings {
+	return Settings{
+		DefaultMode:           "chat",
+		CompanionCorner:       "top-right",
+		HideOnBlur:            hideOnBlurAlways,
+		BlurGraceMs:           300,
+		Provider:              ProviderSettings{Kind: "openai", Model: "gpt-4o-mini"},
+		MaxConcurrentRequests: 2,
+		StreamBatchMs:         defaultStreamBatchMs,
+		ScreenshotPrompt:      defaultScreenshotPrompt,
+		ResponseCache:         CacheSettings{TTLMinutes: 60},
+		Appearance:            AppearanceSettings{Zoom: 1, FontSize: 14},
+		SpellCheck:            SpellCheckSettings{Enabled: true},
+		Sync:                  SyncSettings{Conflict: "newest"},
+		ClipboardSuggestions:  ClipboardSuggestionSettings{Rules: defaultClipboardRules()},
+		SelectionHint:         SelectionHintSettings{Actions: selectionActions},
+		Links:                 LinkSettings{Confirm: confirmLinksUnknown},
+		SystemControls:        SystemControlSettings{Confirm: confirmSystemDestructive},
+		Updates:               UpdateSettings{Channel: updateChannelStable},
+		Dictionary:            DictionarySettings{Language: "en"},
+		Translation:           TranslationSettings{Target: "English"},
+		Retrieval:             RetrievalSettings{Model: "text-embedding-3-small", TopK: 3, MinScore: 0.35},
+		Workspace:             WorkspaceSettings{MaxFileKB: 64, MaxContextKB: 256},
+		Storage:               StorageSettings{TempMaxMB: 500, TempMaxAgeHours: 24, CacheMaxMB: 200},
+		LocalAPI:              LocalAPISettings{Port: defaultLocalAPIPort},
+	}
+}
+
+// configDir returns the directory all persisted state lives in, creating
+// it if needed; see portableDir
+func configDir() (string, error) {
+	base, err := os.UserConfigDir()
+	if p := portableDir(); p != "" {
+		base, err = p, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(base, "overlae")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// configPath joins name onto the config directory
+func configPath(name string) (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// writeFileAtomic writes data to a temp file next to path and renames it
+// into place so readers never see a partial file
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// readJSONConfig decodes a JSON file from the config dir into v; a missing
+// file leaves v untouched
+func readJSONConfig(name string, v any) error {
+	path, err := configPath(name)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func writeJSONConfig(name string, v any, perm os.FileMode) error {
+	path, err := configPath(name)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data, perm)
+}
+
+// loadSettings reads settings.json, falling back to defaults for a
+// missing file or missing fields
+func loadSettings() (Settings, error) {
+	s := defaultSettings()
+	err := readJSONConfig(settingsFile, &s)
+	return s, err
+}
+
+func (a *App) saveSettingsLocked() error {
+	return writeJSONConfig(settingsFile, a.settings, 0o644)
+}
+
+// reloadSettings re-reads settings.json after it was replaced on disk
+func (a *App) reloadSettings() error {
+	s, err := loadSettings()
+	if err != nil {
+		return err
+	}
+	a.settingsMu.Lock()
+	a.settings = s
+	a.settingsMu.Unlock()
+
+	if a.ctx != nil {
+		eventSettingsChanged.emit(a.ctx, s)
+	}
+	return nil
+}
+
+// updateSettings applies fn to the current settings and persists the result
+func (a *App) updateSettings(fn func(s *Settings)) error {
+	a.settingsMu.Lock()
+	fn(&a.settings)
+	err := a.saveSettingsLocked()
+	s := a.settings
+	a.settingsMu.Unlock()
+
+	if err == nil && a.ctx != nil {
+		eventSettingsChanged.emit(a.ctx, s)
+	}
+	return err
+}
+
+// GetSettings returns the current settings
+func (a *App) GetSettings() Settings {
+	a.settingsMu.RLock()
+	defer a.settingsMu.RUnlock()
+	return a.settings
+}
+
+// SaveSettings replaces and persists the current settings
+func (a *App) SaveSettings(s Settings) error {
+	return a.updateSettings(func(cur *Settings) { *cur = s })
+}
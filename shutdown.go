@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// shutdownTimeout bounds the whole pipeline; whatever hasn't finished by
+// then is abandoned and the app exits anyway
+const shutdownTimeout = 5 * time.Second
+
+// shutdownStage orders the shutdown pipeline. Work that produces data
+// stops before the stores it writes to are flushed.
+type shutdownStage int
+
+const (
+	// stageRequests cancels in-flight provider requests and streams
+	stageRequests shutdownStage = iota
+	// stageWatchers stops pollers, hooks and hotkeys
+	stageWatchers
+	// stageWindows persists window state and closes child windows
+	stageWindows
+	// stageFlush flushes stores such as the history database and logs
+	stageFlush
+)
+
+type shutdownHook struct {
+	stage shutdownStage
+	name  string
+	fn    func(ctx context.Context) error
+}
+
+// onShutdown registers fn to run during Cleanup. Hooks run one at a time,
+// by stage and then in registration order, and should return early once
+// ctx is done.
+func (a *App) onShutdown(stage shutdownStage, name string, fn func(ctx context.Context) error) {
+	a.shutdownMu.Lock()
+	defer a.shutdownMu.Unlock()
+	a.shutdownHooks = append(a.shutdownHooks, shutdownHook{stage: stage, name: name, fn: fn})
+}
+
+// runShutdown runs the registered hooks, giving up after timeout
+func (a *App) runShutdown(timeout time.Duration) {
+	a.shutdownMu.Lock()
+	hooks := append([]shutdownHook(nil), a.shutdownHooks...)
+	a.shutdownMu.Unlock()
+	sort.SliceStable(hooks, func(i, j int) bool { return hooks[i].stage < hooks[j].stage })
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, h := range hooks {
+			if ctx.Err() != nil {
+				return
+			}
+			if err := h.fn(ctx); err != nil {
+				println("Error during shutdown:", h.name+":", err.Error())
+			}
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		println("Shutdown timed out, forcing exit")
+	}
+}
+
+// registerShutdownHooks wires the app's own teardown into the pipeline
+func (a *App) registerShutdownHooks() {
+	a.onShutdown(stageRequests, "requests", a.cancelAllRequests)
+	a.onShutdown(stageRequests, "local API", func(context.Context) error {
+		a.stopLocalAPI()
+		return nil
+	})
+	a.onShutdown(stageWatchers, "watchers", func(context.Context) error {
+		a.stopWatchers()
+		a.CancelHotkeyCapture()
+		a.stopMouseTrigger()
+		a.stopWakeWordListener()
+		a.unregisterHotkeys()
+		return nil
+	})
+	a.onShutdown(stageWindows, "overlay placement", func(context.Context) error {
+		if a.GetWindowState().Visible {
+			a.rememberPlacement()
+		}
+		return nil
+	})
+	a.onShutdown(stageWindows, "child windows", func(context.Context) error {
+		a.CloseAnnotation()
+		a.companionMu.Lock()
+		a.companion.close()
+		a.companionMu.Unlock()
+		a.closeDetachedWindows()
+		a.closeSelectionHint()
+		return nil
+	})
+	a.onShutdown(stageFlush, "semantic index", func(context.Context) error {
+		return a.closeVectorIndex()
+	})
+	a.onShutdown(stageFlush, "history", func(context.Context) error {
+		if a.history == nil {
+			return nil
+		}
+		return a.history.close()
+	})
+}
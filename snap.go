@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const snapMargin = 16
+
+// snapPositions are the targets accepted by SnapOverlay. Halves dock the
+// overlay to an edge across the whole side; corners and centre keep its size.
+var snapPositions = []string{"left", "right", "top", "bottom", "top-left", "top-right", "bottom-left", "bottom-right", "center"}
+
+// snapRect computes where a window of frame's size goes inside work,
+// keeping corners margin away from the edges
+func snapRect(work, frame Rect, position string, margin int) (Rect, error) {
+	halfW, halfH := work.Width/2, work.Height/2
+	switch position {
+	case "left":
+		return Rect{X: work.X, Y: work.Y, Width: halfW, Height: work.Height}, nil
+	case "right":
+		return Rect{X: work.X + halfW, Y: work.Y, Width: work.Width - halfW, Height: work.Height}, nil
+	case "top":
+		return Rect{X: work.X, Y: work.Y, Width: work.Width, Height: halfH}, nil
+	case "bottom":
+		return Rect{X: work.X, Y: work.Y + halfH, Width: work.Width, Height: work.Height - halfH}, nil
+	case "top-left":
+		frame.X, frame.Y = work.X+margin, work.Y+margin
+	case "top-right":
+		frame.X, frame.Y = work.right()-frame.Width-margin, work.Y+margin
+	case "bottom-left":
+		frame.X, frame.Y = work.X+margin, work.bottom()-frame.Height-margin
+	case "bottom-right":
+		frame.X, frame.Y = work.right()-frame.Width-margin, work.bottom()-frame.Height-margin
+	case "center":
+		return centerIn(work, frame.Width, frame.Height), nil
+	default:
+		return Rect{}, fmt.Errorf("unknown snap position %q", position)
+	}
+	return clampInto(frame, work), nil
+}
+
+// SnapOverlay moves the overlay to a half, corner or the centre of the
+// display it is currently on
+func (a *App) SnapOverlay(position string) error {
+	frame, err := ownWindowFrame()
+	if err != nil {
+		return a.snapRelative(position)
+	}
+	displays, err := listDisplays()
+	if err != nil {
+		return err
+	}
+	display, ok := displayFor(displays, frame)
+	if !ok {
+		return fmt.Errorf("no displays found")
+	}
+	target, err := snapRect(display.WorkArea, frame, position, display.toFrame(snapMargin))
+	if err != nil {
+		return err
+	}
+	if err := setOwnWindowFrame(target); err != nil {
+		return err
+	}
+	eventOverlaySnapped.emit(a.ctx, position)
+	return nil
+}
+
+// snapRelative is used where absolute window frames are unavailable; the
+// Wails window API positions relative to the current monitor
+func (a *App) snapRelative(position string) error {
+	screens, err := wailsruntime.ScreenGetAll(a.ctx)
+	if err != nil {
+		return err
+	}
+	for _, s := range screens {
+		if !s.IsCurrent {
+			continue
+		}
+		w, h := wailsruntime.WindowGetSize(a.ctx)
+		target, err := snapRect(Rect{Width: s.Size.Width, Height: s.Size.Height}, Rect{Width: w, Height: h}, position, snapMargin)
+		if err != nil {
+			return err
+		}
+		wailsruntime.WindowSetSize(a.ctx, target.Width, target.Height)
+		wailsruntime.WindowSetPosition(a.ctx, target.X, target.Y)
+		eventOverlaySnapped.emit(a.ctx, position)
+		return nil
+	}
+	return fmt.Errorf("current screen not found")
+}
+
+// snapHotkeys maps snap positions to their default Cmd/Ctrl+Option/Alt
+// shortcuts
+var snapHotkeys = map[string]string{
+	"left":         "CmdOrCtrl+Alt+Left",
+	"right":        "CmdOrCtrl+Alt+Right",
+	"top":          "CmdOrCtrl+Alt+Up",
+	"bottom":       "CmdOrCtrl+Alt+Down",
+	"top-left":     "CmdOrCtrl+Alt+U",
+	"top-right":    "CmdOrCtrl+Alt+I",
+	"bottom-left":  "CmdOrCtrl+Alt+J",
+	"bottom-right": "CmdOrCtrl+Alt+K",
+	"center":       "CmdOrCtrl+Alt+C",
+}
+
+// registerSnapHotkeys registers the optional snapping shortcuts
+func (a *App) registerSnapHotkeys() {
+	for position, spec := range snapHotkeys {
+		_ = a.bindHotkey("snap-"+position, spec, func() {
+			_ = a.SnapOverlay(position)
+		})
+	}
+}
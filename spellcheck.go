@@ -0,0 +1,61 @@
+package main
+
+import (
+	"errors"
+	"slices"
+)
+
+// SpellCheckSettings control spell checking in the overlay's text fields.
+// Language is a code such as "de" or "pt_BR"; empty follows the system.
+type SpellCheckSettings struct {
+	Enabled  bool   `json:"enabled"`
+	Language string `json:"language"`
+}
+
+// SpellCheckInfo is the current configuration plus what the platform
+// webview supports
+type SpellCheckInfo struct {
+	SpellCheckSettings
+	// Languages lists the selectable dictionaries; it is empty where the
+	// webview always follows the system languages
+	Languages []string `json:"languages"`
+}
+
+// errSpellLanguage reports a platform whose webview has no per-app
+// dictionary setting
+var errSpellLanguage = errors.New("this platform's webview always spell checks in the system languages")
+
+// GetSpellCheck returns the spell check settings and available languages
+func (a *App) GetSpellCheck() SpellCheckInfo {
+	langs := spellCheckLanguages()
+	if langs == nil {
+		langs = []string{}
+	}
+	return SpellCheckInfo{SpellCheckSettings: a.GetSettings().SpellCheck, Languages: langs}
+}
+
+// SetSpellCheck turns spell checking on or off and picks its language
+func (a *App) SetSpellCheck(s SpellCheckSettings) error {
+	if s.Language != "" {
+		langs := spellCheckLanguages()
+		if len(langs) == 0 {
+			return errSpellLanguage
+		}
+		if !slices.Contains(langs, s.Language) {
+			return errors.New("no spell check dictionary for " + s.Language)
+		}
+	}
+	if err := setSpellCheckLanguage(s.Language); err != nil && !errors.Is(err, errSpellLanguage) {
+		return err
+	}
+	return a.updateSettings(func(cur *Settings) { cur.SpellCheck = s })
+}
+
+// applySpellCheck restores the saved language at startup
+func (a *App) applySpellCheck() {
+	if lang := a.GetSettings().SpellCheck.Language; lang != "" {
+		if err := setSpellCheckLanguage(lang); err != nil && !errors.Is(err, errSpellLanguage) {
+			println("Error setting spell check language:", err.Error())
+		}
+	}
+}
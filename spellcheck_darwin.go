@@ -0,0 +1,66 @@
+package main
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Cocoa
+#import <Cocoa/Cocoa.h>
+#include <stdlib.h>
+
+static void ovOnMain(void (^block)(void)) {
+	if ([NSThread isMainThread]) block();
+	else dispatch_sync(dispatch_get_main_queue(), block);
+}
+
+// ovSpellLanguages returns the installed dictionaries, newline separated
+static char *ovSpellLanguages(void) {
+	__block char *out = NULL;
+	ovOnMain(^{
+		NSString *joined = [[[NSSpellChecker sharedSpellChecker] availableLanguages] componentsJoinedByString:@"\n"];
+		out = strdup(joined.UTF8String);
+	});
+	return out;
+}
+
+// ovSetSpellLanguage pins the dictionary WKWebView checks with, or goes
+// back to automatic detection for an empty lang
+static int ovSetSpellLanguage(const char *lang) {
+	__block int ok = 1;
+	NSString *l = [NSString stringWithUTF8String:lang];
+	ovOnMain(^{
+		NSSpellChecker *checker = [NSSpellChecker sharedSpellChecker];
+		checker.automaticallyIdentifiesLanguages = l.length == 0;
+		if (l.length > 0) ok = [checker setLanguage:l];
+	});
+	return ok;
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"strings"
+	"unsafe"
+)
+
+// spellCheckLanguages lists NSSpellChecker's dictionaries, which WKWebView
+// uses for its text fields
+func spellCheckLanguages() []string {
+	out := C.ovSpellLanguages()
+	if out == nil {
+		return nil
+	}
+	defer C.free(unsafe.Pointer(out))
+	if s := C.GoString(out); s != "" {
+		return strings.Split(s, "\n")
+	}
+	return nil
+}
+
+func setSpellCheckLanguage(lang string) error {
+	clang := C.CString(lang)
+	defer C.free(unsafe.Pointer(clang))
+	if C.ovSetSpellLanguage(clang) == 0 {
+		return fmt.Errorf("no spell check dictionary for %s", lang)
+	}
+	return nil
+}
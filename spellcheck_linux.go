@@ -0,0 +1,7 @@
+package main
+
+// Wails doesn't expose WebKitGTK's web context, where the dictionaries
+// are chosen, so only the per-field on/off switch is configurable
+func spellCheckLanguages() []string { return nil }
+
+func setSpellCheckLanguage(string) error { return errSpellLanguage }
@@ -0,0 +1,7 @@
+package main
+
+// WebView2 checks spelling in the languages enabled in Windows' language
+// settings and has no per-app override, so only on/off is configurable
+func spellCheckLanguages() []string { return nil }
+
+func setSpellCheckLanguage(string) error { return errSpellLanguage }
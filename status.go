@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// appVersion is set at build time with -ldflags "-X main.appVersion=..."
+var appVersion = "dev"
+
+const providerCheckTimeout = 5 * time.Second
+
+// HotkeyStatus reports one global shortcut
+type HotkeyStatus struct {
+	Name string `json:"name"`
+	Spec string `json:"spec"`
+	// Active is whether the binding should be registered right now;
+	// overlay-only ones are inactive while the overlay is hidden
+	Active     bool   `json:"active"`
+	Registered bool   `json:"registered"`
+	Error      string `json:"error,omitempty"`
+}
+
+// ProviderStatus reports whether the model endpoint answers
+type ProviderStatus struct {
+	Kind    string `json:"kind"`
+	BaseURL string `json:"baseUrl"`
+	Model   string `json:"model"`
+	// Checked is false when reachability was not probed
+	Checked   bool   `json:"checked"`
+	Reachable bool   `json:"reachable"`
+	LatencyMs int64  `json:"latencyMs,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// DatabaseStatus reports the history log
+type DatabaseStatus struct {
+	Path    string `json:"path"`
+	Records int    `json:"records"`
+	Lines   int    `json:"lines"`
+	// Corrupt counts lines that no longer parse
+	Corrupt int    `json:"corrupt"`
+	OK      bool   `json:"ok"`
+	Error   string `json:"error,omitempty"`
+}
+
+// AppStatus is the health of the running app
+type AppStatus struct {
+	Version   string `json:"version"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+	GoVersion string `json:"goVersion"`
+	// DataDir is where config and history live; Portable is set when it
+	// is beside the executable
+	DataDir        string         `json:"dataDir"`
+	Portable       bool           `json:"portable"`
+	HotkeysEnabled bool           `json:"hotkeysEnabled"`
+	Hotkeys        []HotkeyStatus `json:"hotkeys"`
+	Provider       ProviderStatus `json:"provider"`
+	Database       DatabaseStatus `json:"database"`
+	// System is the last self-check of OS permissions
+	System    SelfCheck `json:"system"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// GetStatus checks hotkeys, the provider and the history database
+func (a *App) GetStatus() AppStatus {
+	return a.status(true)
+}
+
+// status builds an AppStatus; probing the provider costs a request, so
+// frequent callers such as /status skip it
+func (a *App) status(checkProvider bool) AppStatus {
+	s := AppStatus{
+		Version:   appVersion,
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		GoVersion: runtime.Version(),
+		Portable:  portableDir() != "",
+		Hotkeys:   []HotkeyStatus{},
+		CheckedAt: time.Now(),
+	}
+	if dir, err := configDir(); err == nil {
+		s.DataDir = dir
+	}
+
+	a.hotkeysMu.Lock()
+	s.HotkeysEnabled = !a.hotkeysPaused
+	for _, b := range a.hotkeys {
+		h := HotkeyStatus{Name: b.name, Spec: b.spec, Active: a.activeLocked(b), Registered: b.hk != nil}
+		if b.err != nil && b.hk == nil {
+			h.Error = b.err.Error()
+		}
+		s.Hotkeys = append(s.Hotkeys, h)
+	}
+	a.hotkeysMu.Unlock()
+
+	cfg := a.GetSettings().Provider
+	s.Provider = ProviderStatus{Kind: providerKind(cfg.Kind), BaseURL: cfg.BaseURL, Model: cfg.Model, Checked: checkProvider}
+	if checkProvider {
+		started := time.Now()
+		p, _, err := a.currentProvider()
+		if err == nil {
+			ctx, cancel := context.WithTimeout(context.Background(), providerCheckTimeout)
+			_, err = p.listModels(ctx)
+			cancel()
+		}
+		s.Provider.Reachable = err == nil
+		s.Provider.LatencyMs = time.Since(started).Milliseconds()
+		if err != nil {
+			s.Provider.Error = err.Error()
+		}
+	}
+
+	s.System = a.GetSelfCheck()
+
+	s.Database.Path, _ = configPath(historyFile)
+	if a.history == nil {
+		s.Database.Error = "history store failed to open"
+	} else {
+		s.Database.Records = len(a.history.list(nil))
+		lines, corrupt, err := a.history.verify()
+		s.Database.Lines, s.Database.Corrupt = lines, corrupt
+		s.Database.OK = err == nil && corrupt == 0
+		if err != nil {
+			s.Database.Error = err.Error()
+		}
+	}
+	return s
+}
+
+// diagnosticsReport is what RunDiagnostics bundles. API keys live in the
+// keychain, but settings still hold URLs and commands that can carry
+// tokens, so the report goes through redactDiagnostics.
+type diagnosticsReport struct {
+	Status   AppStatus        `json:"status"`
+	Settings Settings         `json:"settings"`
+	Window   WindowState      `json:"window"`
+	Displays []Display        `json:"displays"`
+	Keys     []ProviderKey    `json:"keys"`
+	Files    map[string]int64 `json:"files"`
+}
+
+// RunDiagnostics writes a report for bug reports to the cache directory,
+// copies it to the clipboard and returns its path
+func (a *App) RunDiagnostics() (string, error) {
+	report := diagnosticsReport{
+		Status:   a.GetStatus(),
+		Settings: a.GetSettings(),
+		Window:   a.GetWindowState(),
+		Files:    map[string]int64{},
+	}
+	report.Displays, _ = a.GetDisplays()
+	if infos, err := a.ListProviderKeys(); err == nil {
+		for _, k := range infos {
+			report.Keys = append(report.Keys, k.ProviderKey)
+		}
+	}
+	if dir, err := configDir(); err == nil {
+		entries, _ := os.ReadDir(dir)
+		for _, e := range entries {
+			if info, err := e.Info(); err == nil && !e.IsDir() {
+				report.Files[e.Name()] = info.Size()
+			}
+		}
+	}
+
+	raw, err := json.Marshal(report)
+	if err != nil {
+		return "", err
+	}
+	var generic any
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(redactDiagnostics("", generic), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	dir, err := cacheDir()
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, "diagnostics-"+time.Now().Format("20060102-150405")+".json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", err
+	}
+	a.markOwnClipboard(string(data))
+	if err := wailsruntime.ClipboardSetText(a.ctx, string(data)); err != nil {
+		println("Error copying diagnostics:", err.Error())
+	}
+	return path, nil
+}
+
+// redactDiagnostics strips what a bug report must not carry from the
+// decoded JSON v under key: URLs keep only their scheme and host, other
+// remotes are dropped, as are commands and anything named like a
+// credential
+func redactDiagnostics(key string, v any) any {
+	k := strings.ToLower(key)
+	switch v := v.(type) {
+	case map[string]any:
+		for name, field := range v {
+			v[name] = redactDiagnostics(name, field)
+		}
+		return v
+	case []any:
+		if k == "command" && len(v) > 0 {
+			return redacted
+		}
+		for i, item := range v {
+			v[i] = redactDiagnostics(key, item)
+		}
+		return v
+	case string:
+		switch {
+		case v == "":
+			return v
+		case k == "command" || strings.Contains(k, "password") || strings.Contains(k, "secret") || strings.Contains(k, "token"):
+			return redacted
+		case strings.Contains(v, "://") || strings.HasSuffix(k, "url"):
+			if u, err := url.Parse(v); err == nil && u.Host != "" {
+				return u.Scheme + "://" + u.Host
+			}
+			if strings.HasSuffix(k, "url") {
+				return redacted
+			}
+		}
+	}
+	return v
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	// storageSweepInterval is how often the janitor enforces the limits
+	storageSweepInterval = time.Hour
+	// storageInUseGrace protects files a capture or recording may still be
+	// writing
+	storageInUseGrace = 2 * time.Minute
+)
+
+// StorageSettings bounds the disk space screenshots, recordings and
+// cached data may use. Zero disables a limit.
+type StorageSettings struct {
+	// TempMaxMB and TempMaxAgeHours apply to captures and recordings
+	// that were never attached
+	TempMaxMB       int `json:"tempMaxMb"`
+	TempMaxAgeHours int `json:"tempMaxAgeHours"`
+	// CacheMaxMB applies to the response cache, model lists and
+	// diagnostics reports
+	CacheMaxMB int `json:"cacheMaxMb"`
+}
+
+// StorageUsage is the size of one managed directory
+type StorageUsage struct {
+	Name  string `json:"name"`
+	Path  string `json:"path"`
+	Files int    `json:"files"`
+	Bytes int64  `json:"bytes"`
+}
+
+// CacheReport describes what ClearCache or a sweep removed
+type CacheReport struct {
+	Files int   `json:"files"`
+	Bytes int64 `json:"bytes"`
+}
+
+func (r *CacheReport) add(o CacheReport) {
+	r.Files += o.Files
+	r.Bytes += o.Bytes
+}
+
+type storedFile struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// listStoredFiles returns the regular files under dir, oldest first
+func listStoredFiles(dir string) ([]storedFile, error) {
+	var files []storedFile
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		files = append(files, storedFile{path: path, size: info.Size(), modTime: info.ModTime()})
+		return nil
+	})
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.Before(files[j].modTime) })
+	return files, err
+}
+
+// pruneDir removes files under dir older than maxAge, then the oldest ones
+// until the rest fit in maxBytes. Files modified within storageInUseGrace
+// are kept either way.
+func pruneDir(dir string, maxAge time.Duration, maxBytes int64) (CacheReport, error) {
+	files, err := listStoredFiles(dir)
+	if err != nil {
+		return CacheReport{}, err
+	}
+	var total int64
+	for _, f := range files {
+		total += f.size
+	}
+	var report CacheReport
+	for _, f := range files {
+		age := time.Since(f.modTime)
+		expired := maxAge > 0 && age > maxAge
+		over := maxBytes > 0 && total > maxBytes
+		if (!expired && !over) || age < storageInUseGrace {
+			continue
+		}
+		if err := os.Remove(f.path); err != nil {
+			continue
+		}
+		total -= f.size
+		report.add(CacheReport{Files: 1, Bytes: f.size})
+	}
+	return report, nil
+}
+
+// orphanedAttachments returns attachment files no history record lists,
+// e.g. when the app quit between moving a clip and saving the record
+func (a *App) orphanedAttachments() ([]storedFile, error) {
+	dir, err := configPath(attachmentsDir)
+	if err != nil {
+		return nil, err
+	}
+	files, err := listStoredFiles(dir)
+	if err != nil || a.history == nil {
+		return nil, err
+	}
+	used := map[string]bool{}
+	for _, r := range a.history.list(nil) {
+		for _, att := range r.Attachments {
+			if att.Path != "" {
+				used[att.Path] = true
+			}
+		}
+	}
+	var orphans []storedFile
+	for _, f := range files {
+		if !used[f.path] && time.Since(f.modTime) > storageInUseGrace {
+			orphans = append(orphans, f)
+		}
+	}
+	return orphans, nil
+}
+
+func removeStoredFiles(files []storedFile) CacheReport {
+	var report CacheReport
+	for _, f := range files {
+		if os.Remove(f.path) == nil {
+			report.add(CacheReport{Files: 1, Bytes: f.size})
+		}
+	}
+	return report
+}
+
+// sweepStorage enforces the storage limits once
+func (a *App) sweepStorage() (CacheReport, error) {
+	cfg := a.GetSettings().Storage
+	var report CacheReport
+	r, err := pruneDir(captureTempDir(), time.Duration(cfg.TempMaxAgeHours)*time.Hour, int64(cfg.TempMaxMB)<<20)
+	report.add(r)
+	if dir, cerr := cacheDir(); cerr == nil {
+		// The semantic index is closed so its database can be removed; the
+		// next search opens it again
+		err = errors.Join(err, a.closeVectorIndex())
+		r, cerr = pruneDir(dir, 0, int64(cfg.CacheMaxMB)<<20)
+		report.add(r)
+		err = errors.Join(err, cerr)
+	}
+	orphans, oerr := a.orphanedAttachments()
+	report.add(removeStoredFiles(orphans))
+	return report, errors.Join(err, oerr)
+}
+
+// watchStorage sweeps on startup and then every storageSweepInterval
+func (a *App) watchStorage(ctx context.Context) {
+	ticker := time.NewTicker(storageSweepInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := a.sweepStorage(); err != nil {
+			println("Error cleaning up storage:", err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetStorageUsage returns the size of the temp, cache and attachment
+// directories
+func (a *App) GetStorageUsage() ([]StorageUsage, error) {
+	dirs := []StorageUsage{{Name: "temp", Path: captureTempDir()}}
+	if dir, err := cacheDir(); err == nil {
+		dirs = append(dirs, StorageUsage{Name: "cache", Path: dir})
+	}
+	if dir, err := configPath(attachmentsDir); err == nil {
+		dirs = append(dirs, StorageUsage{Name: "attachments", Path: dir})
+	}
+	for i, d := range dirs {
+		files, err := listStoredFiles(d.Path)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			dirs[i].Files++
+			dirs[i].Bytes += f.size
+		}
+	}
+	return dirs, nil
+}
+
+// SetStorageLimits changes the storage limits and applies them at once
+func (a *App) SetStorageLimits(cfg StorageSettings) (CacheReport, error) {
+	if cfg.TempMaxMB < 0 || cfg.TempMaxAgeHours < 0 || cfg.CacheMaxMB < 0 {
+		return CacheReport{}, errors.New("storage limits cannot be negative")
+	}
+	if err := a.updateSettings(func(s *Settings) { s.Storage = cfg }); err != nil {
+		return CacheReport{}, err
+	}
+	return a.sweepStorage()
+}
+
+// ClearCache deletes every unattached capture and recording, all cached
+// data and orphaned attachments, and reports the space reclaimed.
+// Attachments of saved notes are kept.
+func (a *App) ClearCache() (CacheReport, error) {
+	if _, err := a.ClearResponseCache(); err != nil {
+		return CacheReport{}, err
+	}
+
+	var report CacheReport
+	temp, err := listStoredFiles(captureTempDir())
+	errs := []error{err}
+	var idle []storedFile
+	for _, f := range temp {
+		if time.Since(f.modTime) > storageInUseGrace {
+			idle = append(idle, f)
+		}
+	}
+	report.add(removeStoredFiles(idle))
+	if dir, err := cacheDir(); err == nil {
+		errs = append(errs, a.closeVectorIndex())
+		cached, err := listStoredFiles(dir)
+		errs = append(errs, err)
+		report.add(removeStoredFiles(cached))
+	}
+	orphans, err := a.orphanedAttachments()
+	report.add(removeStoredFiles(orphans))
+	return report, errors.Join(append(errs, err)...)
+}
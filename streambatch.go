@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultStreamBatchMs is roughly one or two frames, fast enough to look
+// live while cutting a token-per-event stream down to a few events
+const defaultStreamBatchMs = 24
+
+// chunkBatcher coalesces streamed deltas so response-chunk fires at most
+// once per interval. The first delta goes out at once; later ones wait for
+// the window to close. Seq counts emitted batches.
+type chunkBatcher struct {
+	mu       sync.Mutex
+	interval time.Duration
+	emit     func(ResponseChunk)
+	pending  strings.Builder
+	seq      int
+	last     time.Time
+	timer    *time.Timer
+	closed   bool
+}
+
+func newChunkBatcher(interval time.Duration, emit func(ResponseChunk)) *chunkBatcher {
+	return &chunkBatcher{interval: interval, emit: emit}
+}
+
+func (b *chunkBatcher) add(delta string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.pending.WriteString(delta)
+	if b.timer != nil {
+		return
+	}
+	if wait := b.interval - time.Since(b.last); wait > 0 {
+		b.timer = time.AfterFunc(wait, b.flush)
+		return
+	}
+	b.flushLocked()
+}
+
+func (b *chunkBatcher) flush() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.timer = nil
+	if !b.closed {
+		b.flushLocked()
+	}
+}
+
+// flushLocked emits while holding the lock so a timer flush can never
+// overtake the final one from close
+func (b *chunkBatcher) flushLocked() {
+	if b.pending.Len() == 0 {
+		return
+	}
+	b.emit(ResponseChunk{Seq: b.seq, Delta: b.pending.String()})
+	b.seq++
+	b.pending.Reset()
+	b.last = time.Now()
+}
+
+// close emits whatever is pending; call it before response-done so the
+// last chunk always precedes it
+func (b *chunkBatcher) close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	b.flushLocked()
+	b.closed = true
+}
+
+// SetStreamBatch sets how long streamed text is gathered before a
+// response-chunk event; 0 sends every delta on its own
+func (a *App) SetStreamBatch(ms int) error {
+	if ms < 0 || ms > 250 {
+		return fmt.Errorf("stream batching must be between 0 and 250 ms")
+	}
+	return a.updateSettings(func(s *Settings) { s.StreamBatchMs = ms })
+}
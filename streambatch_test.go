@@ -0,0 +1,110 @@
+package main
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// chunkRecorder collects emitted chunks
+type chunkRecorder struct {
+	mu     sync.Mutex
+	chunks []ResponseChunk
+	added  chan struct{}
+}
+
+func newChunkRecorder() *chunkRecorder {
+	return &chunkRecorder{added: make(chan struct{}, 16)}
+}
+
+func (r *chunkRecorder) emit(c ResponseChunk) {
+	r.mu.Lock()
+	r.chunks = append(r.chunks, c)
+	r.mu.Unlock()
+	r.added <- struct{}{}
+}
+
+func (r *chunkRecorder) got() []ResponseChunk {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.chunks)
+}
+
+func TestChunkBatcher(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval time.Duration
+		deltas   []string
+		// afterClose are added once the batcher is closed
+		afterClose []string
+		want       []ResponseChunk
+	}{
+		{
+			name:     "no batching sends every delta",
+			interval: 0,
+			deltas:   []string{"a", "b", "c"},
+			want:     []ResponseChunk{{Seq: 0, Delta: "a"}, {Seq: 1, Delta: "b"}, {Seq: 2, Delta: "c"}},
+		},
+		{
+			name:     "first delta at once, the rest on close",
+			interval: time.Hour,
+			deltas:   []string{"a", "b", "c"},
+			want:     []ResponseChunk{{Seq: 0, Delta: "a"}, {Seq: 1, Delta: "bc"}},
+		},
+		{
+			name:       "nothing after close",
+			interval:   time.Hour,
+			deltas:     []string{"a", "b"},
+			afterClose: []string{"c"},
+			want:       []ResponseChunk{{Seq: 0, Delta: "a"}, {Seq: 1, Delta: "b"}},
+		},
+		{
+			name:     "empty deltas are not sent",
+			interval: 0,
+			deltas:   []string{"", "a", ""},
+			want:     []ResponseChunk{{Seq: 0, Delta: "a"}},
+		},
+		{
+			name:     "close with nothing pending",
+			interval: time.Hour,
+			want:     nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := newChunkRecorder()
+			b := newChunkBatcher(tt.interval, rec.emit)
+			for _, d := range tt.deltas {
+				b.add(d)
+			}
+			b.close()
+			for _, d := range tt.afterClose {
+				b.add(d)
+			}
+			if got := rec.got(); !slices.Equal(got, tt.want) {
+				t.Errorf("chunks = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChunkBatcherFlushesAfterInterval(t *testing.T) {
+	rec := newChunkRecorder()
+	b := newChunkBatcher(10*time.Millisecond, rec.emit)
+	b.add("a")
+	b.add("b")
+	b.add("c")
+	for range 2 {
+		select {
+		case <-rec.added:
+		case <-time.After(time.Second):
+			t.Fatalf("chunks = %+v, want a second chunk once the interval passed", rec.got())
+		}
+	}
+	b.close()
+	want := []ResponseChunk{{Seq: 0, Delta: "a"}, {Seq: 1, Delta: "bc"}}
+	if got := rec.got(); !slices.Equal(got, want) {
+		t.Errorf("chunks = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestResolveSyncConflict(t *testing.T) {
+	now := time.Now()
+	older, newer := now.Add(-time.Hour), now.Add(time.Hour)
+	tests := []struct {
+		name     string
+		policy   string
+		local    []byte
+		remote   []byte
+		localMod time.Time
+		// remoteMod is when the remote copy was last written
+		remoteMod time.Time
+		want      string
+	}{
+		{name: "local policy", policy: "local", local: []byte("l"), remote: []byte("r"), localMod: older, remoteMod: now, want: "local"},
+		{name: "remote policy", policy: "remote", local: []byte("l"), remote: []byte("r"), localMod: newer, remoteMod: now, want: "remote"},
+		{name: "newest local", policy: "newest", local: []byte("l"), remote: []byte("r"), localMod: newer, remoteMod: now, want: "local"},
+		{name: "newest remote", policy: "newest", local: []byte("l"), remote: []byte("r"), localMod: older, remoteMod: now, want: "remote"},
+		{name: "tie goes to remote", policy: "newest", local: []byte("l"), remote: []byte("r"), localMod: now, remoteMod: now, want: "remote"},
+		{name: "empty policy is newest", local: []byte("l"), remote: []byte("r"), localMod: newer, remoteMod: now, want: "local"},
+		{name: "remote deleted", policy: "newest", local: []byte("l"), localMod: older, remoteMod: now, want: "local"},
+		{name: "local deleted", policy: "newest", remote: []byte("r"), remoteMod: older, want: "remote"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "settings.json")
+			var pushed, pulled bool
+			var result SyncResult
+			err := resolveSyncConflict(tt.policy, "settings.json", path, tt.local, tt.remote, tt.localMod,
+				syncManifestEntry{Modified: tt.remoteMod},
+				func() error { pushed = true; return nil },
+				func() error { pulled = true; return nil },
+				&result)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if pushed != (tt.want == "local") || pulled != (tt.want == "remote") {
+				t.Errorf("pushed = %v, pulled = %v, want %s to win", pushed, pulled, tt.want)
+			}
+			if len(result.Conflicts) != 1 {
+				t.Fatalf("conflicts = %+v, want one", result.Conflicts)
+			}
+			conflict := result.Conflicts[0]
+			if conflict.File != "settings.json" || conflict.Resolution != tt.want {
+				t.Errorf("conflict = %+v, want settings.json resolved to %s", conflict, tt.want)
+			}
+
+			// Pulling over a local copy keeps it as a backup
+			wantBackup := tt.want == "remote" && tt.local != nil
+			if (conflict.BackupPath != "") != wantBackup {
+				t.Fatalf("backup path = %q, want one: %v", conflict.BackupPath, wantBackup)
+			}
+			if wantBackup {
+				if filepath.Dir(conflict.BackupPath) != filepath.Dir(path) {
+					t.Errorf("backup %q is not next to %q", conflict.BackupPath, path)
+				}
+				data, err := os.ReadFile(conflict.BackupPath)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(data, tt.local) {
+					t.Errorf("backup = %q, want %q", data, tt.local)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveSyncConflictErrors(t *testing.T) {
+	failed := errors.New("backend down")
+	ok := func() error { return nil }
+	fail := func() error { return failed }
+	path := filepath.Join(t.TempDir(), "settings.json")
+
+	var result SyncResult
+	if err := resolveSyncConflict("local", "settings.json", path, []byte("l"), []byte("r"), time.Now(),
+		syncManifestEntry{}, fail, ok, &result); !errors.Is(err, failed) {
+		t.Errorf("push error = %v, want %v", err, failed)
+	}
+	if err := resolveSyncConflict("remote", "settings.json", path, nil, []byte("r"), time.Now(),
+		syncManifestEntry{}, ok, fail, &result); !errors.Is(err, failed) {
+		t.Errorf("pull error = %v, want %v", err, failed)
+	}
+	if len(result.Conflicts) != 0 {
+		t.Errorf("conflicts = %+v, want none recorded when the winner fails to apply", result.Conflicts)
+	}
+}
+
+func TestMarshalSnippetsOrder(t *testing.T) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	snippets := []HistoryRecord{
+		{ID: "c", Kind: "snippet", Text: "third", CreatedAt: at.Add(time.Minute)},
+		{ID: "b", Kind: "snippet", Text: "second", CreatedAt: at},
+		{ID: "a", Kind: "snippet", Text: "first", CreatedAt: at},
+	}
+	data, err := marshalSnippets(snippets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reversed := slices.Clone(snippets)
+	slices.Reverse(reversed)
+	again, err := marshalSnippets(reversed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, again) {
+		t.Errorf("marshalSnippets depends on input order:\n%s\n%s", data, again)
+	}
+	if snippets[0].ID != "c" {
+		t.Errorf("marshalSnippets sorted its input in place")
+	}
+	if first, third := bytes.Index(data, []byte(`"first"`)), bytes.Index(data, []byte(`"third"`)); first < 0 || third < first {
+		t.Errorf("snippets not ordered by creation then ID:\n%s", data)
+	}
+
+	empty, err := marshalSnippets(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(empty) != "[]" {
+		t.Errorf("marshalSnippets(nil) = %s, want []", empty)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+func systemScript(script string) (string, error) {
+	out, err := exec.Command("osascript", "-e", script).Output()
+	return strings.TrimSpace(string(out)), err
+}
+
+func setVolume(percent int) error {
+	_, err := systemScript(fmt.Sprintf("set volume output volume %d without output muted", percent))
+	return err
+}
+
+func adjustVolume(delta int) error {
+	out, err := systemScript("output volume of (get volume settings)")
+	if err != nil {
+		return err
+	}
+	current, err := strconv.Atoi(out)
+	if err != nil {
+		return fmt.Errorf("unexpected volume %q", out)
+	}
+	return setVolume(max(0, min(100, current+delta)))
+}
+
+func toggleMute() error {
+	_, err := systemScript("set volume output muted not (output muted of (get volume settings))")
+	return err
+}
+
+// adjustBrightness presses the brightness keys, since macOS has no public
+// command line for display brightness; each press is one sixteenth
+func adjustBrightness(delta int) error {
+	key := 144
+	if delta < 0 {
+		key, delta = 145, -delta
+	}
+	presses := max(1, delta*16/100)
+	_, err := systemScript(fmt.Sprintf(`tell application "System Events" to repeat %d times
+	key code %d
+end repeat`, presses, key))
+	return err
+}
+
+func lockScreen() error {
+	_, err := systemScript(`tell application "System Events" to keystroke "q" using {control down, command down}`)
+	return err
+}
+
+func sleepSystem() error {
+	return exec.Command("pmset", "sleepnow").Run()
+}
+
+func emptyTrash() error {
+	_, err := systemScript(`tell application "Finder" to empty trash`)
+	return err
+}
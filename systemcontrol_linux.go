@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// Volume goes through PulseAudio or PipeWire's pactl, brightness through
+// brightnessctl and the rest through systemd's loginctl and systemctl
+
+func setVolume(percent int) error {
+	_, err := pactl("set-sink-volume", "@DEFAULT_SINK@", fmt.Sprintf("%d%%", percent))
+	return err
+}
+
+func adjustVolume(delta int) error {
+	_, err := pactl("set-sink-volume", "@DEFAULT_SINK@", fmt.Sprintf("%+d%%", delta))
+	return err
+}
+
+func toggleMute() error {
+	_, err := pactl("set-sink-mute", "@DEFAULT_SINK@", "toggle")
+	return err
+}
+
+func adjustBrightness(delta int) error {
+	step := fmt.Sprintf("+%d%%", delta)
+	if delta < 0 {
+		step = fmt.Sprintf("%d%%-", -delta)
+	}
+	if err := exec.Command("brightnessctl", "set", step).Run(); err != nil {
+		return fmt.Errorf("brightnessctl: %w", err)
+	}
+	return nil
+}
+
+func lockScreen() error {
+	return exec.Command("loginctl", "lock-session").Run()
+}
+
+func sleepSystem() error {
+	return exec.Command("systemctl", "suspend").Run()
+}
+
+// emptyTrash deletes the freedesktop.org trash of the home directory: the
+// trashed files and the info records describing them
+func emptyTrash() error {
+	dir := os.Getenv("XDG_DATA_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		dir = filepath.Join(home, ".local", "share")
+	}
+	var errs []error
+	for _, sub := range []string{"files", "info", "expunged"} {
+		entries, err := os.ReadDir(filepath.Join(dir, "Trash", sub))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+		for _, e := range entries {
+			errs = append(errs, os.RemoveAll(filepath.Join(dir, "Trash", sub, e.Name())))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"syscall"
+)
+
+var (
+	powrprof               = syscall.NewLazyDLL("powrprof.dll")
+	procSetSuspendState    = powrprof.NewProc("SetSuspendState")
+	procLockWorkStation    = user32.NewProc("LockWorkStation")
+	procSHEmptyRecycleBinW = shell32.NewProc("SHEmptyRecycleBinW")
+)
+
+const (
+	vkVolumeMute = 0xAD
+	vkVolumeDown = 0xAE
+	vkVolumeUp   = 0xAF
+	// Each volume key press moves the volume by two percent
+	volumeKeyStep = 2
+
+	sherbNoConfirmation = 0x1
+	sherbNoProgressUI   = 0x2
+	sherbNoSound        = 0x4
+)
+
+// pressKey taps a virtual key. The volume keys avoid the COM endpoint API
+// the rest of the app does without.
+func pressKey(vk uintptr, times int) {
+	for range times {
+		procKeybdEvent.Call(vk, 0, 0, 0)
+		procKeybdEvent.Call(vk, 0, keyEventFKeyUp, 0)
+	}
+}
+
+// setVolume drops the volume to zero and then raises it to percent
+func setVolume(percent int) error {
+	pressKey(vkVolumeDown, 100/volumeKeyStep)
+	pressKey(vkVolumeUp, percent/volumeKeyStep)
+	return nil
+}
+
+func adjustVolume(delta int) error {
+	if delta < 0 {
+		pressKey(vkVolumeDown, -delta/volumeKeyStep)
+	} else {
+		pressKey(vkVolumeUp, delta/volumeKeyStep)
+	}
+	return nil
+}
+
+func toggleMute() error {
+	pressKey(vkVolumeMute, 1)
+	return nil
+}
+
+// adjustBrightness uses the WMI brightness methods, which only laptop and
+// other built-in panels implement
+func adjustBrightness(delta int) error {
+	script := `$b = (Get-CimInstance -Namespace root/WMI -ClassName WmiMonitorBrightness).CurrentBrightness
+$n = [Math]::Max(0, [Math]::Min(100, $b + ` + strconv.Itoa(delta) + `))
+Get-CimInstance -Namespace root/WMI -ClassName WmiMonitorBrightnessMethods | Invoke-CimMethod -MethodName WmiSetBrightness -Arguments @{Timeout=1; Brightness=$n}`
+	cmd := exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", script)
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("this display does not support brightness control: %s", out)
+	}
+	return nil
+}
+
+func lockScreen() error {
+	if r, _, err := procLockWorkStation.Call(); r == 0 {
+		return fmt.Errorf("LockWorkStation: %w", err)
+	}
+	return nil
+}
+
+func sleepSystem() error {
+	if r, _, err := procSetSuspendState.Call(0, 0, 0); r == 0 {
+		return fmt.Errorf("SetSuspendState: %w", err)
+	}
+	return nil
+}
+
+// emptyTrash empties the Recycle Bin of every drive without Explorer's own
+// prompt; RunSystemCommand does the confirming
+func emptyTrash() error {
+	// S_OK, or E_UNEXPECTED when the bin is already empty
+	if r, _, _ := procSHEmptyRecycleBinW.Call(0, 0, sherbNoConfirmation|sherbNoProgressUI|sherbNoSound); r != 0 && r != 0x8000FFFF {
+		return fmt.Errorf("could not empty the Recycle Bin (error %#x)", r)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// Values of SystemControlSettings.Confirm
+const (
+	confirmSystemAlways      = "always"
+	confirmSystemDestructive = "destructive"
+	confirmSystemNever       = "never"
+)
+
+const (
+	volumeStep     = 10
+	brightnessStep = 10
+)
+
+// SystemControlSettings controls when system commands ask first
+type SystemControlSettings struct {
+	// Confirm is "always", "destructive" for sleep and emptying the trash,
+	// or "never"
+	Confirm string `json:"confirm"`
+}
+
+// SystemCommand is a system control the overlay can search and run
+type SystemCommand struct {
+	ID       string   `json:"id"`
+	Title    string   `json:"title"`
+	Keywords []string `json:"keywords,omitempty"`
+	// TakesValue commands read a 0-100 value, such as the volume to set
+	TakesValue bool `json:"takesValue,omitempty"`
+	// Destructive commands lose work or data if run by mistake
+	Destructive bool `json:"destructive,omitempty"`
+}
+
+var systemCommands = []SystemCommand{
+	{ID: "volume-set", Title: "Set Volume", Keywords: []string{"sound", "audio"}, TakesValue: true},
+	{ID: "volume-up", Title: "Volume Up", Keywords: []string{"sound", "louder"}},
+	{ID: "volume-down", Title: "Volume Down", Keywords: []string{"sound", "quieter"}},
+	{ID: "mute", Title: "Toggle Mute", Keywords: []string{"sound", "silence", "unmute"}},
+	{ID: "brightness-up", Title: "Brightness Up", Keywords: []string{"display", "screen", "brighter"}},
+	{ID: "brightness-down", Title: "Brightness Down", Keywords: []string{"display", "screen", "dimmer"}},
+	{ID: "lock", Title: "Lock Screen", Keywords: []string{"away"}},
+	{ID: "sleep", Title: "Sleep", Keywords: []string{"suspend"}, Destructive: true},
+	{ID: "empty-trash", Title: "Empty Trash", Keywords: []string{"recycle bin", "delete"}, Destructive: true},
+}
+
+// ListSystemCommands returns every system command
+func (a *App) ListSystemCommands() []SystemCommand {
+	return slices.Clone(systemCommands)
+}
+
+// SearchSystemCommands fuzzy-matches query against the command titles and
+// keywords
+func (a *App) SearchSystemCommands(query string, limit int) []SystemCommand {
+	var candidates []MatchCandidate
+	for _, c := range systemCommands {
+		for _, text := range append([]string{c.Title}, c.Keywords...) {
+			candidates = append(candidates, MatchCandidate{ID: c.ID, Text: text})
+		}
+	}
+	out := []SystemCommand{}
+	for _, m := range fuzzyMatch(query, candidates, MatchOptions{MaxTypos: 1}) {
+		i := slices.IndexFunc(systemCommands, func(c SystemCommand) bool { return c.ID == m.ID })
+		if !slices.ContainsFunc(out, func(c SystemCommand) bool { return c.ID == m.ID }) {
+			out = append(out, systemCommands[i])
+		}
+		if limit > 0 && len(out) == limit {
+			break
+		}
+	}
+	return out
+}
+
+// confirmSystemCommand asks before running c when the settings say so
+func (a *App) confirmSystemCommand(c SystemCommand) bool {
+	confirm := a.GetSettings().SystemControls.Confirm
+	if confirm == confirmSystemNever || (confirm != confirmSystemAlways && !c.Destructive) {
+		return true
+	}
+	choice, err := wailsruntime.MessageDialog(a.ctx, wailsruntime.MessageDialogOptions{
+		Type:          wailsruntime.QuestionDialog,
+		Title:         c.Title + "?",
+		Message:       "Are you sure you want to " + strings.ToLower(c.Title) + "?",
+		Buttons:       []string{"Run", "Cancel"},
+		DefaultButton: "Cancel",
+		CancelButton:  "Cancel",
+	})
+	// Windows reports Yes/No for two-button question dialogs
+	return err == nil && (choice == "Run" || choice == "Yes")
+}
+
+// RunSystemCommand runs the command with id; value is the 0-100 level for
+// commands that take one. It reports false when the user declined.
+func (a *App) RunSystemCommand(id string, value int) (bool, error) {
+	i := slices.IndexFunc(systemCommands, func(c SystemCommand) bool { return c.ID == id })
+	if i < 0 {
+		return false, fmt.Errorf("unknown system command %q", id)
+	}
+	c := systemCommands[i]
+	if c.TakesValue && (value < 0 || value > 100) {
+		return false, fmt.Errorf("%s needs a value between 0 and 100", c.Title)
+	}
+	if !a.confirmSystemCommand(c) {
+		return false, nil
+	}
+
+	var err error
+	switch id {
+	case "volume-set":
+		err = setVolume(value)
+	case "volume-up":
+		err = adjustVolume(volumeStep)
+	case "volume-down":
+		err = adjustVolume(-volumeStep)
+	case "mute":
+		err = toggleMute()
+	case "brightness-up":
+		err = adjustBrightness(brightnessStep)
+	case "brightness-down":
+		err = adjustBrightness(-brightnessStep)
+	case "lock":
+		a.HideOverlay()
+		err = lockScreen()
+	case "sleep":
+		a.HideOverlay()
+		err = sleepSystem()
+	case "empty-trash":
+		err = emptyTrash()
+	}
+	return err == nil, err
+}
+
+// SetSystemControls saves the confirmation setting
+func (a *App) SetSystemControls(cfg SystemControlSettings) error {
+	switch cfg.Confirm {
+	case confirmSystemAlways, confirmSystemDestructive, confirmSystemNever:
+	default:
+		return fmt.Errorf("unknown confirmation mode %q", cfg.Confirm)
+	}
+	return a.updateSettings(func(s *Settings) { s.SystemControls = cfg })
+}
@@ -0,0 +1,318 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+const tagsFile = "tags.json"
+
+// Tag is a user-defined label for history records and notes
+type Tag struct {
+	Name  string `json:"name"`
+	Color string `json:"color,omitempty"`
+}
+
+// TagInfo is a tag with the number of records carrying it
+type TagInfo struct {
+	Name  string `json:"name"`
+	Color string `json:"color,omitempty"`
+	Count int    `json:"count"`
+}
+
+// DateRange limits history queries; a zero bound is open
+type DateRange struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+func (d DateRange) contains(t time.Time) bool {
+	return (d.From.IsZero() || !t.Before(d.From)) && (d.To.IsZero() || t.Before(d.To))
+}
+
+// normalizeTag trims and lowercases a tag so "Work" and "work " match
+func normalizeTag(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return "", errors.New("tag name is empty")
+	}
+	if strings.ContainsAny(name, ",\n") {
+		return "", fmt.Errorf("tag %q contains a comma or newline", name)
+	}
+	return name, nil
+}
+
+// normalizeFolder cleans a folder path; "" and "/" are the top level
+func normalizeFolder(folder string) string {
+	return strings.Trim(path.Clean("/"+strings.TrimSpace(folder)), "/")
+}
+
+func (a *App) loadTagsLocked() ([]Tag, error) {
+	var tags []Tag
+	err := readJSONConfig(tagsFile, &tags)
+	return tags, err
+}
+
+func (a *App) saveTagsLocked(tags []Tag) error {
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+	return writeJSONConfig(tagsFile, tags, 0o644)
+}
+
+// ensureTagLocked adds name to the tag list if it isn't there yet
+func (a *App) ensureTagLocked(name string) error {
+	tags, err := a.loadTagsLocked()
+	if err != nil {
+		return err
+	}
+	if slices.ContainsFunc(tags, func(t Tag) bool { return t.Name == name }) {
+		return nil
+	}
+	return a.saveTagsLocked(append(tags, Tag{Name: name}))
+}
+
+// ListTags returns every tag with its usage count, including tags only
+// found on records
+func (a *App) ListTags() ([]TagInfo, error) {
+	a.tagsMu.Lock()
+	tags, err := a.loadTagsLocked()
+	a.tagsMu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[string]int{}
+	if a.history != nil {
+		for _, r := range a.history.list(func(r HistoryRecord) bool { return len(r.Tags) > 0 }) {
+			for _, t := range r.Tags {
+				counts[t]++
+			}
+		}
+	}
+	infos := []TagInfo{}
+	for _, t := range tags {
+		infos = append(infos, TagInfo{Name: t.Name, Color: t.Color, Count: counts[t.Name]})
+		delete(counts, t.Name)
+	}
+	for name, n := range counts {
+		infos = append(infos, TagInfo{Name: name, Count: n})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos, nil
+}
+
+// CreateTag adds a new tag
+func (a *App) CreateTag(name, color string) (Tag, error) {
+	name, err := normalizeTag(name)
+	if err != nil {
+		return Tag{}, err
+	}
+	a.tagsMu.Lock()
+	defer a.tagsMu.Unlock()
+	tags, err := a.loadTagsLocked()
+	if err != nil {
+		return Tag{}, err
+	}
+	if slices.ContainsFunc(tags, func(t Tag) bool { return t.Name == name }) {
+		return Tag{}, fmt.Errorf("tag %q already exists", name)
+	}
+	tag := Tag{Name: name, Color: color}
+	return tag, a.saveTagsLocked(append(tags, tag))
+}
+
+// UpdateTag changes a tag's name or color. Renaming onto an existing tag
+// merges the two.
+func (a *App) UpdateTag(name string, tag Tag) error {
+	from, err := normalizeTag(name)
+	if err != nil {
+		return err
+	}
+	to, err := normalizeTag(tag.Name)
+	if err != nil {
+		return err
+	}
+
+	a.tagsMu.Lock()
+	tags, err := a.loadTagsLocked()
+	if err == nil {
+		tags = slices.DeleteFunc(tags, func(t Tag) bool { return t.Name == from || t.Name == to })
+		err = a.saveTagsLocked(append(tags, Tag{Name: to, Color: tag.Color}))
+	}
+	a.tagsMu.Unlock()
+	if err != nil || from == to {
+		return err
+	}
+	return a.retagRecords(from, to)
+}
+
+// DeleteTag removes a tag and strips it from every record
+func (a *App) DeleteTag(name string) error {
+	name, err := normalizeTag(name)
+	if err != nil {
+		return err
+	}
+	a.tagsMu.Lock()
+	tags, err := a.loadTagsLocked()
+	if err == nil {
+		err = a.saveTagsLocked(slices.DeleteFunc(tags, func(t Tag) bool { return t.Name == name }))
+	}
+	a.tagsMu.Unlock()
+	if err != nil {
+		return err
+	}
+	return a.retagRecords(name, "")
+}
+
+// retagRecords replaces from with to on every record; an empty to removes it
+func (a *App) retagRecords(from, to string) error {
+	if a.history == nil {
+		return nil
+	}
+	for _, r := range a.history.list(func(r HistoryRecord) bool { return slices.Contains(r.Tags, from) }) {
+		if _, err := a.history.update(r.ID, func(r *HistoryRecord) error {
+			r.Tags = slices.DeleteFunc(r.Tags, func(t string) bool { return t == from })
+			if to != "" && !slices.Contains(r.Tags, to) {
+				r.Tags = append(r.Tags, to)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TagRecord adds a tag to a history record or note, creating the tag if
+// needed
+func (a *App) TagRecord(id, tag string) error {
+	if a.history == nil {
+		return errors.New("history is unavailable")
+	}
+	tag, err := normalizeTag(tag)
+	if err != nil {
+		return err
+	}
+	a.tagsMu.Lock()
+	err = a.ensureTagLocked(tag)
+	a.tagsMu.Unlock()
+	if err != nil {
+		return err
+	}
+	_, err = a.history.update(id, func(r *HistoryRecord) error {
+		if !slices.Contains(r.Tags, tag) {
+			r.Tags = append(r.Tags, tag)
+		}
+		return nil
+	})
+	return err
+}
+
+// UntagRecord removes a tag from a history record or note
+func (a *App) UntagRecord(id, tag string) error {
+	if a.history == nil {
+		return errors.New("history is unavailable")
+	}
+	tag, err := normalizeTag(tag)
+	if err != nil {
+		return err
+	}
+	_, err = a.history.update(id, func(r *HistoryRecord) error {
+		r.Tags = slices.DeleteFunc(r.Tags, func(t string) bool { return t == tag })
+		return nil
+	})
+	return err
+}
+
+// SetFolder moves a history record or note into folder; "" is the top level
+func (a *App) SetFolder(id, folder string) error {
+	if a.history == nil {
+		return errors.New("history is unavailable")
+	}
+	_, err := a.history.update(id, func(r *HistoryRecord) error {
+		r.Folder = normalizeFolder(folder)
+		return nil
+	})
+	return err
+}
+
+// ListFolders returns every folder in use, including the parents of
+// nested ones
+func (a *App) ListFolders() []string {
+	folders := []string{}
+	if a.history == nil {
+		return folders
+	}
+	seen := map[string]bool{}
+	for _, r := range a.history.list(func(r HistoryRecord) bool { return r.Folder != "" }) {
+		for f := r.Folder; f != "." && !seen[f]; f = path.Dir(f) {
+			seen[f] = true
+			folders = append(folders, f)
+		}
+	}
+	sort.Strings(folders)
+	return folders
+}
+
+// ListHistory returns records carrying tag, or all records for "", created
+// within dateRange, newest first
+func (a *App) ListHistory(tag string, dateRange DateRange) ([]HistoryRecord, error) {
+	if tag != "" {
+		var err error
+		if tag, err = normalizeTag(tag); err != nil {
+			return nil, err
+		}
+	}
+	return a.queryHistory(func(r HistoryRecord) bool {
+		return (tag == "" || slices.Contains(r.Tags, tag)) && dateRange.contains(r.CreatedAt)
+	}), nil
+}
+
+// ListFolder returns the records in folder and its subfolders created
+// within dateRange, newest first
+func (a *App) ListFolder(folder string, dateRange DateRange) []HistoryRecord {
+	folder = normalizeFolder(folder)
+	return a.queryHistory(func(r HistoryRecord) bool {
+		in := folder == "" || r.Folder == folder || strings.HasPrefix(r.Folder, folder+"/")
+		return in && dateRange.contains(r.CreatedAt)
+	})
+}
+
+// SearchHistory fuzzy-matches query against every record, best first.
+// Repeats of the same text collapse into the newest one, which ranks
+// higher the more often it was used.
+func (a *App) SearchHistory(query string, limit int) []HistoryRecord {
+	var candidates []MatchCandidate
+	byID := map[string]HistoryRecord{}
+	seen := map[string]int{}
+	for _, r := range a.queryHistory(nil) {
+		key := r.Kind + "\x00" + r.Text
+		if i, ok := seen[key]; ok {
+			candidates[i].Frequency++
+			continue
+		}
+		seen[key] = len(candidates)
+		byID[r.ID] = r
+		candidates = append(candidates, MatchCandidate{ID: r.ID, Text: r.Text, Frequency: 1, LastUsed: r.CreatedAt})
+	}
+	out := []HistoryRecord{}
+	for _, m := range fuzzyMatch(query, candidates, MatchOptions{Limit: limit, MaxTypos: 1}) {
+		out = append(out, byID[m.ID])
+	}
+	return out
+}
+
+func (a *App) queryHistory(keep func(HistoryRecord) bool) []HistoryRecord {
+	if a.history == nil {
+		return []HistoryRecord{}
+	}
+	records := a.history.list(keep)
+	slices.Reverse(records)
+	if records == nil {
+		records = []HistoryRecord{}
+	}
+	return records
+}
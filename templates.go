@@ -0,0 +1,151 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+const templatesFile = "templates.json"
+
+// PromptTemplate is a saved prompt. The optional overrides are applied at
+// send time, so a quick fix can use a small model and deep analysis a
+// large one.
+type PromptTemplate struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Prompt string `json:"prompt"`
+	// SystemPrompt is sent ahead of the prompt when set
+	SystemPrompt string `json:"systemPrompt,omitempty"`
+	// BaseURL, KeyID and Model replace the provider settings when set
+	BaseURL string `json:"baseUrl,omitempty"`
+	KeyID   string `json:"keyId,omitempty"`
+	Model   string `json:"model,omitempty"`
+	// Temperature is nil to use the provider's default
+	Temperature *float64 `json:"temperature,omitempty"`
+	MaxTokens   int      `json:"maxTokens,omitempty"`
+}
+
+// promptOverrides change how one prompt is sent; zero fields keep the
+// saved settings
+type promptOverrides struct {
+	BaseURL, KeyID, Model string
+	System                string
+	Temperature           *float64
+	MaxTokens             int
+}
+
+func (t PromptTemplate) overrides() promptOverrides {
+	return promptOverrides{
+		BaseURL:     t.BaseURL,
+		KeyID:       t.KeyID,
+		Model:       t.Model,
+		System:      t.SystemPrompt,
+		Temperature: t.Temperature,
+		MaxTokens:   t.MaxTokens,
+	}
+}
+
+// apply returns cfg with the provider overrides in place
+func (o promptOverrides) apply(cfg ProviderSettings) ProviderSettings {
+	if o.BaseURL != "" {
+		cfg.BaseURL = o.BaseURL
+	}
+	if o.KeyID != "" {
+		cfg.KeyID = o.KeyID
+	}
+	if o.Model != "" {
+		cfg.Model = o.Model
+	}
+	return cfg
+}
+
+// cacheTag folds the parameters into the response cache key
+func (o promptOverrides) cacheTag() string {
+	temp := ""
+	if o.Temperature != nil {
+		temp = fmt.Sprint(*o.Temperature)
+	}
+	return strings.Join([]string{o.System, temp, fmt.Sprint(o.MaxTokens)}, "\x00")
+}
+
+func (a *App) loadTemplatesLocked() ([]PromptTemplate, error) {
+	var templates []PromptTemplate
+	err := readJSONConfig(templatesFile, &templates)
+	return templates, err
+}
+
+// ListTemplates returns the saved templates by name
+func (a *App) ListTemplates() ([]PromptTemplate, error) {
+	a.templatesMu.Lock()
+	defer a.templatesMu.Unlock()
+	templates, err := a.loadTemplatesLocked()
+	if templates == nil {
+		templates = []PromptTemplate{}
+	}
+	return templates, err
+}
+
+// SaveTemplate adds t, or replaces the template with the same ID
+func (a *App) SaveTemplate(t PromptTemplate) (PromptTemplate, error) {
+	t.Name = strings.TrimSpace(t.Name)
+	if t.Name == "" || strings.TrimSpace(t.Prompt) == "" {
+		return PromptTemplate{}, errors.New("a template needs a name and a prompt")
+	}
+	if t.Temperature != nil && (*t.Temperature < 0 || *t.Temperature > 2) {
+		return PromptTemplate{}, errors.New("temperature must be between 0 and 2")
+	}
+	if t.MaxTokens < 0 {
+		return PromptTemplate{}, errors.New("max tokens cannot be negative")
+	}
+	if t.ID == "" {
+		t.ID = newID()
+	}
+
+	a.templatesMu.Lock()
+	defer a.templatesMu.Unlock()
+	templates, err := a.loadTemplatesLocked()
+	if err != nil {
+		return PromptTemplate{}, err
+	}
+	if i := slices.IndexFunc(templates, func(o PromptTemplate) bool { return o.ID == t.ID }); i >= 0 {
+		templates[i] = t
+	} else {
+		templates = append(templates, t)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return t, writeJSONConfig(templatesFile, templates, 0o644)
+}
+
+// DeleteTemplate removes the template with id
+func (a *App) DeleteTemplate(id string) error {
+	a.templatesMu.Lock()
+	defer a.templatesMu.Unlock()
+	templates, err := a.loadTemplatesLocked()
+	if err != nil {
+		return err
+	}
+	n := len(templates)
+	templates = slices.DeleteFunc(templates, func(t PromptTemplate) bool { return t.ID == id })
+	if len(templates) == n {
+		return fmt.Errorf("no template %q", id)
+	}
+	return writeJSONConfig(templatesFile, templates, 0o644)
+}
+
+// SendTemplate sends the template with id like SendPrompt, with its
+// model, parameters and system prompt resolved on top of the settings
+func (a *App) SendTemplate(id string) (string, error) {
+	templates, err := a.ListTemplates()
+	if err != nil {
+		return "", err
+	}
+	i := slices.IndexFunc(templates, func(t PromptTemplate) bool { return t.ID == id })
+	if i < 0 {
+		return "", fmt.Errorf("no template %q", id)
+	}
+	t := templates[i]
+	return a.sendPromptWith(t.Prompt, a.lastShowContext(), t.overrides())
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	themeCSSFile  = "theme.css"
+	themeJSONFile = "theme.json"
+	// themeMaxSize bounds each theme file
+	themeMaxSize = 256 << 10
+)
+
+// Theme is the user's customization from theme.css and theme.json in the
+// config dir. Anything that fails validation is left out and described in
+// Errors, so one typo doesn't throw away the rest of the theme.
+type Theme struct {
+	CSS string `json:"css"`
+	// Variables are CSS custom properties set on the document root
+	Variables map[string]string `json:"variables"`
+	Errors    []string          `json:"errors,omitempty"`
+}
+
+// themeFile is the layout of theme.json
+type themeFile struct {
+	Variables map[string]string `json:"variables"`
+}
+
+var themeVariableName = regexp.MustCompile(`^--[A-Za-z0-9_-]+$`)
+
+// remoteURL catches stylesheets that would make the overlay fetch from the
+// network, which could leak when and where it is opened
+var remoteURL = regexp.MustCompile(`(?i)(@import|url\(\s*['"]?\s*(https?:)?//)`)
+
+// GetTheme loads and validates the current theme files
+func (a *App) GetTheme() Theme {
+	return loadTheme()
+}
+
+func loadTheme() Theme {
+	theme := Theme{Variables: map[string]string{}}
+
+	if data, err := readThemeFile(themeJSONFile); err != nil {
+		theme.Errors = append(theme.Errors, err.Error())
+	} else if data != nil {
+		var f themeFile
+		if err := json.Unmarshal(data, &f); err != nil {
+			theme.Errors = append(theme.Errors, fmt.Sprintf("%s: %v", themeJSONFile, err))
+		}
+		for name, value := range f.Variables {
+			if err := validateThemeVariable(name, value); err != nil {
+				theme.Errors = append(theme.Errors, fmt.Sprintf("%s: %v", themeJSONFile, err))
+				continue
+			}
+			theme.Variables[name] = value
+		}
+	}
+
+	if data, err := readThemeFile(themeCSSFile); err != nil {
+		theme.Errors = append(theme.Errors, err.Error())
+	} else if data != nil {
+		if err := validateThemeCSS(string(data)); err != nil {
+			theme.Errors = append(theme.Errors, fmt.Sprintf("%s: %v", themeCSSFile, err))
+		} else {
+			theme.CSS = string(data)
+		}
+	}
+	return theme
+}
+
+// readThemeFile returns nil for a missing file
+func readThemeFile(name string) ([]byte, error) {
+	path, err := configPath(name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > themeMaxSize {
+		return nil, fmt.Errorf("%s: larger than %d KB", name, themeMaxSize>>10)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if !utf8.Valid(data) {
+		return nil, fmt.Errorf("%s: not valid UTF-8", name)
+	}
+	return data, nil
+}
+
+func validateThemeVariable(name, value string) error {
+	if !themeVariableName.MatchString(name) {
+		return fmt.Errorf("%q is not a CSS custom property name (they start with --)", name)
+	}
+	if len(value) > 500 || strings.ContainsAny(value, ";{}<>") {
+		return fmt.Errorf("invalid value for %s", name)
+	}
+	if remoteURL.MatchString(value) {
+		return fmt.Errorf("%s loads a remote resource", name)
+	}
+	return nil
+}
+
+// validateThemeCSS rejects stylesheets with unbalanced braces, which would
+// swallow the app's own rules, or that load anything remote
+func validateThemeCSS(css string) error {
+	if remoteURL.MatchString(css) {
+		return errors.New("@import and remote url() are not allowed")
+	}
+	depth, line := 0, 1
+	for i := 0; i < len(css); i++ {
+		switch c := css[i]; c {
+		case '\n':
+			line++
+		case '/':
+			if i+1 < len(css) && css[i+1] == '*' {
+				end := strings.Index(css[i+2:], "*/")
+				if end < 0 {
+					return fmt.Errorf("line %d: unterminated comment", line)
+				}
+				line += strings.Count(css[i:i+2+end], "\n")
+				i += end + 3
+			}
+		case '"', '\'':
+			end := strings.IndexByte(css[i+1:], c)
+			if end < 0 {
+				return fmt.Errorf("line %d: unterminated string", line)
+			}
+			i += end + 1
+		case '{':
+			depth++
+		case '}':
+			if depth--; depth < 0 {
+				return fmt.Errorf("line %d: unexpected }", line)
+			}
+		}
+	}
+	if depth != 0 {
+		return errors.New("unclosed {")
+	}
+	return nil
+}
+
+// themeSignature changes whenever either theme file is written, created
+// or removed
+func themeSignature() string {
+	var parts []string
+	for _, name := range []string{themeCSSFile, themeJSONFile} {
+		path, err := configPath(name)
+		if err != nil {
+			continue
+		}
+		if info, err := os.Stat(path); err == nil {
+			parts = append(parts, fmt.Sprintf("%s:%d:%d", name, info.Size(), info.ModTime().UnixNano()))
+		}
+	}
+	return strings.Join(parts, "|")
+}
+
+// watchTheme polls the theme files and pushes the reloaded theme to the
+// frontend when they change, so edits show up without a restart
+func (a *App) watchTheme(ctx context.Context) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	last := themeSignature()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if sig := themeSignature(); sig != last {
+			last = sig
+			eventThemeChanged.emit(a.ctx, loadTheme())
+		}
+	}
+}
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	translateTimeout = time.Minute
+	// localTranslatorFile is not in syncedFiles: the translator runs on
+	// this machine, so only this install may choose it
+	localTranslatorFile = "local-translator.json"
+)
+
+// languageNames maps the ISO 639-1 codes detectLanguage returns to the
+// names sent to the model
+var languageNames = map[string]string{
+	"ar": "Arabic", "de": "German", "el": "Greek", "en": "English",
+	"es": "Spanish", "fr": "French", "he": "Hebrew", "hi": "Hindi",
+	"it": "Italian", "ja": "Japanese", "ko": "Korean", "nl": "Dutch",
+	"pt": "Portuguese", "ru": "Russian", "th": "Thai", "zh": "Chinese",
+}
+
+// stopwords tell Latin-script languages apart; they are common enough
+// that a sentence nearly always contains some
+var stopwords = map[string][]string{
+	"en": {"the", "and", "is", "of", "to", "in", "that", "it", "with", "for", "this", "you"},
+	"es": {"el", "la", "de", "que", "y", "en", "los", "es", "por", "con", "una", "para"},
+	"fr": {"le", "la", "les", "de", "et", "est", "que", "un", "une", "des", "pour", "pas"},
+	"de": {"der", "die", "und", "das", "ist", "nicht", "mit", "ein", "eine", "zu", "ich", "auf"},
+	"it": {"il", "di", "che", "e", "la", "per", "non", "sono", "una", "del", "gli", "con"},
+	"pt": {"o", "de", "que", "e", "do", "da", "em", "um", "uma", "para", "não", "com"},
+	"nl": {"de", "het", "een", "en", "van", "is", "niet", "dat", "op", "te", "ik", "met"},
+}
+
+// TranslationSettings configures the translate quick action. A local
+// translator, if any, is set with SetLocalTranslator.
+type TranslationSettings struct {
+	// Target is a language name or code, e.g. "English" or "es"
+	Target string `json:"target"`
+	// Model replaces the provider's model for translations when set
+	Model string `json:"model,omitempty"`
+	// ReplaceSelection types the translation over the selection instead
+	// of showing it
+	ReplaceSelection bool `json:"replaceSelection"`
+}
+
+// Translation is a translated text; Engine is "local", "provider", or
+// "none" when the text was already in the target language
+type Translation struct {
+	Text   string `json:"text"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Engine string `json:"engine"`
+}
+
+// detectLanguage guesses the language of text from its script, and for
+// Latin script from stopwords. It returns "" when unsure.
+func detectLanguage(text string) string {
+	scripts := map[string]int{}
+	for _, r := range text {
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			scripts["ja"] += 2
+		case unicode.Is(unicode.Han, r):
+			scripts["zh"]++
+		case unicode.Is(unicode.Hangul, r):
+			scripts["ko"]++
+		case unicode.Is(unicode.Cyrillic, r):
+			scripts["ru"]++
+		case unicode.Is(unicode.Arabic, r):
+			scripts["ar"]++
+		case unicode.Is(unicode.Hebrew, r):
+			scripts["he"]++
+		case unicode.Is(unicode.Greek, r):
+			scripts["el"]++
+		case unicode.Is(unicode.Devanagari, r):
+			scripts["hi"]++
+		case unicode.Is(unicode.Thai, r):
+			scripts["th"]++
+		case unicode.Is(unicode.Latin, r):
+			scripts["latin"]++
+		}
+	}
+	best, count := "", 0
+	for s, n := range scripts {
+		if n > count {
+			best, count = s, n
+		}
+	}
+	// Japanese mixes kanji with kana
+	if best == "zh" && scripts["ja"] > 0 {
+		best = "ja"
+	}
+	if best != "latin" {
+		return best
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !unicode.IsLetter(r) })
+	hits := map[string]int{}
+	for _, w := range words {
+		for lang, list := range stopwords {
+			for _, s := range list {
+				if w == s {
+					hits[lang]++
+				}
+			}
+		}
+	}
+	best, count = "", 0
+	for lang, n := range hits {
+		if n > count || (n == count && lang < best) {
+			best, count = lang, n
+		}
+	}
+	// One stray "de" or "is" is not enough to go on
+	if count < 2 && len(words) > 3 {
+		return ""
+	}
+	return best
+}
+
+// languageCode normalizes a name or code to a code, or "" if unknown
+func languageCode(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if _, ok := languageNames[lang]; ok {
+		return lang
+	}
+	for code, name := range languageNames {
+		if strings.ToLower(name) == lang {
+			return code
+		}
+	}
+	return ""
+}
+
+func languageName(lang string) string {
+	if name, ok := languageNames[languageCode(lang)]; ok {
+		return name
+	}
+	return lang
+}
+
+// Translate translates text into target, or the configured target when
+// target is empty, detecting the source language first
+func (a *App) Translate(text, target string) (Translation, error) {
+	cfg := a.GetSettings().Translation
+	if target == "" {
+		target = cfg.Target
+	}
+	if strings.TrimSpace(text) == "" {
+		return Translation{}, errors.New("nothing to translate")
+	}
+	t := Translation{Source: detectLanguage(text), Target: languageName(target)}
+	if t.Source != "" && t.Source == languageCode(target) {
+		t.Text, t.Engine = text, "none"
+		return t, nil
+	}
+
+	ctx, cancel := context.WithTimeout(a.ctx, translateTimeout)
+	defer cancel()
+	command, err := a.GetLocalTranslator()
+	if err != nil {
+		println("Error loading local translator:", err.Error())
+	}
+	if len(command) > 0 {
+		out, err := a.translateLocally(ctx, command, text, t.Source, target)
+		if err == nil {
+			t.Text, t.Engine = out, "local"
+			return t, nil
+		}
+		println("Error running local translator:", err.Error())
+	}
+
+	out, err := a.translateWithProvider(ctx, cfg.Model, text, t.Source, t.Target)
+	if err != nil {
+		return Translation{}, err
+	}
+	t.Text, t.Engine = out, "provider"
+	return t, nil
+}
+
+func (a *App) translateLocally(ctx context.Context, command []string, text, source, target string) (string, error) {
+	if err := a.requirePermission(capShell); err != nil {
+		return "", err
+	}
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Env = append(os.Environ(), "OVERLAE_SOURCE_LANG="+source, "OVERLAE_TARGET_LANG="+languageCode(target))
+	cmd.Stdin = strings.NewReader(text)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	if s := strings.TrimSpace(string(out)); s != "" {
+		return s, nil
+	}
+	return "", errors.New("local translator printed nothing")
+}
+
+func (a *App) translateWithProvider(ctx context.Context, model, text, source, target string) (string, error) {
+	p, cfg, err := a.providerFor(promptOverrides{Model: model}.apply(a.GetSettings().Provider))
+	if err != nil {
+		return "", err
+	}
+	from := "Detect the source language."
+	if source != "" {
+		from = "The text is in " + languageName(source) + "."
+	}
+	req := chatRequest{Model: cfg.Model, Messages: []chatMessage{
+		{Role: "system", Content: "You are a translator. " + from + " Translate the user's text into " + target +
+			". Keep formatting, names and code unchanged. Reply with the translation only."},
+		{Role: "user", Content: text},
+	}}
+	res, err := p.stream(ctx, req, func(string) {})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(res.Text), nil
+}
+
+// TranslateSelection translates the selection of the frontmost app. With
+// ReplaceSelection it pastes the translation over the selection; otherwise
+// the overlay opens with it.
+func (a *App) TranslateSelection() (Translation, error) {
+	if err := a.requirePermission(capKeystrokes); err != nil {
+		return Translation{}, err
+	}
+	text, err := a.captureSelection()
+	if err != nil {
+		return Translation{}, err
+	}
+	if text == "" {
+		return Translation{}, errors.New("nothing is selected")
+	}
+	t, err := a.Translate(text, "")
+	if err != nil {
+		return Translation{}, err
+	}
+	if a.GetSettings().Translation.ReplaceSelection {
+		return t, a.pasteText(a.postProcess(t.Text))
+	}
+	ev := a.showOverlayEvent("hotkey")
+	ev.Variant = "translation"
+	eventShowOverlay.emit(a.ctx, ev)
+	eventTranslation.emit(a.ctx, t)
+	return t, nil
+}
+
+// SetTranslation saves the translate quick action settings
+func (a *App) SetTranslation(cfg TranslationSettings) error {
+	if cfg.Target == "" {
+		return errors.New("choose a target language")
+	}
+	return a.updateSettings(func(s *Settings) { s.Translation = cfg })
+}
+
+// GetLocalTranslator returns the local translator command of this install
+func (a *App) GetLocalTranslator() ([]string, error) {
+	command := []string{}
+	err := readJSONConfig(localTranslatorFile, &command)
+	return command, err
+}
+
+// SetLocalTranslator saves a local translator for this install, e.g.
+// argos-translate; it is never synced. It gets the text on stdin and
+// OVERLAE_SOURCE_LANG/OVERLAE_TARGET_LANG in the environment, prints the
+// translation, and needs the shell permission. The provider is used when
+// there is none or it fails.
+func (a *App) SetLocalTranslator(command []string) error {
+	if len(command) > 0 {
+		if command[0] == "" {
+			return errors.New("the translator command is empty")
+		}
+		if err := a.requirePermission(capShell); err != nil {
+			return err
+		}
+	}
+	return writeJSONConfig(localTranslatorFile, command, 0o600)
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/semver"
+)
+
+const (
+	updateChannelStable = "stable"
+	updateChannelBeta   = "beta"
+
+	updateCheckTimeout = 15 * time.Second
+)
+
+// UpdateSettings chooses which releases CheckForUpdate offers
+type UpdateSettings struct {
+	// Channel is "stable" or "beta"; beta also offers stable releases
+	Channel string `json:"channel"`
+	// FeedURL serves a JSON array of releases
+	FeedURL string `json:"feedUrl,omitempty"`
+	// PinnedVersion holds the app at one version; only that release is
+	// offered, so a pin can also roll back
+	PinnedVersion string `json:"pinnedVersion,omitempty"`
+	// SkippedVersion is a release the user chose not to install
+	SkippedVersion string `json:"skippedVersion,omitempty"`
+	// RolloutBucket places this install in a staged rollout, 1 to 100;
+	// it is picked once at random
+	RolloutBucket int `json:"rolloutBucket,omitempty"`
+}
+
+// Release is one entry in the update feed
+type Release struct {
+	Version string `json:"version"`
+	// Channel is "stable" when empty
+	Channel string `json:"channel,omitempty"`
+	URL     string `json:"url"`
+	Notes   string `json:"notes,omitempty"`
+	// Rollout is the percentage of installs offered the release so far;
+	// zero means everyone
+	Rollout     int       `json:"rollout,omitempty"`
+	PublishedAt time.Time `json:"publishedAt"`
+}
+
+// canonicalVersion adds the "v" semver expects; invalid versions come
+// back empty
+func canonicalVersion(v string) string {
+	if v != "" && !strings.HasPrefix(v, "v") {
+		v = "v" + v
+	}
+	return semver.Canonical(v)
+}
+
+// selectRelease picks the release to offer from the feed, or nil. Without
+// a pin that is the newest release on the channel that is newer than
+// current, reached this install's rollout bucket, and was not skipped.
+func selectRelease(releases []Release, cfg UpdateSettings, current string) *Release {
+	if pin := canonicalVersion(cfg.PinnedVersion); pin != "" {
+		for i, r := range releases {
+			if canonicalVersion(r.Version) == pin && pin != canonicalVersion(current) {
+				return &releases[i]
+			}
+		}
+		return nil
+	}
+	var best *Release
+	for i, r := range releases {
+		v := canonicalVersion(r.Version)
+		switch {
+		case v == "":
+			continue
+		case r.Channel != "" && r.Channel != updateChannelStable && !(cfg.Channel == updateChannelBeta && r.Channel == updateChannelBeta):
+			continue
+		case v == canonicalVersion(cfg.SkippedVersion):
+			continue
+		case r.Rollout > 0 && cfg.RolloutBucket > r.Rollout:
+			continue
+		// A dev build compares below every release
+		case canonicalVersion(current) != "" && semver.Compare(v, canonicalVersion(current)) <= 0:
+			continue
+		}
+		if best == nil || semver.Compare(v, canonicalVersion(best.Version)) > 0 {
+			best = &releases[i]
+		}
+	}
+	return best
+}
+
+// CheckForUpdate fetches the update feed and returns the release to offer,
+// or nil when the app is up to date. Installing is left to the release
+// download; see OpenURL.
+func (a *App) CheckForUpdate() (*Release, error) {
+	cfg := a.GetSettings().Updates
+	if cfg.FeedURL == "" {
+		return nil, errors.New("no update feed configured")
+	}
+	if cfg.RolloutBucket == 0 {
+		cfg.RolloutBucket = rand.IntN(100) + 1
+		if err := a.updateSettings(func(s *Settings) { s.Updates.RolloutBucket = cfg.RolloutBucket }); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(a.ctx, updateCheckTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, cfg.FeedURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("update feed returned %s", resp.Status)
+	}
+	var releases []Release
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, fmt.Errorf("invalid update feed: %w", err)
+	}
+	return selectRelease(releases, cfg, appVersion), nil
+}
+
+// SetUpdateChannel switches between the stable and beta channels
+func (a *App) SetUpdateChannel(channel string) error {
+	if channel != updateChannelStable && channel != updateChannelBeta {
+		return fmt.Errorf("unknown update channel %q", channel)
+	}
+	return a.updateSettings(func(s *Settings) { s.Updates.Channel = channel })
+}
+
+// PinVersion holds the app at version; empty removes the pin
+func (a *App) PinVersion(version string) error {
+	if version != "" && canonicalVersion(version) == "" {
+		return fmt.Errorf("invalid version %q", version)
+	}
+	return a.updateSettings(func(s *Settings) { s.Updates.PinnedVersion = version })
+}
+
+// SkipRelease stops offering version; a newer release is offered as usual
+func (a *App) SkipRelease(version string) error {
+	if canonicalVersion(version) == "" {
+		return fmt.Errorf("invalid version %q", version)
+	}
+	return a.updateSettings(func(s *Settings) { s.Updates.SkippedVersion = version })
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// DailyUsage totals one local calendar day
+type DailyUsage struct {
+	// Date is formatted as 2006-01-02
+	Date      string `json:"date"`
+	Requests  int    `json:"requests"`
+	Responses int    `json:"responses"`
+	// InputChars and OutputChars approximate spend where token counts
+	// are not reported
+	InputChars  int `json:"inputChars"`
+	OutputChars int `json:"outputChars"`
+}
+
+// TemplateUsage is how often one prompt, before context expansion, was sent
+type TemplateUsage struct {
+	Template string    `json:"template"`
+	Count    int       `json:"count"`
+	LastUsed time.Time `json:"lastUsed"`
+}
+
+// ModelLatency is the average time to a complete answer from one model
+type ModelLatency struct {
+	Model     string `json:"model"`
+	AverageMs int64  `json:"averageMs"`
+	Samples   int    `json:"samples"`
+}
+
+// LatencySummary averages response times; cached answers are left out
+type LatencySummary struct {
+	AverageMs int64          `json:"averageMs"`
+	Samples   int            `json:"samples"`
+	Models    []ModelLatency `json:"models"`
+}
+
+const dayFormat = "2006-01-02"
+
+// GetDailyUsage returns one entry per day in dateRange, oldest first and
+// including idle days, so a chart needs no gap filling. An open start
+// begins at the first recorded request.
+func (a *App) GetDailyUsage(dateRange DateRange) []DailyUsage {
+	records := a.queryHistory(func(r HistoryRecord) bool {
+		return (r.Kind == "input" || r.Kind == "response") && dateRange.contains(r.CreatedAt)
+	})
+	days := map[string]*DailyUsage{}
+	for _, r := range records {
+		key := r.CreatedAt.Local().Format(dayFormat)
+		d := days[key]
+		if d == nil {
+			d = &DailyUsage{Date: key}
+			days[key] = d
+		}
+		if r.Kind == "input" {
+			d.Requests++
+			d.InputChars += len(r.Text)
+		} else {
+			d.Responses++
+			d.OutputChars += len(r.Text)
+		}
+	}
+
+	out := []DailyUsage{}
+	from, to := dateRange.From, dateRange.To
+	if from.IsZero() {
+		if len(records) == 0 {
+			return out
+		}
+		// queryHistory is newest first
+		from = records[len(records)-1].CreatedAt
+	}
+	if to.IsZero() {
+		to = time.Now()
+	}
+	from, to = from.Local(), to.Local()
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.Local)
+	for ; day.Before(to); day = day.AddDate(0, 0, 1) {
+		key := day.Format(dayFormat)
+		if d := days[key]; d != nil {
+			out = append(out, *d)
+		} else {
+			out = append(out, DailyUsage{Date: key})
+		}
+	}
+	return out
+}
+
+// GetTopTemplates returns the limit most sent prompts in dateRange, most
+// used first. Prompts differing only in whitespace count as one.
+func (a *App) GetTopTemplates(dateRange DateRange, limit int) []TemplateUsage {
+	counts := map[string]*TemplateUsage{}
+	for _, r := range a.queryHistory(func(r HistoryRecord) bool {
+		return r.Kind == "input" && dateRange.contains(r.CreatedAt)
+	}) {
+		key := strings.Join(strings.Fields(r.Text), " ")
+		if key == "" {
+			continue
+		}
+		if t := counts[key]; t != nil {
+			t.Count++
+			continue
+		}
+		// Newest first, so the first sighting has the latest text and time
+		counts[key] = &TemplateUsage{Template: r.Text, Count: 1, LastUsed: r.CreatedAt}
+	}
+
+	out := make([]TemplateUsage, 0, len(counts))
+	for _, t := range counts {
+		out = append(out, *t)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].LastUsed.After(out[j].LastUsed)
+	})
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+// GetAverageLatency averages the time to a complete answer in dateRange,
+// overall and per model
+func (a *App) GetAverageLatency(dateRange DateRange) LatencySummary {
+	type total struct {
+		ms int64
+		n  int
+	}
+	var all total
+	models := map[string]*total{}
+	for _, r := range a.queryHistory(func(r HistoryRecord) bool {
+		return r.Kind == "response" && !r.Cached && r.DurationMs > 0 && dateRange.contains(r.CreatedAt)
+	}) {
+		all.ms += r.DurationMs
+		all.n++
+		m := models[r.Model]
+		if m == nil {
+			m = &total{}
+			models[r.Model] = m
+		}
+		m.ms += r.DurationMs
+		m.n++
+	}
+
+	summary := LatencySummary{Samples: all.n, Models: []ModelLatency{}}
+	if all.n > 0 {
+		summary.AverageMs = all.ms / int64(all.n)
+	}
+	for model, m := range models {
+		summary.Models = append(summary.Models, ModelLatency{Model: model, AverageMs: m.ms / int64(m.n), Samples: m.n})
+	}
+	sort.Slice(summary.Models, func(i, j int) bool { return summary.Models[i].Samples > summary.Models[j].Samples })
+	return summary
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// wakeWordDetectorFile is not in syncedFiles: the detector runs on this
+// machine, so only this install may choose it
+const wakeWordDetectorFile = "wake-word-detector.json"
+
+// WakeWordSettings configures the opt-in voice trigger. Detection runs in
+// a local program such as an openWakeWord or Porcupine script, set with
+// SetWakeWordDetector, so no audio leaves the machine; overlae only reads
+// its stdout, where every line is one detection.
+type WakeWordSettings struct {
+	Enabled bool   `json:"enabled"`
+	Phrase  string `json:"phrase"`
+}
+
+// WakeWordStatus is the payload of the wake-word-status event. Listening
+// means the microphone is open.
+type WakeWordStatus struct {
+	Listening bool   `json:"listening"`
+	Phrase    string `json:"phrase,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// startWakeWord stops the running detector and starts cfg's if enabled
+func (a *App) startWakeWord(cfg WakeWordSettings) error {
+	a.wakeMu.Lock()
+	defer a.wakeMu.Unlock()
+	if a.wakeStop != nil {
+		a.wakeStop()
+		a.wakeStop = nil
+	}
+	if !cfg.Enabled {
+		a.setWakeStatus(WakeWordStatus{})
+		return nil
+	}
+	command, err := a.GetWakeWordDetector()
+	if err != nil {
+		return err
+	}
+	if len(command) == 0 {
+		return errors.New("no wake word detector configured")
+	}
+	if err := a.requirePermission(capShell); err != nil {
+		return err
+	}
+
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Env = append(os.Environ(), "OVERLAE_WAKE_PHRASE="+cfg.Phrase)
+	if mic, err := a.audioDevice("input"); err == nil && mic.ID != "" {
+		cmd.Env = append(cmd.Env, "OVERLAE_AUDIO_INPUT="+mic.ID)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	var once sync.Once
+	stopped := false
+	a.wakeStop = func() {
+		once.Do(func() {
+			stopped = true
+			_ = cmd.Process.Kill()
+		})
+	}
+	a.setWakeStatus(WakeWordStatus{Listening: true, Phrase: cfg.Phrase})
+
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			if strings.TrimSpace(scanner.Text()) != "" && a.HotkeysEnabled() {
+				a.emitWakeWord()
+			}
+		}
+		err := cmd.Wait()
+		a.wakeMu.Lock()
+		defer a.wakeMu.Unlock()
+		if stopped {
+			return
+		}
+		// The detector died on its own, e.g. the microphone went away
+		a.wakeStop = nil
+		status := WakeWordStatus{Error: "wake word detector exited"}
+		if err != nil {
+			status.Error += ": " + err.Error()
+		}
+		a.setWakeStatus(status)
+	}()
+	return nil
+}
+
+func (a *App) setWakeStatus(s WakeWordStatus) {
+	a.wakeStatus = s
+	a.syncWakeWordMenu(s.Listening)
+	eventWakeWordStatus.emit(a.ctx, s)
+}
+
+// emitWakeWord opens the overlay ready for dictation
+func (a *App) emitWakeWord() {
+	ev := a.showOverlayEvent("voice")
+	ev.Variant, ev.Dictate = "wake-word", true
+	eventShowOverlay.emit(a.ctx, ev)
+}
+
+// GetWakeWordStatus reports whether the microphone is open for the wake
+// word
+func (a *App) GetWakeWordStatus() WakeWordStatus {
+	a.wakeMu.Lock()
+	defer a.wakeMu.Unlock()
+	return a.wakeStatus
+}
+
+// GetWakeWordDetector returns the detector command of this install
+func (a *App) GetWakeWordDetector() ([]string, error) {
+	command := []string{}
+	err := readJSONConfig(wakeWordDetectorFile, &command)
+	return command, err
+}
+
+// SetWakeWordDetector saves the detector and its arguments for this
+// install; they are never synced. The detector gets the chosen microphone
+// in OVERLAE_AUDIO_INPUT and the phrase in OVERLAE_WAKE_PHRASE. A running
+// detector is restarted with the new command.
+func (a *App) SetWakeWordDetector(command []string) error {
+	if len(command) > 0 {
+		if command[0] == "" {
+			return errors.New("the detector command is empty")
+		}
+		if err := a.requirePermission(capShell); err != nil {
+			return err
+		}
+	}
+	if err := writeJSONConfig(wakeWordDetectorFile, command, 0o600); err != nil {
+		return err
+	}
+	if cfg := a.GetSettings().WakeWord; cfg.Enabled {
+		return a.startWakeWord(cfg)
+	}
+	return nil
+}
+
+// SetWakeWord saves the wake word settings and starts or stops listening
+func (a *App) SetWakeWord(cfg WakeWordSettings) error {
+	if err := a.startWakeWord(cfg); err != nil {
+		return err
+	}
+	return a.updateSettings(func(s *Settings) { s.WakeWord = cfg })
+}
+
+// StopWakeWord is the kill switch: it closes the microphone at once and
+// keeps it closed across restarts
+func (a *App) StopWakeWord() error {
+	cfg := a.GetSettings().WakeWord
+	cfg.Enabled = false
+	return a.SetWakeWord(cfg)
+}
+
+// stopWakeWordListener ends the detector on shutdown without changing
+// settings
+func (a *App) stopWakeWordListener() {
+	a.wakeMu.Lock()
+	defer a.wakeMu.Unlock()
+	if a.wakeStop != nil {
+		a.wakeStop()
+		a.wakeStop = nil
+	}
+}
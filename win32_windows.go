@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	user32                       = syscall.NewLazyDLL("user32.dll")
+	kernel32                     = syscall.NewLazyDLL("kernel32.dll")
+	procEnumWindows              = user32.NewProc("EnumWindows")
+	procGetClassNameW            = user32.NewProc("GetClassNameW")
+	procGetWindowThreadProcessId = user32.NewProc("GetWindowThreadProcessId")
+)
+
+// wailsWindowClass is the window class Wails registers for its main window
+const wailsWindowClass = "wailsWindow"
+
+type win32Rect struct {
+	Left, Top, Right, Bottom int32
+}
+
+func (r win32Rect) toRect() Rect {
+	return Rect{X: int(r.Left), Y: int(r.Top), Width: int(r.Right - r.Left), Height: int(r.Bottom - r.Top)}
+}
+
+// The runtime never frees a callback, so EnumWindows is always passed this
+// one and enumWindows hands it the visitor
+var (
+	enumWindowsCallback = syscall.NewCallback(func(hwnd, _ uintptr) uintptr {
+		if enumWindowsVisit(hwnd) {
+			return 1
+		}
+		return 0
+	})
+	enumWindowsMu    sync.Mutex
+	enumWindowsVisit func(hwnd uintptr) bool
+)
+
+// enumWindows calls visit for each top-level window until it returns false
+func enumWindows(visit func(hwnd uintptr) bool) {
+	enumWindowsMu.Lock()
+	defer enumWindowsMu.Unlock()
+	enumWindowsVisit = visit
+	procEnumWindows.Call(enumWindowsCallback, 0)
+	enumWindowsVisit = nil
+}
+
+// ownWindow returns the Wails window of this process, visible or not
+func ownWindow() (uintptr, error) {
+	pid := uint32(os.Getpid())
+	var found uintptr
+	enumWindows(func(hwnd uintptr) bool {
+		var owner uint32
+		procGetWindowThreadProcessId.Call(hwnd, uintptr(unsafe.Pointer(&owner)))
+		if owner != pid {
+			return true
+		}
+		class := make([]uint16, 64)
+		procGetClassNameW.Call(hwnd, uintptr(unsafe.Pointer(&class[0])), uintptr(len(class)))
+		if syscall.UTF16ToString(class) == wailsWindowClass {
+			found = hwnd
+			return false
+		}
+		return true
+	})
+	if found == 0 {
+		return 0, fmt.Errorf("window not found")
+	}
+	return found, nil
+}
@@ -0,0 +1,70 @@
+package main
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Cocoa
+#import <Cocoa/Cocoa.h>
+
+static void ovOnMain(void (^block)(void)) {
+	if ([NSThread isMainThread]) block();
+	else dispatch_sync(dispatch_get_main_queue(), block);
+}
+
+static NSWindow *ovOwnWindow(void) {
+	NSArray<NSWindow *> *windows = [NSApp windows];
+	return windows.count > 0 ? windows[0] : nil;
+}
+
+static int ovGetWindowFrame(int *x, int *y, int *w, int *h) {
+	__block int ok = 0;
+	ovOnMain(^{
+		NSWindow *win = ovOwnWindow();
+		NSArray<NSScreen *> *screens = [NSScreen screens];
+		if (win == nil || screens.count == 0) return;
+		NSRect f = win.frame;
+		*x = f.origin.x;
+		*y = screens[0].frame.size.height - f.origin.y - f.size.height;
+		*w = f.size.width;
+		*h = f.size.height;
+		ok = 1;
+	});
+	return ok;
+}
+
+static int ovSetWindowFrame(int x, int y, int w, int h) {
+	__block int ok = 0;
+	ovOnMain(^{
+		NSWindow *win = ovOwnWindow();
+		NSArray<NSScreen *> *screens = [NSScreen screens];
+		if (win == nil || screens.count == 0) return;
+		CGFloat flippedY = screens[0].frame.size.height - y - h;
+		[win setFrame:NSMakeRect(x, flippedY, w, h) display:YES animate:NO];
+		ok = 1;
+	});
+	return ok;
+}
+*/
+import "C"
+
+import "fmt"
+
+// Window frames and display bounds are in points on macOS, which are
+// already independent of the backing scale factor
+const physicalFrames = false
+
+// ownWindowFrame returns this process' window frame in desktop points
+func ownWindowFrame() (Rect, error) {
+	var x, y, w, h C.int
+	if C.ovGetWindowFrame(&x, &y, &w, &h) == 0 {
+		return Rect{}, fmt.Errorf("window not found")
+	}
+	return Rect{X: int(x), Y: int(y), Width: int(w), Height: int(h)}, nil
+}
+
+// setOwnWindowFrame moves and resizes this process' window
+func setOwnWindowFrame(r Rect) error {
+	if C.ovSetWindowFrame(C.int(r.X), C.int(r.Y), C.int(r.Width), C.int(r.Height)) == 0 {
+		return fmt.Errorf("window not found")
+	}
+	return nil
+}
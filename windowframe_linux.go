@@ -0,0 +1,17 @@
+package main
+
+import "errors"
+
+const physicalFrames = false
+
+var errWindowFrameUnsupported = errors.New("absolute window positioning is not supported on Linux")
+
+// ownWindowFrame is unsupported on Linux; callers fall back to the
+// monitor-relative Wails window API
+func ownWindowFrame() (Rect, error) {
+	return Rect{}, errWindowFrameUnsupported
+}
+
+func setOwnWindowFrame(Rect) error {
+	return errWindowFrameUnsupported
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+var (
+	procGetWindowRect = user32.NewProc("GetWindowRect")
+	procSetWindowPos  = user32.NewProc("SetWindowPos")
+)
+
+// Window frames and display bounds are in physical pixels on Windows, so
+// logical sizes must be scaled by the DPI of the target monitor
+const physicalFrames = true
+
+const (
+	swpNoSize     = 0x0001
+	swpNoZOrder   = 0x0004
+	swpNoActivate = 0x0010
+)
+
+// ownWindowFrame returns this process' window frame in physical pixels
+func ownWindowFrame() (Rect, error) {
+	hwnd, err := ownWindow()
+	if err != nil {
+		return Rect{}, err
+	}
+	var r win32Rect
+	if ok, _, err := procGetWindowRect.Call(hwnd, uintptr(unsafe.Pointer(&r))); ok == 0 {
+		return Rect{}, fmt.Errorf("GetWindowRect: %w", err)
+	}
+	return r.toRect(), nil
+}
+
+// setOwnWindowFrame moves and resizes this process' window. The window is
+// moved onto the target monitor first so the WM_DPICHANGED rescale happens
+// before the final size is applied, otherwise the size r was computed for
+// would be scaled a second time.
+func setOwnWindowFrame(r Rect) error {
+	hwnd, err := ownWindow()
+	if err != nil {
+		return err
+	}
+	if ok, _, err := procSetWindowPos.Call(hwnd, 0, uintptr(r.X), uintptr(r.Y), 0, 0, swpNoSize|swpNoZOrder|swpNoActivate); ok == 0 {
+		return fmt.Errorf("SetWindowPos: %w", err)
+	}
+	if ok, _, err := procSetWindowPos.Call(hwnd, 0, uintptr(r.X), uintptr(r.Y), uintptr(r.Width), uintptr(r.Height), swpNoZOrder|swpNoActivate); ok == 0 {
+		return fmt.Errorf("SetWindowPos: %w", err)
+	}
+	return nil
+}
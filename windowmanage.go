@@ -0,0 +1,122 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+)
+
+// windowActions are the arrangements ArrangeWindow accepts
+var windowActions = []string{"left-half", "right-half", "maximize", "next-monitor"}
+
+// windowActionHotkeys are the optional shortcuts for ArrangeWindow; Shift
+// sets them apart from the overlay's snapping shortcuts
+var windowActionHotkeys = map[string]string{
+	"left-half":    "CmdOrCtrl+Alt+Shift+Left",
+	"right-half":   "CmdOrCtrl+Alt+Shift+Right",
+	"maximize":     "CmdOrCtrl+Alt+Shift+Up",
+	"next-monitor": "CmdOrCtrl+Alt+Shift+N",
+}
+
+// arrangeTarget returns the app whose window is arranged: the frontmost
+// one, or when that is the overlay, the app it was summoned over
+func (a *App) arrangeTarget() (ActiveApp, error) {
+	if app, err := activeApp(); err == nil && app.PID != 0 && app.PID != os.Getpid() {
+		return app, nil
+	}
+	if app := a.lastShowContext().App; app.PID != 0 && app.PID != os.Getpid() {
+		return app, nil
+	}
+	return ActiveApp{}, errors.New("no other app's window to arrange")
+}
+
+// nextDisplay returns the display after d, left to right and top to
+// bottom, wrapping around
+func nextDisplay(displays []Display, d Display) Display {
+	sorted := slices.Clone(displays)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Bounds.X != sorted[j].Bounds.X {
+			return sorted[i].Bounds.X < sorted[j].Bounds.X
+		}
+		return sorted[i].Bounds.Y < sorted[j].Bounds.Y
+	})
+	i := slices.IndexFunc(sorted, func(o Display) bool { return o.ID == d.ID })
+	return sorted[(i+1)%len(sorted)]
+}
+
+// moveToDisplay keeps r's position and size relative to the work area
+// when it moves from one display to another
+func moveToDisplay(r, from, to Rect) Rect {
+	if from.Width <= 0 || from.Height <= 0 {
+		return clampInto(r, to)
+	}
+	scale := func(n, a, b int) int { return n * b / a }
+	moved := Rect{
+		X:      to.X + scale(r.X-from.X, from.Width, to.Width),
+		Y:      to.Y + scale(r.Y-from.Y, from.Height, to.Height),
+		Width:  scale(r.Width, from.Width, to.Width),
+		Height: scale(r.Height, from.Height, to.Height),
+	}
+	return clampInto(moved, to)
+}
+
+// ArrangeWindow moves or resizes the frontmost app's window: "left-half",
+// "right-half", "maximize" or "next-monitor". Run from the overlay it acts
+// on the app the overlay was summoned over and then hides the overlay.
+func (a *App) ArrangeWindow(action string) error {
+	if !slices.Contains(windowActions, action) {
+		return fmt.Errorf("unknown window action %q", action)
+	}
+	if err := a.requirePermission(capWindows); err != nil {
+		return err
+	}
+	app, err := a.arrangeTarget()
+	if err != nil {
+		return err
+	}
+	win, frame, err := appWindowFrame(app.PID)
+	if err != nil {
+		return err
+	}
+	displays, err := listDisplays()
+	if err != nil {
+		return err
+	}
+	display, ok := displayFor(displays, frame)
+	if !ok {
+		return fmt.Errorf("no displays found")
+	}
+
+	switch action {
+	case "maximize":
+		err = maximizeAppWindow(win, display.WorkArea)
+	case "next-monitor":
+		err = setAppWindowFrame(win, moveToDisplay(frame, display.WorkArea, nextDisplay(displays, display).WorkArea))
+	default:
+		side := map[string]string{"left-half": "left", "right-half": "right"}[action]
+		var target Rect
+		if target, err = snapRect(display.WorkArea, frame, side, 0); err == nil {
+			err = setAppWindowFrame(win, target)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if a.GetWindowState().Visible {
+		a.HideOverlay()
+	}
+	return nil
+}
+
+// registerWindowHotkeys registers the optional window arranging shortcuts
+func (a *App) registerWindowHotkeys() {
+	for action, spec := range windowActionHotkeys {
+		_ = a.bindHotkey("window-"+action, spec, func() {
+			if err := a.withPermission(capWindows, func() error { return a.ArrangeWindow(action) }); err != nil {
+				println("Error arranging window:", err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+
+// WindowState is the overlay window state shared by the frontend and the
+// application menu
+type WindowState struct {
+	Visible     bool `json:"visible"`
+	Fullscreen  bool `json:"fullscreen"`
+	AlwaysOnTop bool `json:"alwaysOnTop"`
+}
+
+// updateWindowState applies fn and broadcasts the result if it changed
+func (a *App) updateWindowState(fn func(s *WindowState)) {
+	a.windowMu.Lock()
+	before := a.windowState
+	fn(&a.windowState)
+	s := a.windowState
+	a.windowMu.Unlock()
+
+	if s == before {
+		return
+	}
+	if s.Visible != before.Visible {
+		a.setOverlayHotkeys(s.Visible)
+	}
+	a.syncWindowMenu(s)
+	eventWindowState.emit(a.ctx, s)
+}
+
+// GetWindowState returns the current overlay window state
+func (a *App) GetWindowState() WindowState {
+	a.windowMu.Lock()
+	defer a.windowMu.Unlock()
+	return a.windowState
+}
+
+// MinimizeToTray hides the overlay until the next hotkey or menu action.
+// Wails v2 has no tray icon, so this leaves fullscreen first rather than
+// hiding a fullscreen window, which would leave an empty Space on macOS.
+func (a *App) MinimizeToTray() {
+	if wailsruntime.WindowIsFullscreen(a.ctx) {
+		wailsruntime.WindowUnfullscreen(a.ctx)
+	}
+	a.rememberPlacement()
+	wailsruntime.WindowHide(a.ctx)
+	a.updateWindowState(func(s *WindowState) {
+		s.Visible = false
+		s.Fullscreen = false
+	})
+}
+
+// ToggleFullscreen switches the overlay in or out of fullscreen
+func (a *App) ToggleFullscreen() bool {
+	fullscreen := !wailsruntime.WindowIsFullscreen(a.ctx)
+	if fullscreen {
+		wailsruntime.WindowFullscreen(a.ctx)
+	} else {
+		wailsruntime.WindowUnfullscreen(a.ctx)
+	}
+	a.updateWindowState(func(s *WindowState) { s.Fullscreen = fullscreen })
+	return fullscreen
+}
+
+// SetAlwaysOnTop keeps the overlay above other windows, or lets it drop
+// behind them; the choice also applies the next time it is shown
+func (a *App) SetAlwaysOnTop(onTop bool) {
+	wailsruntime.WindowSetAlwaysOnTop(a.ctx, onTop)
+	a.updateWindowState(func(s *WindowState) { s.AlwaysOnTop = onTop })
+}
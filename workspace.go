@@ -0,0 +1,298 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// workspaceMaxFiles bounds the file map of huge trees
+	workspaceMaxFiles = 5000
+	// workspaceMapTTL is how long a file map is reused before rescanning
+	workspaceMapTTL = 30 * time.Second
+)
+
+// workspaceSkipDirs are never scanned: dependencies, build output and VCS
+// metadata that would drown out the project's own files
+var workspaceSkipDirs = map[string]bool{
+	"node_modules": true, "vendor": true, "dist": true, "build": true,
+	"target": true, "out": true, "bin": true, "obj": true, "__pycache__": true,
+}
+
+// workspaceLanguages maps file extensions to the language they hold
+var workspaceLanguages = map[string]string{
+	".go": "Go", ".rs": "Rust", ".py": "Python", ".rb": "Ruby", ".java": "Java",
+	".kt": "Kotlin", ".swift": "Swift", ".c": "C", ".h": "C", ".cc": "C++",
+	".cpp": "C++", ".hpp": "C++", ".cs": "C#", ".js": "JavaScript",
+	".jsx": "JavaScript", ".mjs": "JavaScript", ".ts": "TypeScript",
+	".tsx": "TypeScript", ".vue": "Vue", ".svelte": "Svelte", ".php": "PHP",
+	".html": "HTML", ".css": "CSS", ".scss": "CSS", ".sql": "SQL",
+	".sh": "Shell", ".md": "Markdown", ".json": "JSON", ".yaml": "YAML",
+	".yml": "YAML", ".toml": "TOML", ".xml": "XML", ".proto": "Protobuf",
+}
+
+// fileVariable matches {{file:path/in/workspace}}
+var fileVariable = regexp.MustCompile(`\{\{file:([^{}]+)\}\}`)
+
+// WorkspaceSettings points code questions at a project folder
+type WorkspaceSettings struct {
+	Root string `json:"root"`
+	// MaxFileKB caps each file pulled into a prompt and MaxContextKB all
+	// of them together, including the tree
+	MaxFileKB    int `json:"maxFileKb"`
+	MaxContextKB int `json:"maxContextKb"`
+}
+
+// WorkspaceFile is one file in the map; Path is slash-separated and
+// relative to the root
+type WorkspaceFile struct {
+	Path     string `json:"path"`
+	Size     int64  `json:"size"`
+	Language string `json:"language,omitempty"`
+}
+
+// LanguageStat sums the files of one language
+type LanguageStat struct {
+	Language string `json:"language"`
+	Files    int    `json:"files"`
+	Bytes    int64  `json:"bytes"`
+}
+
+// WorkspaceMap is a lightweight index of the workspace
+type WorkspaceMap struct {
+	Root      string          `json:"root"`
+	Files     []WorkspaceFile `json:"files"`
+	Languages []LanguageStat  `json:"languages"`
+	// Truncated is set when the tree had more than workspaceMaxFiles
+	Truncated bool      `json:"truncated"`
+	BuiltAt   time.Time `json:"builtAt"`
+}
+
+// SetWorkspace points code questions at root; empty clears it. Reading a
+// project needs the file access permission.
+func (a *App) SetWorkspace(root string) (WorkspaceMap, error) {
+	if root != "" {
+		if err := a.requirePermission(capFiles); err != nil {
+			return WorkspaceMap{}, err
+		}
+		abs, err := filepath.Abs(root)
+		if err != nil {
+			return WorkspaceMap{}, err
+		}
+		info, err := os.Stat(abs)
+		if err != nil {
+			return WorkspaceMap{}, err
+		}
+		if !info.IsDir() {
+			return WorkspaceMap{}, fmt.Errorf("%s is not a folder", abs)
+		}
+		root = abs
+	}
+	if err := a.updateSettings(func(s *Settings) { s.Workspace.Root = root }); err != nil {
+		return WorkspaceMap{}, err
+	}
+	a.workspaceMu.Lock()
+	a.workspaceMap = nil
+	a.workspaceMu.Unlock()
+	if root == "" {
+		return WorkspaceMap{}, nil
+	}
+	return a.GetWorkspaceMap()
+}
+
+// GetWorkspaceMap returns the file tree of the workspace, rescanning it
+// when the last scan is stale
+func (a *App) GetWorkspaceMap() (WorkspaceMap, error) {
+	root := a.GetSettings().Workspace.Root
+	if root == "" {
+		return WorkspaceMap{}, errors.New("no workspace selected")
+	}
+	if err := a.requirePermission(capFiles); err != nil {
+		return WorkspaceMap{}, err
+	}
+	a.workspaceMu.Lock()
+	defer a.workspaceMu.Unlock()
+	if m := a.workspaceMap; m != nil && m.Root == root && time.Since(m.BuiltAt) < workspaceMapTTL {
+		return *m, nil
+	}
+	m, err := scanWorkspace(root)
+	if err != nil {
+		return WorkspaceMap{}, err
+	}
+	a.workspaceMap = &m
+	return m, nil
+}
+
+func scanWorkspace(root string) (WorkspaceMap, error) {
+	m := WorkspaceMap{Root: root, Files: []WorkspaceFile{}, BuiltAt: time.Now()}
+	langs := map[string]*LanguageStat{}
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Unreadable folders are left out rather than failing the scan
+			if d != nil && d.IsDir() && path != root {
+				return fs.SkipDir
+			}
+			return err
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if path != root && (strings.HasPrefix(name, ".") || workspaceSkipDirs[name]) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || strings.HasPrefix(name, ".") {
+			return nil
+		}
+		if len(m.Files) == workspaceMaxFiles {
+			m.Truncated = true
+			return fs.SkipAll
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		rel, _ := filepath.Rel(root, path)
+		f := WorkspaceFile{Path: filepath.ToSlash(rel), Size: info.Size(), Language: workspaceLanguages[strings.ToLower(filepath.Ext(name))]}
+		m.Files = append(m.Files, f)
+		if f.Language != "" {
+			if langs[f.Language] == nil {
+				langs[f.Language] = &LanguageStat{Language: f.Language}
+			}
+			langs[f.Language].Files++
+			langs[f.Language].Bytes += f.Size
+		}
+		return nil
+	})
+	if err != nil {
+		return WorkspaceMap{}, err
+	}
+	for _, l := range langs {
+		m.Languages = append(m.Languages, *l)
+	}
+	sort.Slice(m.Languages, func(i, j int) bool { return m.Languages[i].Bytes > m.Languages[j].Bytes })
+	return m, nil
+}
+
+// workspacePath resolves rel inside the workspace, refusing anything that
+// escapes it, including through symlinks
+func workspacePath(root, rel string) (string, error) {
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", err
+	}
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", err
+	}
+	if r, err := filepath.Rel(realRoot, resolved); err != nil || r == ".." || strings.HasPrefix(r, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside the workspace", rel)
+	}
+	return resolved, nil
+}
+
+// ReadWorkspaceFile returns a text file from the workspace, cut to
+// MaxFileKB; truncated reports whether it was cut
+func (a *App) ReadWorkspaceFile(rel string) (text string, truncated bool, err error) {
+	cfg := a.GetSettings().Workspace
+	if cfg.Root == "" {
+		return "", false, errors.New("no workspace selected")
+	}
+	if err := a.requirePermission(capFiles); err != nil {
+		return "", false, err
+	}
+	return readWorkspaceFile(cfg.Root, rel, cfg.MaxFileKB<<10)
+}
+
+func readWorkspaceFile(root, rel string, limit int) (string, bool, error) {
+	path, err := workspacePath(root, rel)
+	if err != nil {
+		return "", false, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return "", false, err
+	}
+	defer f.Close()
+	buf := make([]byte, limit+1)
+	n, err := io.ReadFull(f, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", false, err
+	}
+	truncated := n > limit
+	data := buf[:min(n, limit)]
+	// Drop a rune split by the cut before checking the encoding
+	for i := 0; truncated && i < utf8.UTFMax && !utf8.Valid(data); i++ {
+		data = data[:len(data)-1]
+	}
+	if !utf8.Valid(data) || strings.ContainsRune(string(data), 0) {
+		return "", false, fmt.Errorf("%s is not a text file", rel)
+	}
+	return string(data), truncated, nil
+}
+
+// workspaceTree renders the file map for {{tree}}, one path and size per
+// line, within limit bytes
+func workspaceTree(m WorkspaceMap, limit int) string {
+	var b strings.Builder
+	for _, f := range m.Files {
+		line := fmt.Sprintf("%s (%d B)\n", f.Path, f.Size)
+		if b.Len()+len(line) > limit {
+			b.WriteString("…\n")
+			break
+		}
+		b.WriteString(line)
+	}
+	return b.String()
+}
+
+// workspaceItems resolves {{tree}} and every {{file:…}} in template,
+// sharing the MaxContextKB budget in the order they appear
+func (a *App) workspaceItems(template string, enabled bool) []ContextItem {
+	files := fileVariable.FindAllStringSubmatch(template, -1)
+	cfg := a.GetSettings().Workspace
+	budget := cfg.MaxContextKB << 10
+	items := []ContextItem{{Variable: "tree", Enabled: enabled}}
+	if !enabled || cfg.Root == "" || a.requirePermission(capFiles) != nil {
+		for _, f := range files {
+			items = append(items, ContextItem{Variable: "file:" + f[1], Enabled: enabled})
+		}
+		return items
+	}
+
+	if strings.Contains(template, "{{tree}}") {
+		if m, err := a.GetWorkspaceMap(); err == nil {
+			items[0].Value = workspaceTree(m, budget)
+			budget -= len(items[0].Value)
+		}
+	}
+	seen := map[string]bool{}
+	for _, f := range files {
+		rel := strings.TrimSpace(f[1])
+		if seen[f[1]] {
+			continue
+		}
+		seen[f[1]] = true
+		item := ContextItem{Variable: "file:" + f[1], Enabled: true}
+		text, truncated, err := readWorkspaceFile(cfg.Root, rel, min(cfg.MaxFileKB<<10, max(budget, 0)))
+		if err == nil && text != "" {
+			if truncated {
+				text += "\n… (truncated)"
+			}
+			item.Value = "```" + rel + "\n" + text + "\n```"
+			budget -= len(text)
+		}
+		items = append(items, item)
+	}
+	return items
+}
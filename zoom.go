@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	zoomMin     = 0.5
+	zoomMax     = 3
+	zoomStep    = 0.1
+	fontSizeMin = 10
+	fontSizeMax = 32
+)
+
+// AppearanceSettings scale the overlay UI. Zoom scales everything; FontSize
+// is the base text size in pixels before zooming.
+type AppearanceSettings struct {
+	Zoom     float64 `json:"zoom"`
+	FontSize int     `json:"fontSize"`
+}
+
+// GetAppearance returns the saved zoom and font size
+func (a *App) GetAppearance() AppearanceSettings {
+	return a.GetSettings().Appearance
+}
+
+// SetZoom sets the UI scale, clamped to 50%-300%, and returns the result
+func (a *App) SetZoom(zoom float64) (AppearanceSettings, error) {
+	zoom = math.Round(min(max(zoom, zoomMin), zoomMax)*100) / 100
+	return a.setAppearance(func(s *AppearanceSettings) { s.Zoom = zoom })
+}
+
+// SetFontSize sets the base text size in pixels
+func (a *App) SetFontSize(px int) (AppearanceSettings, error) {
+	if px < fontSizeMin || px > fontSizeMax {
+		return a.GetAppearance(), fmt.Errorf("font size must be between %d and %d", fontSizeMin, fontSizeMax)
+	}
+	return a.setAppearance(func(s *AppearanceSettings) { s.FontSize = px })
+}
+
+// ZoomIn, ZoomOut and ResetZoom back the Cmd/Ctrl +, - and 0 shortcuts
+func (a *App) ZoomIn() (AppearanceSettings, error) {
+	return a.SetZoom(a.GetAppearance().Zoom + zoomStep)
+}
+
+func (a *App) ZoomOut() (AppearanceSettings, error) {
+	return a.SetZoom(a.GetAppearance().Zoom - zoomStep)
+}
+
+func (a *App) ResetZoom() (AppearanceSettings, error) {
+	return a.SetZoom(1)
+}
+
+func (a *App) setAppearance(fn func(s *AppearanceSettings)) (AppearanceSettings, error) {
+	var appearance AppearanceSettings
+	err := a.updateSettings(func(s *Settings) {
+		fn(&s.Appearance)
+		appearance = s.Appearance
+	})
+	if err == nil {
+		eventZoomChanged.emit(a.ctx, appearance)
+	}
+	return appearance, err
+}
+
+// bindZoomHotkeys registers the zoom shortcuts. They are overlay-only so
+// browsers and editors keep their own zoom keys while the overlay is hidden.
+func (a *App) bindZoomHotkeys() {
+	for name, zoom := range map[string]func() (AppearanceSettings, error){
+		"zoom-in":    a.ZoomIn,
+		"zoom-out":   a.ZoomOut,
+		"zoom-reset": a.ResetZoom,
+	} {
+		_ = a.bindOverlayHotkey(name, defaultHotkeys[name], func() {
+			if _, err := zoom(); err != nil {
+				println("Error saving zoom:", err.Error())
+			}
+		})
+	}
+}